@@ -27,6 +27,9 @@ type DeviceServiceClient interface {
 	Remove(ctx context.Context, in *EntityIdRequest, opts ...grpc.CallOption) (*empty.Empty, error)
 	Update(ctx context.Context, in *UpdateDeviceRequest, opts ...grpc.CallOption) (*empty.Empty, error)
 	Get(ctx context.Context, in *EntityIdRequest, opts ...grpc.CallOption) (*Device, error)
+	ImportDevice(ctx context.Context, in *ImportDeviceRequest, opts ...grpc.CallOption) (*ImportDeviceResponse, error)
+	RotateKey(ctx context.Context, in *RotateDeviceKeyRequest, opts ...grpc.CallOption) (*RotateDeviceKeyResponse, error)
+	GetReconcileReport(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*ReconcileReport, error)
 	GetAll(ctx context.Context, in *GetDevicesRequest, opts ...grpc.CallOption) (*GetDevicesResponse, error)
 }
 
@@ -74,6 +77,33 @@ func (c *deviceServiceClient) Get(ctx context.Context, in *EntityIdRequest, opts
 	return out, nil
 }
 
+func (c *deviceServiceClient) ImportDevice(ctx context.Context, in *ImportDeviceRequest, opts ...grpc.CallOption) (*ImportDeviceResponse, error) {
+	out := new(ImportDeviceResponse)
+	err := c.cc.Invoke(ctx, "/DeviceService/ImportDevice", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deviceServiceClient) RotateKey(ctx context.Context, in *RotateDeviceKeyRequest, opts ...grpc.CallOption) (*RotateDeviceKeyResponse, error) {
+	out := new(RotateDeviceKeyResponse)
+	err := c.cc.Invoke(ctx, "/DeviceService/RotateKey", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deviceServiceClient) GetReconcileReport(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*ReconcileReport, error) {
+	out := new(ReconcileReport)
+	err := c.cc.Invoke(ctx, "/DeviceService/GetReconcileReport", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *deviceServiceClient) GetAll(ctx context.Context, in *GetDevicesRequest, opts ...grpc.CallOption) (*GetDevicesResponse, error) {
 	out := new(GetDevicesResponse)
 	err := c.cc.Invoke(ctx, "/DeviceService/GetAll", in, out, opts...)
@@ -91,6 +121,9 @@ type DeviceServiceServer interface {
 	Remove(context.Context, *EntityIdRequest) (*empty.Empty, error)
 	Update(context.Context, *UpdateDeviceRequest) (*empty.Empty, error)
 	Get(context.Context, *EntityIdRequest) (*Device, error)
+	ImportDevice(context.Context, *ImportDeviceRequest) (*ImportDeviceResponse, error)
+	RotateKey(context.Context, *RotateDeviceKeyRequest) (*RotateDeviceKeyResponse, error)
+	GetReconcileReport(context.Context, *empty.Empty) (*ReconcileReport, error)
 	GetAll(context.Context, *GetDevicesRequest) (*GetDevicesResponse, error)
 	mustEmbedUnimplementedDeviceServiceServer()
 }
@@ -111,6 +144,15 @@ func (UnimplementedDeviceServiceServer) Update(context.Context, *UpdateDeviceReq
 func (UnimplementedDeviceServiceServer) Get(context.Context, *EntityIdRequest) (*Device, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Get not implemented")
 }
+func (UnimplementedDeviceServiceServer) ImportDevice(context.Context, *ImportDeviceRequest) (*ImportDeviceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ImportDevice not implemented")
+}
+func (UnimplementedDeviceServiceServer) RotateKey(context.Context, *RotateDeviceKeyRequest) (*RotateDeviceKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RotateKey not implemented")
+}
+func (UnimplementedDeviceServiceServer) GetReconcileReport(context.Context, *empty.Empty) (*ReconcileReport, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetReconcileReport not implemented")
+}
 func (UnimplementedDeviceServiceServer) GetAll(context.Context, *GetDevicesRequest) (*GetDevicesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetAll not implemented")
 }
@@ -199,6 +241,60 @@ func _DeviceService_Get_Handler(srv interface{}, ctx context.Context, dec func(i
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DeviceService_ImportDevice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportDeviceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeviceServiceServer).ImportDevice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/DeviceService/ImportDevice",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeviceServiceServer).ImportDevice(ctx, req.(*ImportDeviceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeviceService_RotateKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RotateDeviceKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeviceServiceServer).RotateKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/DeviceService/RotateKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeviceServiceServer).RotateKey(ctx, req.(*RotateDeviceKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeviceService_GetReconcileReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeviceServiceServer).GetReconcileReport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/DeviceService/GetReconcileReport",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeviceServiceServer).GetReconcileReport(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _DeviceService_GetAll_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetDevicesRequest)
 	if err := dec(in); err != nil {
@@ -240,6 +336,18 @@ func _DeviceService_GetAll_Handler(srv interface{}, ctx context.Context, dec fun
 			MethodName: "Get",
 			Handler:    _DeviceService_Get_Handler,
 		},
+		{
+			MethodName: "ImportDevice",
+			Handler:    _DeviceService_ImportDevice_Handler,
+		},
+		{
+			MethodName: "RotateKey",
+			Handler:    _DeviceService_RotateKey_Handler,
+		},
+		{
+			MethodName: "GetReconcileReport",
+			Handler:    _DeviceService_GetReconcileReport_Handler,
+		},
 		{
 			MethodName: "GetAll",
 			Handler:    _DeviceService_GetAll_Handler,
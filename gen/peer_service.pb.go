@@ -25,6 +25,116 @@
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type QuotaPeriod int32
+
+const (
+	QuotaPeriod_QUOTA_PERIOD_TOTAL   QuotaPeriod = 0
+	QuotaPeriod_QUOTA_PERIOD_MONTHLY QuotaPeriod = 1
+)
+
+// Enum value maps for QuotaPeriod.
+var (
+	QuotaPeriod_name = map[int32]string{
+		0: "QUOTA_PERIOD_TOTAL",
+		1: "QUOTA_PERIOD_MONTHLY",
+	}
+	QuotaPeriod_value = map[string]int32{
+		"QUOTA_PERIOD_TOTAL":   0,
+		"QUOTA_PERIOD_MONTHLY": 1,
+	}
+)
+
+func (x QuotaPeriod) Enum() *QuotaPeriod {
+	p := new(QuotaPeriod)
+	*p = x
+	return p
+}
+
+func (x QuotaPeriod) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (QuotaPeriod) Descriptor() protoreflect.EnumDescriptor {
+	return file_peer_service_proto_enumTypes[0].Descriptor()
+}
+
+func (QuotaPeriod) Type() protoreflect.EnumType {
+	return &file_peer_service_proto_enumTypes[0]
+}
+
+func (x QuotaPeriod) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use QuotaPeriod.Descriptor instead.
+func (QuotaPeriod) EnumDescriptor() ([]byte, []int) {
+	return file_peer_service_proto_rawDescGZIP(), []int{0}
+}
+
+type PeerEventType int32
+
+const (
+	PeerEventType_PEER_EVENT_TYPE_SNAPSHOT         PeerEventType = 0
+	PeerEventType_PEER_EVENT_TYPE_ADDED            PeerEventType = 1
+	PeerEventType_PEER_EVENT_TYPE_REMOVED          PeerEventType = 2
+	PeerEventType_PEER_EVENT_TYPE_ENABLED          PeerEventType = 3
+	PeerEventType_PEER_EVENT_TYPE_DISABLED         PeerEventType = 4
+	PeerEventType_PEER_EVENT_TYPE_HANDSHAKE        PeerEventType = 5
+	PeerEventType_PEER_EVENT_TYPE_ENDPOINT_CHANGED PeerEventType = 6
+	PeerEventType_PEER_EVENT_TYPE_TRAFFIC          PeerEventType = 7
+)
+
+// Enum value maps for PeerEventType.
+var (
+	PeerEventType_name = map[int32]string{
+		0: "PEER_EVENT_TYPE_SNAPSHOT",
+		1: "PEER_EVENT_TYPE_ADDED",
+		2: "PEER_EVENT_TYPE_REMOVED",
+		3: "PEER_EVENT_TYPE_ENABLED",
+		4: "PEER_EVENT_TYPE_DISABLED",
+		5: "PEER_EVENT_TYPE_HANDSHAKE",
+		6: "PEER_EVENT_TYPE_ENDPOINT_CHANGED",
+		7: "PEER_EVENT_TYPE_TRAFFIC",
+	}
+	PeerEventType_value = map[string]int32{
+		"PEER_EVENT_TYPE_SNAPSHOT":         0,
+		"PEER_EVENT_TYPE_ADDED":            1,
+		"PEER_EVENT_TYPE_REMOVED":          2,
+		"PEER_EVENT_TYPE_ENABLED":          3,
+		"PEER_EVENT_TYPE_DISABLED":         4,
+		"PEER_EVENT_TYPE_HANDSHAKE":        5,
+		"PEER_EVENT_TYPE_ENDPOINT_CHANGED": 6,
+		"PEER_EVENT_TYPE_TRAFFIC":          7,
+	}
+)
+
+func (x PeerEventType) Enum() *PeerEventType {
+	p := new(PeerEventType)
+	*p = x
+	return p
+}
+
+func (x PeerEventType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PeerEventType) Descriptor() protoreflect.EnumDescriptor {
+	return file_peer_service_proto_enumTypes[1].Descriptor()
+}
+
+func (PeerEventType) Type() protoreflect.EnumType {
+	return &file_peer_service_proto_enumTypes[1]
+}
+
+func (x PeerEventType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PeerEventType.Descriptor instead.
+func (PeerEventType) EnumDescriptor() ([]byte, []int) {
+	return file_peer_service_proto_rawDescGZIP(), []int{1}
+}
+
 type Peer struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -48,6 +158,15 @@ type Peer struct {
 	Description         string               `protobuf:"bytes,16,opt,name=description,proto3" json:"description,omitempty"`
 	Dns                 string               `protobuf:"bytes,17,opt,name=dns,proto3" json:"dns,omitempty"`
 	Mtu                 int32                `protobuf:"varint,18,opt,name=mtu,proto3" json:"mtu,omitempty"`
+	HasPrivateKey       bool                 `protobuf:"varint,19,opt,name=has_private_key,json=hasPrivateKey,proto3" json:"has_private_key,omitempty"`
+	ExpiresAt           *timestamp.Timestamp `protobuf:"bytes,20,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	QuotaBytes          int64                `protobuf:"varint,21,opt,name=quota_bytes,json=quotaBytes,proto3" json:"quota_bytes,omitempty"`
+	QuotaPeriod         QuotaPeriod          `protobuf:"varint,22,opt,name=quota_period,json=quotaPeriod,proto3,enum=QuotaPeriod" json:"quota_period,omitempty"`
+	UsageReceiveBytes   int64                `protobuf:"varint,23,opt,name=usage_receive_bytes,json=usageReceiveBytes,proto3" json:"usage_receive_bytes,omitempty"`
+	UsageTransmitBytes  int64                `protobuf:"varint,24,opt,name=usage_transmit_bytes,json=usageTransmitBytes,proto3" json:"usage_transmit_bytes,omitempty"`
+	UsagePeriodStart    *timestamp.Timestamp `protobuf:"bytes,25,opt,name=usage_period_start,json=usagePeriodStart,proto3" json:"usage_period_start,omitempty"`
+	ClientAllowedIps    []string             `protobuf:"bytes,26,rep,name=client_allowed_ips,json=clientAllowedIps,proto3" json:"client_allowed_ips,omitempty"`
+	ExtraRoutes         []string             `protobuf:"bytes,27,rep,name=extra_routes,json=extraRoutes,proto3" json:"extra_routes,omitempty"`
 }
 
 func (x *Peer) Reset() {
@@ -208,23 +327,93 @@ func (x *Peer) GetMtu() int32 {
 	return 0
 }
 
+func (x *Peer) GetHasPrivateKey() bool {
+	if x != nil {
+		return x.HasPrivateKey
+	}
+	return false
+}
+
+func (x *Peer) GetExpiresAt() *timestamp.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *Peer) GetQuotaBytes() int64 {
+	if x != nil {
+		return x.QuotaBytes
+	}
+	return 0
+}
+
+func (x *Peer) GetQuotaPeriod() QuotaPeriod {
+	if x != nil {
+		return x.QuotaPeriod
+	}
+	return QuotaPeriod_QUOTA_PERIOD_TOTAL
+}
+
+func (x *Peer) GetUsageReceiveBytes() int64 {
+	if x != nil {
+		return x.UsageReceiveBytes
+	}
+	return 0
+}
+
+func (x *Peer) GetUsageTransmitBytes() int64 {
+	if x != nil {
+		return x.UsageTransmitBytes
+	}
+	return 0
+}
+
+func (x *Peer) GetUsagePeriodStart() *timestamp.Timestamp {
+	if x != nil {
+		return x.UsagePeriodStart
+	}
+	return nil
+}
+
+func (x *Peer) GetClientAllowedIps() []string {
+	if x != nil {
+		return x.ClientAllowedIps
+	}
+	return nil
+}
+
+func (x *Peer) GetExtraRoutes() []string {
+	if x != nil {
+		return x.ExtraRoutes
+	}
+	return nil
+}
+
 type PeerAbridged struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id                  string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	DeviceId            string   `protobuf:"bytes,2,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
-	Name                string   `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
-	Email               string   `protobuf:"bytes,4,opt,name=email,proto3" json:"email,omitempty"`
-	PublicKey           string   `protobuf:"bytes,5,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
-	PersistentKeepAlive int32    `protobuf:"varint,6,opt,name=persistent_keep_alive,json=persistentKeepAlive,proto3" json:"persistent_keep_alive,omitempty"`
-	AllowedIps          []string `protobuf:"bytes,7,rep,name=allowed_ips,json=allowedIps,proto3" json:"allowed_ips,omitempty"`
-	HasPresharedKey     bool     `protobuf:"varint,8,opt,name=has_preshared_key,json=hasPresharedKey,proto3" json:"has_preshared_key,omitempty"`
-	IsEnabled           bool     `protobuf:"varint,9,opt,name=is_enabled,json=isEnabled,proto3" json:"is_enabled,omitempty"`
-	Description         string   `protobuf:"bytes,10,opt,name=description,proto3" json:"description,omitempty"`
-	Dns                 string   `protobuf:"bytes,11,opt,name=dns,proto3" json:"dns,omitempty"`
-	Mtu                 int32    `protobuf:"varint,12,opt,name=mtu,proto3" json:"mtu,omitempty"`
+	Id                  string               `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	DeviceId            string               `protobuf:"bytes,2,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	Name                string               `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Email               string               `protobuf:"bytes,4,opt,name=email,proto3" json:"email,omitempty"`
+	PublicKey           string               `protobuf:"bytes,5,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
+	PersistentKeepAlive int32                `protobuf:"varint,6,opt,name=persistent_keep_alive,json=persistentKeepAlive,proto3" json:"persistent_keep_alive,omitempty"`
+	AllowedIps          []string             `protobuf:"bytes,7,rep,name=allowed_ips,json=allowedIps,proto3" json:"allowed_ips,omitempty"`
+	HasPresharedKey     bool                 `protobuf:"varint,8,opt,name=has_preshared_key,json=hasPresharedKey,proto3" json:"has_preshared_key,omitempty"`
+	IsEnabled           bool                 `protobuf:"varint,9,opt,name=is_enabled,json=isEnabled,proto3" json:"is_enabled,omitempty"`
+	Description         string               `protobuf:"bytes,10,opt,name=description,proto3" json:"description,omitempty"`
+	Dns                 string               `protobuf:"bytes,11,opt,name=dns,proto3" json:"dns,omitempty"`
+	Mtu                 int32                `protobuf:"varint,12,opt,name=mtu,proto3" json:"mtu,omitempty"`
+	HasPrivateKey       bool                 `protobuf:"varint,13,opt,name=has_private_key,json=hasPrivateKey,proto3" json:"has_private_key,omitempty"`
+	ExpiresAt           *timestamp.Timestamp `protobuf:"bytes,14,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	QuotaBytes          int64                `protobuf:"varint,15,opt,name=quota_bytes,json=quotaBytes,proto3" json:"quota_bytes,omitempty"`
+	QuotaPeriod         QuotaPeriod          `protobuf:"varint,16,opt,name=quota_period,json=quotaPeriod,proto3,enum=QuotaPeriod" json:"quota_period,omitempty"`
+	UsageBytes          int64                `protobuf:"varint,17,opt,name=usage_bytes,json=usageBytes,proto3" json:"usage_bytes,omitempty"`
+	ClientAllowedIps    []string             `protobuf:"bytes,18,rep,name=client_allowed_ips,json=clientAllowedIps,proto3" json:"client_allowed_ips,omitempty"`
+	ExtraRoutes         []string             `protobuf:"bytes,19,rep,name=extra_routes,json=extraRoutes,proto3" json:"extra_routes,omitempty"`
 }
 
 func (x *PeerAbridged) Reset() {
@@ -343,19 +532,75 @@ func (x *PeerAbridged) GetMtu() int32 {
 	return 0
 }
 
+func (x *PeerAbridged) GetHasPrivateKey() bool {
+	if x != nil {
+		return x.HasPrivateKey
+	}
+	return false
+}
+
+func (x *PeerAbridged) GetExpiresAt() *timestamp.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *PeerAbridged) GetQuotaBytes() int64 {
+	if x != nil {
+		return x.QuotaBytes
+	}
+	return 0
+}
+
+func (x *PeerAbridged) GetQuotaPeriod() QuotaPeriod {
+	if x != nil {
+		return x.QuotaPeriod
+	}
+	return QuotaPeriod_QUOTA_PERIOD_TOTAL
+}
+
+func (x *PeerAbridged) GetUsageBytes() int64 {
+	if x != nil {
+		return x.UsageBytes
+	}
+	return 0
+}
+
+func (x *PeerAbridged) GetClientAllowedIps() []string {
+	if x != nil {
+		return x.ClientAllowedIps
+	}
+	return nil
+}
+
+func (x *PeerAbridged) GetExtraRoutes() []string {
+	if x != nil {
+		return x.ExtraRoutes
+	}
+	return nil
+}
+
 type AddPeerRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	DeviceId            string `protobuf:"bytes,1,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
-	AddPresharedKey     bool   `protobuf:"varint,2,opt,name=add_preshared_key,json=addPresharedKey,proto3" json:"add_preshared_key,omitempty"`
-	PersistentKeepAlive int32  `protobuf:"varint,3,opt,name=persistent_keep_alive,json=persistentKeepAlive,proto3" json:"persistent_keep_alive,omitempty"`
-	Description         string `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
-	Name                string `protobuf:"bytes,5,opt,name=name,proto3" json:"name,omitempty"`
-	Email               string `protobuf:"bytes,6,opt,name=email,proto3" json:"email,omitempty"`
-	Dns                 string `protobuf:"bytes,7,opt,name=dns,proto3" json:"dns,omitempty"`
-	Mtu                 int32  `protobuf:"varint,8,opt,name=mtu,proto3" json:"mtu,omitempty"`
+	DeviceId            string               `protobuf:"bytes,1,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	AddPresharedKey     bool                 `protobuf:"varint,2,opt,name=add_preshared_key,json=addPresharedKey,proto3" json:"add_preshared_key,omitempty"`
+	PersistentKeepAlive int32                `protobuf:"varint,3,opt,name=persistent_keep_alive,json=persistentKeepAlive,proto3" json:"persistent_keep_alive,omitempty"`
+	Description         string               `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	Name                string               `protobuf:"bytes,5,opt,name=name,proto3" json:"name,omitempty"`
+	Email               string               `protobuf:"bytes,6,opt,name=email,proto3" json:"email,omitempty"`
+	Dns                 string               `protobuf:"bytes,7,opt,name=dns,proto3" json:"dns,omitempty"`
+	Mtu                 int32                `protobuf:"varint,8,opt,name=mtu,proto3" json:"mtu,omitempty"`
+	PublicKey           string               `protobuf:"bytes,9,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
+	ExpiresAt           *timestamp.Timestamp `protobuf:"bytes,10,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	QuotaBytes          int64                `protobuf:"varint,11,opt,name=quota_bytes,json=quotaBytes,proto3" json:"quota_bytes,omitempty"`
+	QuotaPeriod         QuotaPeriod          `protobuf:"varint,12,opt,name=quota_period,json=quotaPeriod,proto3,enum=QuotaPeriod" json:"quota_period,omitempty"`
+	ClientAllowedIps    []string             `protobuf:"bytes,13,rep,name=client_allowed_ips,json=clientAllowedIps,proto3" json:"client_allowed_ips,omitempty"`
+	ExtraRoutes         []string             `protobuf:"bytes,14,rep,name=extra_routes,json=extraRoutes,proto3" json:"extra_routes,omitempty"`
+	Addresses           []string             `protobuf:"bytes,15,rep,name=addresses,proto3" json:"addresses,omitempty"`
 }
 
 func (x *AddPeerRequest) Reset() {
@@ -446,24 +691,67 @@ func (x *AddPeerRequest) GetMtu() int32 {
 	return 0
 }
 
-type UpdatePeerData struct {
+func (x *AddPeerRequest) GetPublicKey() string {
+	if x != nil {
+		return x.PublicKey
+	}
+	return ""
+}
+
+func (x *AddPeerRequest) GetExpiresAt() *timestamp.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *AddPeerRequest) GetQuotaBytes() int64 {
+	if x != nil {
+		return x.QuotaBytes
+	}
+	return 0
+}
+
+func (x *AddPeerRequest) GetQuotaPeriod() QuotaPeriod {
+	if x != nil {
+		return x.QuotaPeriod
+	}
+	return QuotaPeriod_QUOTA_PERIOD_TOTAL
+}
+
+func (x *AddPeerRequest) GetClientAllowedIps() []string {
+	if x != nil {
+		return x.ClientAllowedIps
+	}
+	return nil
+}
+
+func (x *AddPeerRequest) GetExtraRoutes() []string {
+	if x != nil {
+		return x.ExtraRoutes
+	}
+	return nil
+}
+
+func (x *AddPeerRequest) GetAddresses() []string {
+	if x != nil {
+		return x.Addresses
+	}
+	return nil
+}
+
+type BulkPeerEntry struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id                  string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	AddPresharedKey     bool   `protobuf:"varint,2,opt,name=add_preshared_key,json=addPresharedKey,proto3" json:"add_preshared_key,omitempty"`
-	RemovePresharedKey  bool   `protobuf:"varint,3,opt,name=remove_preshared_key,json=removePresharedKey,proto3" json:"remove_preshared_key,omitempty"`
-	PersistentKeepAlive int32  `protobuf:"varint,4,opt,name=persistent_keep_alive,json=persistentKeepAlive,proto3" json:"persistent_keep_alive,omitempty"`
-	Description         string `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`
-	Name                string `protobuf:"bytes,6,opt,name=name,proto3" json:"name,omitempty"`
-	Email               string `protobuf:"bytes,7,opt,name=email,proto3" json:"email,omitempty"`
-	Dns                 string `protobuf:"bytes,8,opt,name=dns,proto3" json:"dns,omitempty"`
-	Mtu                 int32  `protobuf:"varint,9,opt,name=mtu,proto3" json:"mtu,omitempty"`
+	Name        string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Email       string `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
+	Description string `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
 }
 
-func (x *UpdatePeerData) Reset() {
-	*x = UpdatePeerData{}
+func (x *BulkPeerEntry) Reset() {
+	*x = BulkPeerEntry{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_peer_service_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -471,13 +759,13 @@ func (x *UpdatePeerData) Reset() {
 	}
 }
 
-func (x *UpdatePeerData) String() string {
+func (x *BulkPeerEntry) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdatePeerData) ProtoMessage() {}
+func (*BulkPeerEntry) ProtoMessage() {}
 
-func (x *UpdatePeerData) ProtoReflect() protoreflect.Message {
+func (x *BulkPeerEntry) ProtoReflect() protoreflect.Message {
 	mi := &file_peer_service_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -489,85 +777,46 @@ func (x *UpdatePeerData) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdatePeerData.ProtoReflect.Descriptor instead.
-func (*UpdatePeerData) Descriptor() ([]byte, []int) {
+// Deprecated: Use BulkPeerEntry.ProtoReflect.Descriptor instead.
+func (*BulkPeerEntry) Descriptor() ([]byte, []int) {
 	return file_peer_service_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *UpdatePeerData) GetId() string {
-	if x != nil {
-		return x.Id
-	}
-	return ""
-}
-
-func (x *UpdatePeerData) GetAddPresharedKey() bool {
-	if x != nil {
-		return x.AddPresharedKey
-	}
-	return false
-}
-
-func (x *UpdatePeerData) GetRemovePresharedKey() bool {
-	if x != nil {
-		return x.RemovePresharedKey
-	}
-	return false
-}
-
-func (x *UpdatePeerData) GetPersistentKeepAlive() int32 {
-	if x != nil {
-		return x.PersistentKeepAlive
-	}
-	return 0
-}
-
-func (x *UpdatePeerData) GetDescription() string {
-	if x != nil {
-		return x.Description
-	}
-	return ""
-}
-
-func (x *UpdatePeerData) GetName() string {
+func (x *BulkPeerEntry) GetName() string {
 	if x != nil {
 		return x.Name
 	}
 	return ""
 }
 
-func (x *UpdatePeerData) GetEmail() string {
+func (x *BulkPeerEntry) GetEmail() string {
 	if x != nil {
 		return x.Email
 	}
 	return ""
 }
 
-func (x *UpdatePeerData) GetDns() string {
+func (x *BulkPeerEntry) GetDescription() string {
 	if x != nil {
-		return x.Dns
+		return x.Description
 	}
 	return ""
 }
 
-func (x *UpdatePeerData) GetMtu() int32 {
-	if x != nil {
-		return x.Mtu
-	}
-	return 0
-}
-
-type UpdatePeerRequest struct {
+type BulkAddPeersRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Peer      *UpdatePeerData       `protobuf:"bytes,1,opt,name=peer,proto3" json:"peer,omitempty"`
-	FieldMask *field_mask.FieldMask `protobuf:"bytes,2,opt,name=field_mask,json=fieldMask,proto3" json:"field_mask,omitempty"`
+	DeviceId        string           `protobuf:"bytes,1,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	AddPresharedKey bool             `protobuf:"varint,2,opt,name=add_preshared_key,json=addPresharedKey,proto3" json:"add_preshared_key,omitempty"`
+	Peers           []*BulkPeerEntry `protobuf:"bytes,3,rep,name=peers,proto3" json:"peers,omitempty"`
+	// csv with header row, supported columns are name, email and description
+	Csv string `protobuf:"bytes,4,opt,name=csv,proto3" json:"csv,omitempty"`
 }
 
-func (x *UpdatePeerRequest) Reset() {
-	*x = UpdatePeerRequest{}
+func (x *BulkAddPeersRequest) Reset() {
+	*x = BulkAddPeersRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_peer_service_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -575,13 +824,13 @@ func (x *UpdatePeerRequest) Reset() {
 	}
 }
 
-func (x *UpdatePeerRequest) String() string {
+func (x *BulkAddPeersRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdatePeerRequest) ProtoMessage() {}
+func (*BulkAddPeersRequest) ProtoMessage() {}
 
-func (x *UpdatePeerRequest) ProtoReflect() protoreflect.Message {
+func (x *BulkAddPeersRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_peer_service_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -593,38 +842,50 @@ func (x *UpdatePeerRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdatePeerRequest.ProtoReflect.Descriptor instead.
-func (*UpdatePeerRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use BulkAddPeersRequest.ProtoReflect.Descriptor instead.
+func (*BulkAddPeersRequest) Descriptor() ([]byte, []int) {
 	return file_peer_service_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *UpdatePeerRequest) GetPeer() *UpdatePeerData {
+func (x *BulkAddPeersRequest) GetDeviceId() string {
 	if x != nil {
-		return x.Peer
+		return x.DeviceId
 	}
-	return nil
+	return ""
 }
 
-func (x *UpdatePeerRequest) GetFieldMask() *field_mask.FieldMask {
+func (x *BulkAddPeersRequest) GetAddPresharedKey() bool {
 	if x != nil {
-		return x.FieldMask
+		return x.AddPresharedKey
+	}
+	return false
+}
+
+func (x *BulkAddPeersRequest) GetPeers() []*BulkPeerEntry {
+	if x != nil {
+		return x.Peers
 	}
 	return nil
 }
 
-type GetPeersRequest struct {
+func (x *BulkAddPeersRequest) GetCsv() string {
+	if x != nil {
+		return x.Csv
+	}
+	return ""
+}
+
+type FieldViolation struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Skip     int32  `protobuf:"varint,1,opt,name=skip,proto3" json:"skip,omitempty"`
-	Limit    int32  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
-	Search   string `protobuf:"bytes,3,opt,name=search,proto3" json:"search,omitempty"`
-	DeviceId string `protobuf:"bytes,4,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	Field       string `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
+	Description string `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
 }
 
-func (x *GetPeersRequest) Reset() {
-	*x = GetPeersRequest{}
+func (x *FieldViolation) Reset() {
+	*x = FieldViolation{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_peer_service_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -632,13 +893,13 @@ func (x *GetPeersRequest) Reset() {
 	}
 }
 
-func (x *GetPeersRequest) String() string {
+func (x *FieldViolation) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetPeersRequest) ProtoMessage() {}
+func (*FieldViolation) ProtoMessage() {}
 
-func (x *GetPeersRequest) ProtoReflect() protoreflect.Message {
+func (x *FieldViolation) ProtoReflect() protoreflect.Message {
 	mi := &file_peer_service_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -650,66 +911,645 @@ func (x *GetPeersRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetPeersRequest.ProtoReflect.Descriptor instead.
-func (*GetPeersRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use FieldViolation.ProtoReflect.Descriptor instead.
+func (*FieldViolation) Descriptor() ([]byte, []int) {
 	return file_peer_service_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *GetPeersRequest) GetSkip() int32 {
+func (x *FieldViolation) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *FieldViolation) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+type BulkAddPeerResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Index int32 `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	// peer is not set when the entry failed validation
+	Peer   *Peer             `protobuf:"bytes,2,opt,name=peer,proto3" json:"peer,omitempty"`
+	Errors []*FieldViolation `protobuf:"bytes,3,rep,name=errors,proto3" json:"errors,omitempty"`
+}
+
+func (x *BulkAddPeerResult) Reset() {
+	*x = BulkAddPeerResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_peer_service_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BulkAddPeerResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BulkAddPeerResult) ProtoMessage() {}
+
+func (x *BulkAddPeerResult) ProtoReflect() protoreflect.Message {
+	mi := &file_peer_service_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BulkAddPeerResult.ProtoReflect.Descriptor instead.
+func (*BulkAddPeerResult) Descriptor() ([]byte, []int) {
+	return file_peer_service_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *BulkAddPeerResult) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *BulkAddPeerResult) GetPeer() *Peer {
+	if x != nil {
+		return x.Peer
+	}
+	return nil
+}
+
+func (x *BulkAddPeerResult) GetErrors() []*FieldViolation {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+type BulkAddPeersResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Results []*BulkAddPeerResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	Added   int32                `protobuf:"varint,2,opt,name=added,proto3" json:"added,omitempty"`
+}
+
+func (x *BulkAddPeersResponse) Reset() {
+	*x = BulkAddPeersResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_peer_service_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BulkAddPeersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BulkAddPeersResponse) ProtoMessage() {}
+
+func (x *BulkAddPeersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_peer_service_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BulkAddPeersResponse.ProtoReflect.Descriptor instead.
+func (*BulkAddPeersResponse) Descriptor() ([]byte, []int) {
+	return file_peer_service_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *BulkAddPeersResponse) GetResults() []*BulkAddPeerResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *BulkAddPeersResponse) GetAdded() int32 {
+	if x != nil {
+		return x.Added
+	}
+	return 0
+}
+
+type UpdatePeerData struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id                  string               `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	AddPresharedKey     bool                 `protobuf:"varint,2,opt,name=add_preshared_key,json=addPresharedKey,proto3" json:"add_preshared_key,omitempty"`
+	RemovePresharedKey  bool                 `protobuf:"varint,3,opt,name=remove_preshared_key,json=removePresharedKey,proto3" json:"remove_preshared_key,omitempty"`
+	PersistentKeepAlive int32                `protobuf:"varint,4,opt,name=persistent_keep_alive,json=persistentKeepAlive,proto3" json:"persistent_keep_alive,omitempty"`
+	Description         string               `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`
+	Name                string               `protobuf:"bytes,6,opt,name=name,proto3" json:"name,omitempty"`
+	Email               string               `protobuf:"bytes,7,opt,name=email,proto3" json:"email,omitempty"`
+	Dns                 string               `protobuf:"bytes,8,opt,name=dns,proto3" json:"dns,omitempty"`
+	Mtu                 int32                `protobuf:"varint,9,opt,name=mtu,proto3" json:"mtu,omitempty"`
+	ExpiresAt           *timestamp.Timestamp `protobuf:"bytes,10,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	QuotaBytes          int64                `protobuf:"varint,11,opt,name=quota_bytes,json=quotaBytes,proto3" json:"quota_bytes,omitempty"`
+	QuotaPeriod         QuotaPeriod          `protobuf:"varint,12,opt,name=quota_period,json=quotaPeriod,proto3,enum=QuotaPeriod" json:"quota_period,omitempty"`
+	ClientAllowedIps    []string             `protobuf:"bytes,13,rep,name=client_allowed_ips,json=clientAllowedIps,proto3" json:"client_allowed_ips,omitempty"`
+	ExtraRoutes         []string             `protobuf:"bytes,14,rep,name=extra_routes,json=extraRoutes,proto3" json:"extra_routes,omitempty"`
+	Addresses           []string             `protobuf:"bytes,15,rep,name=addresses,proto3" json:"addresses,omitempty"`
+}
+
+func (x *UpdatePeerData) Reset() {
+	*x = UpdatePeerData{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_peer_service_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdatePeerData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdatePeerData) ProtoMessage() {}
+
+func (x *UpdatePeerData) ProtoReflect() protoreflect.Message {
+	mi := &file_peer_service_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdatePeerData.ProtoReflect.Descriptor instead.
+func (*UpdatePeerData) Descriptor() ([]byte, []int) {
+	return file_peer_service_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *UpdatePeerData) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdatePeerData) GetAddPresharedKey() bool {
+	if x != nil {
+		return x.AddPresharedKey
+	}
+	return false
+}
+
+func (x *UpdatePeerData) GetRemovePresharedKey() bool {
+	if x != nil {
+		return x.RemovePresharedKey
+	}
+	return false
+}
+
+func (x *UpdatePeerData) GetPersistentKeepAlive() int32 {
+	if x != nil {
+		return x.PersistentKeepAlive
+	}
+	return 0
+}
+
+func (x *UpdatePeerData) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *UpdatePeerData) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UpdatePeerData) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *UpdatePeerData) GetDns() string {
+	if x != nil {
+		return x.Dns
+	}
+	return ""
+}
+
+func (x *UpdatePeerData) GetMtu() int32 {
+	if x != nil {
+		return x.Mtu
+	}
+	return 0
+}
+
+func (x *UpdatePeerData) GetExpiresAt() *timestamp.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *UpdatePeerData) GetQuotaBytes() int64 {
+	if x != nil {
+		return x.QuotaBytes
+	}
+	return 0
+}
+
+func (x *UpdatePeerData) GetQuotaPeriod() QuotaPeriod {
+	if x != nil {
+		return x.QuotaPeriod
+	}
+	return QuotaPeriod_QUOTA_PERIOD_TOTAL
+}
+
+func (x *UpdatePeerData) GetClientAllowedIps() []string {
+	if x != nil {
+		return x.ClientAllowedIps
+	}
+	return nil
+}
+
+func (x *UpdatePeerData) GetExtraRoutes() []string {
+	if x != nil {
+		return x.ExtraRoutes
+	}
+	return nil
+}
+
+func (x *UpdatePeerData) GetAddresses() []string {
+	if x != nil {
+		return x.Addresses
+	}
+	return nil
+}
+
+type UpdatePeerRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Peer      *UpdatePeerData       `protobuf:"bytes,1,opt,name=peer,proto3" json:"peer,omitempty"`
+	FieldMask *field_mask.FieldMask `protobuf:"bytes,2,opt,name=field_mask,json=fieldMask,proto3" json:"field_mask,omitempty"`
+}
+
+func (x *UpdatePeerRequest) Reset() {
+	*x = UpdatePeerRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_peer_service_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdatePeerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdatePeerRequest) ProtoMessage() {}
+
+func (x *UpdatePeerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_peer_service_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdatePeerRequest.ProtoReflect.Descriptor instead.
+func (*UpdatePeerRequest) Descriptor() ([]byte, []int) {
+	return file_peer_service_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *UpdatePeerRequest) GetPeer() *UpdatePeerData {
+	if x != nil {
+		return x.Peer
+	}
+	return nil
+}
+
+func (x *UpdatePeerRequest) GetFieldMask() *field_mask.FieldMask {
+	if x != nil {
+		return x.FieldMask
+	}
+	return nil
+}
+
+type GetPeersRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Skip     int32  `protobuf:"varint,1,opt,name=skip,proto3" json:"skip,omitempty"`
+	Limit    int32  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	Search   string `protobuf:"bytes,3,opt,name=search,proto3" json:"search,omitempty"`
+	DeviceId string `protobuf:"bytes,4,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+}
+
+func (x *GetPeersRequest) Reset() {
+	*x = GetPeersRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_peer_service_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetPeersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPeersRequest) ProtoMessage() {}
+
+func (x *GetPeersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_peer_service_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPeersRequest.ProtoReflect.Descriptor instead.
+func (*GetPeersRequest) Descriptor() ([]byte, []int) {
+	return file_peer_service_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetPeersRequest) GetSkip() int32 {
+	if x != nil {
+		return x.Skip
+	}
+	return 0
+}
+
+func (x *GetPeersRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *GetPeersRequest) GetSearch() string {
+	if x != nil {
+		return x.Search
+	}
+	return ""
+}
+
+func (x *GetPeersRequest) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+type GetPeersResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Peers   []*PeerAbridged `protobuf:"bytes,1,rep,name=peers,proto3" json:"peers,omitempty"`
+	Total   int32           `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	HasNext bool            `protobuf:"varint,3,opt,name=has_next,json=hasNext,proto3" json:"has_next,omitempty"`
+}
+
+func (x *GetPeersResponse) Reset() {
+	*x = GetPeersResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_peer_service_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetPeersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPeersResponse) ProtoMessage() {}
+
+func (x *GetPeersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_peer_service_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPeersResponse.ProtoReflect.Descriptor instead.
+func (*GetPeersResponse) Descriptor() ([]byte, []int) {
+	return file_peer_service_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetPeersResponse) GetPeers() []*PeerAbridged {
+	if x != nil {
+		return x.Peers
+	}
+	return nil
+}
+
+func (x *GetPeersResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *GetPeersResponse) GetHasNext() bool {
+	if x != nil {
+		return x.HasNext
+	}
+	return false
+}
+
+type DownloadFileResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Size int64  `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	Data []byte `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+}
+
+func (x *DownloadFileResponse) Reset() {
+	*x = DownloadFileResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_peer_service_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DownloadFileResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DownloadFileResponse) ProtoMessage() {}
+
+func (x *DownloadFileResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_peer_service_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DownloadFileResponse.ProtoReflect.Descriptor instead.
+func (*DownloadFileResponse) Descriptor() ([]byte, []int) {
+	return file_peer_service_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *DownloadFileResponse) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *DownloadFileResponse) GetSize() int64 {
 	if x != nil {
-		return x.Skip
+		return x.Size
 	}
 	return 0
 }
 
-func (x *GetPeersRequest) GetLimit() int32 {
+func (x *DownloadFileResponse) GetData() []byte {
 	if x != nil {
-		return x.Limit
+		return x.Data
 	}
-	return 0
+	return nil
 }
 
-func (x *GetPeersRequest) GetSearch() string {
+type RotatePeerKeysRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// public_key is set when the new private key is generated on the client side
+	PublicKey          string `protobuf:"bytes,2,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
+	RotatePresharedKey bool   `protobuf:"varint,3,opt,name=rotate_preshared_key,json=rotatePresharedKey,proto3" json:"rotate_preshared_key,omitempty"`
+}
+
+func (x *RotatePeerKeysRequest) Reset() {
+	*x = RotatePeerKeysRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_peer_service_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RotatePeerKeysRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RotatePeerKeysRequest) ProtoMessage() {}
+
+func (x *RotatePeerKeysRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_peer_service_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RotatePeerKeysRequest.ProtoReflect.Descriptor instead.
+func (*RotatePeerKeysRequest) Descriptor() ([]byte, []int) {
+	return file_peer_service_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *RotatePeerKeysRequest) GetId() string {
 	if x != nil {
-		return x.Search
+		return x.Id
 	}
 	return ""
 }
 
-func (x *GetPeersRequest) GetDeviceId() string {
+func (x *RotatePeerKeysRequest) GetPublicKey() string {
 	if x != nil {
-		return x.DeviceId
+		return x.PublicKey
 	}
 	return ""
 }
 
-type GetPeersResponse struct {
+func (x *RotatePeerKeysRequest) GetRotatePresharedKey() bool {
+	if x != nil {
+		return x.RotatePresharedKey
+	}
+	return false
+}
+
+type WatchPeerRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Peers   []*PeerAbridged `protobuf:"bytes,1,rep,name=peers,proto3" json:"peers,omitempty"`
-	Total   int32           `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
-	HasNext bool            `protobuf:"varint,3,opt,name=has_next,json=hasNext,proto3" json:"has_next,omitempty"`
+	Id       string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Interval int32  `protobuf:"varint,2,opt,name=interval,proto3" json:"interval,omitempty"`
 }
 
-func (x *GetPeersResponse) Reset() {
-	*x = GetPeersResponse{}
+func (x *WatchPeerRequest) Reset() {
+	*x = WatchPeerRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_peer_service_proto_msgTypes[6]
+		mi := &file_peer_service_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *GetPeersResponse) String() string {
+func (x *WatchPeerRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetPeersResponse) ProtoMessage() {}
+func (*WatchPeerRequest) ProtoMessage() {}
 
-func (x *GetPeersResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_peer_service_proto_msgTypes[6]
+func (x *WatchPeerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_peer_service_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -720,59 +1560,109 @@ func (x *GetPeersResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetPeersResponse.ProtoReflect.Descriptor instead.
-func (*GetPeersResponse) Descriptor() ([]byte, []int) {
-	return file_peer_service_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use WatchPeerRequest.ProtoReflect.Descriptor instead.
+func (*WatchPeerRequest) Descriptor() ([]byte, []int) {
+	return file_peer_service_proto_rawDescGZIP(), []int{14}
 }
 
-func (x *GetPeersResponse) GetPeers() []*PeerAbridged {
+func (x *WatchPeerRequest) GetId() string {
 	if x != nil {
-		return x.Peers
+		return x.Id
 	}
-	return nil
+	return ""
 }
 
-func (x *GetPeersResponse) GetTotal() int32 {
+func (x *WatchPeerRequest) GetInterval() int32 {
 	if x != nil {
-		return x.Total
+		return x.Interval
 	}
 	return 0
 }
 
-func (x *GetPeersResponse) GetHasNext() bool {
+type WatchDevicePeersRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	DeviceId string `protobuf:"bytes,1,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	Interval int32  `protobuf:"varint,2,opt,name=interval,proto3" json:"interval,omitempty"`
+}
+
+func (x *WatchDevicePeersRequest) Reset() {
+	*x = WatchDevicePeersRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_peer_service_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WatchDevicePeersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchDevicePeersRequest) ProtoMessage() {}
+
+func (x *WatchDevicePeersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_peer_service_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchDevicePeersRequest.ProtoReflect.Descriptor instead.
+func (*WatchDevicePeersRequest) Descriptor() ([]byte, []int) {
+	return file_peer_service_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *WatchDevicePeersRequest) GetDeviceId() string {
 	if x != nil {
-		return x.HasNext
+		return x.DeviceId
 	}
-	return false
+	return ""
 }
 
-type DownloadFileResponse struct {
+func (x *WatchDevicePeersRequest) GetInterval() int32 {
+	if x != nil {
+		return x.Interval
+	}
+	return 0
+}
+
+type PeerEvent struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Size int64  `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
-	Data []byte `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	Type               PeerEventType        `protobuf:"varint,1,opt,name=type,proto3,enum=PeerEventType" json:"type,omitempty"`
+	Time               *timestamp.Timestamp `protobuf:"bytes,2,opt,name=time,proto3" json:"time,omitempty"`
+	Peer               *Peer                `protobuf:"bytes,3,opt,name=peer,proto3" json:"peer,omitempty"`
+	ReceiveBytesDelta  int64                `protobuf:"varint,4,opt,name=receive_bytes_delta,json=receiveBytesDelta,proto3" json:"receive_bytes_delta,omitempty"`
+	TransmitBytesDelta int64                `protobuf:"varint,5,opt,name=transmit_bytes_delta,json=transmitBytesDelta,proto3" json:"transmit_bytes_delta,omitempty"`
 }
 
-func (x *DownloadFileResponse) Reset() {
-	*x = DownloadFileResponse{}
+func (x *PeerEvent) Reset() {
+	*x = PeerEvent{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_peer_service_proto_msgTypes[7]
+		mi := &file_peer_service_proto_msgTypes[16]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *DownloadFileResponse) String() string {
+func (x *PeerEvent) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DownloadFileResponse) ProtoMessage() {}
+func (*PeerEvent) ProtoMessage() {}
 
-func (x *DownloadFileResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_peer_service_proto_msgTypes[7]
+func (x *PeerEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_peer_service_proto_msgTypes[16]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -783,32 +1673,46 @@ func (x *DownloadFileResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DownloadFileResponse.ProtoReflect.Descriptor instead.
-func (*DownloadFileResponse) Descriptor() ([]byte, []int) {
-	return file_peer_service_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use PeerEvent.ProtoReflect.Descriptor instead.
+func (*PeerEvent) Descriptor() ([]byte, []int) {
+	return file_peer_service_proto_rawDescGZIP(), []int{16}
 }
 
-func (x *DownloadFileResponse) GetName() string {
+func (x *PeerEvent) GetType() PeerEventType {
 	if x != nil {
-		return x.Name
+		return x.Type
 	}
-	return ""
+	return PeerEventType_PEER_EVENT_TYPE_SNAPSHOT
 }
 
-func (x *DownloadFileResponse) GetSize() int64 {
+func (x *PeerEvent) GetTime() *timestamp.Timestamp {
 	if x != nil {
-		return x.Size
+		return x.Time
 	}
-	return 0
+	return nil
 }
 
-func (x *DownloadFileResponse) GetData() []byte {
+func (x *PeerEvent) GetPeer() *Peer {
 	if x != nil {
-		return x.Data
+		return x.Peer
 	}
 	return nil
 }
 
+func (x *PeerEvent) GetReceiveBytesDelta() int64 {
+	if x != nil {
+		return x.ReceiveBytesDelta
+	}
+	return 0
+}
+
+func (x *PeerEvent) GetTransmitBytesDelta() int64 {
+	if x != nil {
+		return x.TransmitBytesDelta
+	}
+	return 0
+}
+
 var File_peer_service_proto protoreflect.FileDescriptor
 
 var file_peer_service_proto_rawDesc = []byte{
@@ -825,7 +1729,7 @@ func (x *DownloadFileResponse) GetData() []byte {
 	0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1c, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x61, 0x70, 0x69,
 	0x2f, 0x61, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x70, 0x72, 0x6f,
 	0x74, 0x6f, 0x1a, 0x15, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x5f, 0x65, 0x6e, 0x74, 0x69, 0x74,
-	0x69, 0x65, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xd5, 0x04, 0x0a, 0x04, 0x50, 0x65,
+	0x69, 0x65, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x87, 0x08, 0x0a, 0x04, 0x50, 0x65,
 	0x65, 0x72, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02,
 	0x69, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x18,
 	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x49, 0x64, 0x12,
@@ -863,192 +1767,444 @@ func (x *DownloadFileResponse) GetData() []byte {
 	0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x10, 0x0a,
 	0x03, 0x64, 0x6e, 0x73, 0x18, 0x11, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x64, 0x6e, 0x73, 0x12,
 	0x10, 0x0a, 0x03, 0x6d, 0x74, 0x75, 0x18, 0x12, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x6d, 0x74,
-	0x75, 0x22, 0xea, 0x02, 0x0a, 0x0c, 0x50, 0x65, 0x65, 0x72, 0x41, 0x62, 0x72, 0x69, 0x64, 0x67,
-	0x65, 0x64, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02,
-	0x69, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x49, 0x64, 0x12,
-	0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e,
-	0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x18, 0x04, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x75, 0x62,
-	0x6c, 0x69, 0x63, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70,
-	0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x12, 0x32, 0x0a, 0x15, 0x70, 0x65, 0x72, 0x73,
-	0x69, 0x73, 0x74, 0x65, 0x6e, 0x74, 0x5f, 0x6b, 0x65, 0x65, 0x70, 0x5f, 0x61, 0x6c, 0x69, 0x76,
-	0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x13, 0x70, 0x65, 0x72, 0x73, 0x69, 0x73, 0x74,
-	0x65, 0x6e, 0x74, 0x4b, 0x65, 0x65, 0x70, 0x41, 0x6c, 0x69, 0x76, 0x65, 0x12, 0x1f, 0x0a, 0x0b,
-	0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x64, 0x5f, 0x69, 0x70, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28,
-	0x09, 0x52, 0x0a, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x64, 0x49, 0x70, 0x73, 0x12, 0x2a, 0x0a,
-	0x11, 0x68, 0x61, 0x73, 0x5f, 0x70, 0x72, 0x65, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x5f, 0x6b,
-	0x65, 0x79, 0x18, 0x08, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0f, 0x68, 0x61, 0x73, 0x50, 0x72, 0x65,
-	0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x4b, 0x65, 0x79, 0x12, 0x1d, 0x0a, 0x0a, 0x69, 0x73, 0x5f,
-	0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x18, 0x09, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x69,
-	0x73, 0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63,
-	0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64,
-	0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x10, 0x0a, 0x03, 0x64, 0x6e,
-	0x73, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x64, 0x6e, 0x73, 0x12, 0x10, 0x0a, 0x03,
-	0x6d, 0x74, 0x75, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x6d, 0x74, 0x75, 0x22, 0xfd,
-	0x01, 0x0a, 0x0e, 0x41, 0x64, 0x64, 0x50, 0x65, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x12, 0x1b, 0x0a, 0x09, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x49, 0x64, 0x12, 0x2a,
-	0x0a, 0x11, 0x61, 0x64, 0x64, 0x5f, 0x70, 0x72, 0x65, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x5f,
-	0x6b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0f, 0x61, 0x64, 0x64, 0x50, 0x72,
-	0x65, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x4b, 0x65, 0x79, 0x12, 0x32, 0x0a, 0x15, 0x70, 0x65,
+	0x75, 0x12, 0x26, 0x0a, 0x0f, 0x68, 0x61, 0x73, 0x5f, 0x70, 0x72, 0x69, 0x76, 0x61, 0x74, 0x65,
+	0x5f, 0x6b, 0x65, 0x79, 0x18, 0x13, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x68, 0x61, 0x73, 0x50,
+	0x72, 0x69, 0x76, 0x61, 0x74, 0x65, 0x4b, 0x65, 0x79, 0x12, 0x39, 0x0a, 0x0a, 0x65, 0x78, 0x70,
+	0x69, 0x72, 0x65, 0x73, 0x5f, 0x61, 0x74, 0x18, 0x14, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x65, 0x78, 0x70, 0x69, 0x72,
+	0x65, 0x73, 0x41, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x71, 0x75, 0x6f, 0x74, 0x61, 0x5f, 0x62, 0x79,
+	0x74, 0x65, 0x73, 0x18, 0x15, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x71, 0x75, 0x6f, 0x74, 0x61,
+	0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x2f, 0x0a, 0x0c, 0x71, 0x75, 0x6f, 0x74, 0x61, 0x5f, 0x70,
+	0x65, 0x72, 0x69, 0x6f, 0x64, 0x18, 0x16, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0c, 0x2e, 0x51, 0x75,
+	0x6f, 0x74, 0x61, 0x50, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x52, 0x0b, 0x71, 0x75, 0x6f, 0x74, 0x61,
+	0x50, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x12, 0x2e, 0x0a, 0x13, 0x75, 0x73, 0x61, 0x67, 0x65, 0x5f,
+	0x72, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x17, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x11, 0x75, 0x73, 0x61, 0x67, 0x65, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76,
+	0x65, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x30, 0x0a, 0x14, 0x75, 0x73, 0x61, 0x67, 0x65, 0x5f,
+	0x74, 0x72, 0x61, 0x6e, 0x73, 0x6d, 0x69, 0x74, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x18,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x12, 0x75, 0x73, 0x61, 0x67, 0x65, 0x54, 0x72, 0x61, 0x6e, 0x73,
+	0x6d, 0x69, 0x74, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x48, 0x0a, 0x12, 0x75, 0x73, 0x61, 0x67,
+	0x65, 0x5f, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x5f, 0x73, 0x74, 0x61, 0x72, 0x74, 0x18, 0x19,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x52, 0x10, 0x75, 0x73, 0x61, 0x67, 0x65, 0x50, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x53, 0x74, 0x61,
+	0x72, 0x74, 0x12, 0x2c, 0x0a, 0x12, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x61, 0x6c, 0x6c,
+	0x6f, 0x77, 0x65, 0x64, 0x5f, 0x69, 0x70, 0x73, 0x18, 0x1a, 0x20, 0x03, 0x28, 0x09, 0x52, 0x10,
+	0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x41, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x64, 0x49, 0x70, 0x73,
+	0x12, 0x21, 0x0a, 0x0c, 0x65, 0x78, 0x74, 0x72, 0x61, 0x5f, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x73,
+	0x18, 0x1b, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x65, 0x78, 0x74, 0x72, 0x61, 0x52, 0x6f, 0x75,
+	0x74, 0x65, 0x73, 0x22, 0x91, 0x05, 0x0a, 0x0c, 0x50, 0x65, 0x65, 0x72, 0x41, 0x62, 0x72, 0x69,
+	0x64, 0x67, 0x65, 0x64, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x02, 0x69, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x69,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x49,
+	0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x12, 0x1d, 0x0a, 0x0a, 0x70,
+	0x75, 0x62, 0x6c, 0x69, 0x63, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x12, 0x32, 0x0a, 0x15, 0x70, 0x65,
 	0x72, 0x73, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x74, 0x5f, 0x6b, 0x65, 0x65, 0x70, 0x5f, 0x61, 0x6c,
-	0x69, 0x76, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x13, 0x70, 0x65, 0x72, 0x73, 0x69,
-	0x73, 0x74, 0x65, 0x6e, 0x74, 0x4b, 0x65, 0x65, 0x70, 0x41, 0x6c, 0x69, 0x76, 0x65, 0x12, 0x20,
-	0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e,
-	0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
-	0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x18, 0x06, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x12, 0x10, 0x0a, 0x03, 0x64, 0x6e,
-	0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x64, 0x6e, 0x73, 0x12, 0x10, 0x0a, 0x03,
-	0x6d, 0x74, 0x75, 0x18, 0x08, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x6d, 0x74, 0x75, 0x22, 0xa2,
-	0x02, 0x0a, 0x0e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x50, 0x65, 0x65, 0x72, 0x44, 0x61, 0x74,
-	0x61, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69,
-	0x64, 0x12, 0x2a, 0x0a, 0x11, 0x61, 0x64, 0x64, 0x5f, 0x70, 0x72, 0x65, 0x73, 0x68, 0x61, 0x72,
-	0x65, 0x64, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0f, 0x61, 0x64,
-	0x64, 0x50, 0x72, 0x65, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x4b, 0x65, 0x79, 0x12, 0x30, 0x0a,
-	0x14, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x5f, 0x70, 0x72, 0x65, 0x73, 0x68, 0x61, 0x72, 0x65,
-	0x64, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x12, 0x72, 0x65, 0x6d,
-	0x6f, 0x76, 0x65, 0x50, 0x72, 0x65, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x4b, 0x65, 0x79, 0x12,
-	0x32, 0x0a, 0x15, 0x70, 0x65, 0x72, 0x73, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x74, 0x5f, 0x6b, 0x65,
-	0x65, 0x70, 0x5f, 0x61, 0x6c, 0x69, 0x76, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x13,
-	0x70, 0x65, 0x72, 0x73, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x74, 0x4b, 0x65, 0x65, 0x70, 0x41, 0x6c,
-	0x69, 0x76, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69,
-	0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69,
-	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x06, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x6d, 0x61,
-	0x69, 0x6c, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x12,
-	0x10, 0x0a, 0x03, 0x64, 0x6e, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x64, 0x6e,
-	0x73, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x74, 0x75, 0x18, 0x09, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03,
-	0x6d, 0x74, 0x75, 0x22, 0x73, 0x0a, 0x11, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x50, 0x65, 0x65,
-	0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x23, 0x0a, 0x04, 0x70, 0x65, 0x65, 0x72,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x50,
-	0x65, 0x65, 0x72, 0x44, 0x61, 0x74, 0x61, 0x52, 0x04, 0x70, 0x65, 0x65, 0x72, 0x12, 0x39, 0x0a,
-	0x0a, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x5f, 0x6d, 0x61, 0x73, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x69, 0x76, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x13, 0x70, 0x65, 0x72, 0x73, 0x69,
+	0x73, 0x74, 0x65, 0x6e, 0x74, 0x4b, 0x65, 0x65, 0x70, 0x41, 0x6c, 0x69, 0x76, 0x65, 0x12, 0x1f,
+	0x0a, 0x0b, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x64, 0x5f, 0x69, 0x70, 0x73, 0x18, 0x07, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x0a, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x64, 0x49, 0x70, 0x73, 0x12,
+	0x2a, 0x0a, 0x11, 0x68, 0x61, 0x73, 0x5f, 0x70, 0x72, 0x65, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64,
+	0x5f, 0x6b, 0x65, 0x79, 0x18, 0x08, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0f, 0x68, 0x61, 0x73, 0x50,
+	0x72, 0x65, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x4b, 0x65, 0x79, 0x12, 0x1d, 0x0a, 0x0a, 0x69,
+	0x73, 0x5f, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x18, 0x09, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x09, 0x69, 0x73, 0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65,
+	0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x10, 0x0a, 0x03,
+	0x64, 0x6e, 0x73, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x64, 0x6e, 0x73, 0x12, 0x10,
+	0x0a, 0x03, 0x6d, 0x74, 0x75, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x6d, 0x74, 0x75,
+	0x12, 0x26, 0x0a, 0x0f, 0x68, 0x61, 0x73, 0x5f, 0x70, 0x72, 0x69, 0x76, 0x61, 0x74, 0x65, 0x5f,
+	0x6b, 0x65, 0x79, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x68, 0x61, 0x73, 0x50, 0x72,
+	0x69, 0x76, 0x61, 0x74, 0x65, 0x4b, 0x65, 0x79, 0x12, 0x39, 0x0a, 0x0a, 0x65, 0x78, 0x70, 0x69,
+	0x72, 0x65, 0x73, 0x5f, 0x61, 0x74, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65,
+	0x73, 0x41, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x71, 0x75, 0x6f, 0x74, 0x61, 0x5f, 0x62, 0x79, 0x74,
+	0x65, 0x73, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x71, 0x75, 0x6f, 0x74, 0x61, 0x42,
+	0x79, 0x74, 0x65, 0x73, 0x12, 0x2f, 0x0a, 0x0c, 0x71, 0x75, 0x6f, 0x74, 0x61, 0x5f, 0x70, 0x65,
+	0x72, 0x69, 0x6f, 0x64, 0x18, 0x10, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0c, 0x2e, 0x51, 0x75, 0x6f,
+	0x74, 0x61, 0x50, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x52, 0x0b, 0x71, 0x75, 0x6f, 0x74, 0x61, 0x50,
+	0x65, 0x72, 0x69, 0x6f, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x75, 0x73, 0x61, 0x67, 0x65, 0x5f, 0x62,
+	0x79, 0x74, 0x65, 0x73, 0x18, 0x11, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x75, 0x73, 0x61, 0x67,
+	0x65, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x2c, 0x0a, 0x12, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74,
+	0x5f, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x64, 0x5f, 0x69, 0x70, 0x73, 0x18, 0x12, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x10, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x41, 0x6c, 0x6c, 0x6f, 0x77, 0x65,
+	0x64, 0x49, 0x70, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x65, 0x78, 0x74, 0x72, 0x61, 0x5f, 0x72, 0x6f,
+	0x75, 0x74, 0x65, 0x73, 0x18, 0x13, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x65, 0x78, 0x74, 0x72,
+	0x61, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x73, 0x22, 0x98, 0x04, 0x0a, 0x0e, 0x41, 0x64, 0x64, 0x50,
+	0x65, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x64, 0x65,
+	0x76, 0x69, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x64,
+	0x65, 0x76, 0x69, 0x63, 0x65, 0x49, 0x64, 0x12, 0x2a, 0x0a, 0x11, 0x61, 0x64, 0x64, 0x5f, 0x70,
+	0x72, 0x65, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x0f, 0x61, 0x64, 0x64, 0x50, 0x72, 0x65, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64,
+	0x4b, 0x65, 0x79, 0x12, 0x32, 0x0a, 0x15, 0x70, 0x65, 0x72, 0x73, 0x69, 0x73, 0x74, 0x65, 0x6e,
+	0x74, 0x5f, 0x6b, 0x65, 0x65, 0x70, 0x5f, 0x61, 0x6c, 0x69, 0x76, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x13, 0x70, 0x65, 0x72, 0x73, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x74, 0x4b, 0x65,
+	0x65, 0x70, 0x41, 0x6c, 0x69, 0x76, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72,
+	0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65,
+	0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a,
+	0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x6d,
+	0x61, 0x69, 0x6c, 0x12, 0x10, 0x0a, 0x03, 0x64, 0x6e, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x64, 0x6e, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x74, 0x75, 0x18, 0x08, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x03, 0x6d, 0x74, 0x75, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x75, 0x62, 0x6c, 0x69,
+	0x63, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x75, 0x62,
+	0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x12, 0x39, 0x0a, 0x0a, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65,
+	0x73, 0x5f, 0x61, 0x74, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d,
+	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x41,
+	0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x71, 0x75, 0x6f, 0x74, 0x61, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73,
+	0x18, 0x0b, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x71, 0x75, 0x6f, 0x74, 0x61, 0x42, 0x79, 0x74,
+	0x65, 0x73, 0x12, 0x2f, 0x0a, 0x0c, 0x71, 0x75, 0x6f, 0x74, 0x61, 0x5f, 0x70, 0x65, 0x72, 0x69,
+	0x6f, 0x64, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0c, 0x2e, 0x51, 0x75, 0x6f, 0x74, 0x61,
+	0x50, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x52, 0x0b, 0x71, 0x75, 0x6f, 0x74, 0x61, 0x50, 0x65, 0x72,
+	0x69, 0x6f, 0x64, 0x12, 0x2c, 0x0a, 0x12, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x61, 0x6c,
+	0x6c, 0x6f, 0x77, 0x65, 0x64, 0x5f, 0x69, 0x70, 0x73, 0x18, 0x0d, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x10, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x41, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x64, 0x49, 0x70,
+	0x73, 0x12, 0x21, 0x0a, 0x0c, 0x65, 0x78, 0x74, 0x72, 0x61, 0x5f, 0x72, 0x6f, 0x75, 0x74, 0x65,
+	0x73, 0x18, 0x0e, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x65, 0x78, 0x74, 0x72, 0x61, 0x52, 0x6f,
+	0x75, 0x74, 0x65, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65,
+	0x73, 0x18, 0x0f, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73,
+	0x65, 0x73, 0x22, 0x5b, 0x0a, 0x0d, 0x42, 0x75, 0x6c, 0x6b, 0x50, 0x65, 0x65, 0x72, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x12, 0x20, 0x0a,
+	0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x22,
+	0x96, 0x01, 0x0a, 0x13, 0x42, 0x75, 0x6c, 0x6b, 0x41, 0x64, 0x64, 0x50, 0x65, 0x65, 0x72, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x64, 0x65, 0x76, 0x69, 0x63,
+	0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x64, 0x65, 0x76, 0x69,
+	0x63, 0x65, 0x49, 0x64, 0x12, 0x2a, 0x0a, 0x11, 0x61, 0x64, 0x64, 0x5f, 0x70, 0x72, 0x65, 0x73,
+	0x68, 0x61, 0x72, 0x65, 0x64, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x0f, 0x61, 0x64, 0x64, 0x50, 0x72, 0x65, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x4b, 0x65, 0x79,
+	0x12, 0x24, 0x0a, 0x05, 0x70, 0x65, 0x65, 0x72, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x0e, 0x2e, 0x42, 0x75, 0x6c, 0x6b, 0x50, 0x65, 0x65, 0x72, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52,
+	0x05, 0x70, 0x65, 0x65, 0x72, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x63, 0x73, 0x76, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x03, 0x63, 0x73, 0x76, 0x22, 0x48, 0x0a, 0x0e, 0x46, 0x69, 0x65, 0x6c,
+	0x64, 0x56, 0x69, 0x6f, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x69,
+	0x65, 0x6c, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64,
+	0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69,
+	0x6f, 0x6e, 0x22, 0x6d, 0x0a, 0x11, 0x42, 0x75, 0x6c, 0x6b, 0x41, 0x64, 0x64, 0x50, 0x65, 0x65,
+	0x72, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x19, 0x0a,
+	0x04, 0x70, 0x65, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x05, 0x2e, 0x50, 0x65,
+	0x65, 0x72, 0x52, 0x04, 0x70, 0x65, 0x65, 0x72, 0x12, 0x27, 0x0a, 0x06, 0x65, 0x72, 0x72, 0x6f,
+	0x72, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64,
+	0x56, 0x69, 0x6f, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x06, 0x65, 0x72, 0x72, 0x6f, 0x72,
+	0x73, 0x22, 0x5a, 0x0a, 0x14, 0x42, 0x75, 0x6c, 0x6b, 0x41, 0x64, 0x64, 0x50, 0x65, 0x65, 0x72,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2c, 0x0a, 0x07, 0x72, 0x65, 0x73,
+	0x75, 0x6c, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x42, 0x75, 0x6c,
+	0x6b, 0x41, 0x64, 0x64, 0x50, 0x65, 0x65, 0x72, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x07,
+	0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x61, 0x64, 0x64, 0x65, 0x64,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x61, 0x64, 0x64, 0x65, 0x64, 0x22, 0x9e, 0x04,
+	0x0a, 0x0e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x50, 0x65, 0x65, 0x72, 0x44, 0x61, 0x74, 0x61,
+	0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64,
+	0x12, 0x2a, 0x0a, 0x11, 0x61, 0x64, 0x64, 0x5f, 0x70, 0x72, 0x65, 0x73, 0x68, 0x61, 0x72, 0x65,
+	0x64, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0f, 0x61, 0x64, 0x64,
+	0x50, 0x72, 0x65, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x4b, 0x65, 0x79, 0x12, 0x30, 0x0a, 0x14,
+	0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x5f, 0x70, 0x72, 0x65, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64,
+	0x5f, 0x6b, 0x65, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x12, 0x72, 0x65, 0x6d, 0x6f,
+	0x76, 0x65, 0x50, 0x72, 0x65, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x4b, 0x65, 0x79, 0x12, 0x32,
+	0x0a, 0x15, 0x70, 0x65, 0x72, 0x73, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x74, 0x5f, 0x6b, 0x65, 0x65,
+	0x70, 0x5f, 0x61, 0x6c, 0x69, 0x76, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x13, 0x70,
+	0x65, 0x72, 0x73, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x74, 0x4b, 0x65, 0x65, 0x70, 0x41, 0x6c, 0x69,
+	0x76, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f,
+	0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x6d, 0x61, 0x69,
+	0x6c, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x12, 0x10,
+	0x0a, 0x03, 0x64, 0x6e, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x64, 0x6e, 0x73,
+	0x12, 0x10, 0x0a, 0x03, 0x6d, 0x74, 0x75, 0x18, 0x09, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x6d,
+	0x74, 0x75, 0x12, 0x39, 0x0a, 0x0a, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x5f, 0x61, 0x74,
+	0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x52, 0x09, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x41, 0x74, 0x12, 0x1f, 0x0a,
+	0x0b, 0x71, 0x75, 0x6f, 0x74, 0x61, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x0b, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x0a, 0x71, 0x75, 0x6f, 0x74, 0x61, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x2f,
+	0x0a, 0x0c, 0x71, 0x75, 0x6f, 0x74, 0x61, 0x5f, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x18, 0x0c,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x0c, 0x2e, 0x51, 0x75, 0x6f, 0x74, 0x61, 0x50, 0x65, 0x72, 0x69,
+	0x6f, 0x64, 0x52, 0x0b, 0x71, 0x75, 0x6f, 0x74, 0x61, 0x50, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x12,
+	0x2c, 0x0a, 0x12, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x65,
+	0x64, 0x5f, 0x69, 0x70, 0x73, 0x18, 0x0d, 0x20, 0x03, 0x28, 0x09, 0x52, 0x10, 0x63, 0x6c, 0x69,
+	0x65, 0x6e, 0x74, 0x41, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x64, 0x49, 0x70, 0x73, 0x12, 0x21, 0x0a,
+	0x0c, 0x65, 0x78, 0x74, 0x72, 0x61, 0x5f, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x73, 0x18, 0x0e, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x0b, 0x65, 0x78, 0x74, 0x72, 0x61, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x73,
+	0x12, 0x1c, 0x0a, 0x09, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x18, 0x0f, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x09, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x22, 0x73,
+	0x0a, 0x11, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x50, 0x65, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x23, 0x0a, 0x04, 0x70, 0x65, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x0f, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x50, 0x65, 0x65, 0x72, 0x44, 0x61,
+	0x74, 0x61, 0x52, 0x04, 0x70, 0x65, 0x65, 0x72, 0x12, 0x39, 0x0a, 0x0a, 0x66, 0x69, 0x65, 0x6c,
+	0x64, 0x5f, 0x6d, 0x61, 0x73, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x46,
+	0x69, 0x65, 0x6c, 0x64, 0x4d, 0x61, 0x73, 0x6b, 0x52, 0x09, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x4d,
+	0x61, 0x73, 0x6b, 0x22, 0x70, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x50, 0x65, 0x65, 0x72, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x6b, 0x69, 0x70, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x73, 0x6b, 0x69, 0x70, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69,
+	0x6d, 0x69, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74,
+	0x12, 0x16, 0x0a, 0x06, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x12, 0x1b, 0x0a, 0x09, 0x64, 0x65, 0x76, 0x69,
+	0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x64, 0x65, 0x76,
+	0x69, 0x63, 0x65, 0x49, 0x64, 0x22, 0x68, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x50, 0x65, 0x65, 0x72,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x23, 0x0a, 0x05, 0x70, 0x65, 0x65,
+	0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x50, 0x65, 0x65, 0x72, 0x41,
+	0x62, 0x72, 0x69, 0x64, 0x67, 0x65, 0x64, 0x52, 0x05, 0x70, 0x65, 0x65, 0x72, 0x73, 0x12, 0x14,
+	0x0a, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x74,
+	0x6f, 0x74, 0x61, 0x6c, 0x12, 0x19, 0x0a, 0x08, 0x68, 0x61, 0x73, 0x5f, 0x6e, 0x65, 0x78, 0x74,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x68, 0x61, 0x73, 0x4e, 0x65, 0x78, 0x74, 0x22,
+	0x52, 0x0a, 0x14, 0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x46, 0x69, 0x6c, 0x65, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x73,
+	0x69, 0x7a, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x12,
+	0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64,
+	0x61, 0x74, 0x61, 0x22, 0x78, 0x0a, 0x15, 0x52, 0x6f, 0x74, 0x61, 0x74, 0x65, 0x50, 0x65, 0x65,
+	0x72, 0x4b, 0x65, 0x79, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1d, 0x0a, 0x0a,
+	0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x12, 0x30, 0x0a, 0x14, 0x72,
+	0x6f, 0x74, 0x61, 0x74, 0x65, 0x5f, 0x70, 0x72, 0x65, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x5f,
+	0x6b, 0x65, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x12, 0x72, 0x6f, 0x74, 0x61, 0x74,
+	0x65, 0x50, 0x72, 0x65, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x4b, 0x65, 0x79, 0x22, 0x3e, 0x0a,
+	0x10, 0x57, 0x61, 0x74, 0x63, 0x68, 0x50, 0x65, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69,
+	0x64, 0x12, 0x1a, 0x0a, 0x08, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x08, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x22, 0x52, 0x0a,
+	0x17, 0x57, 0x61, 0x74, 0x63, 0x68, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x50, 0x65, 0x65, 0x72,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x64, 0x65, 0x76, 0x69,
+	0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x64, 0x65, 0x76,
+	0x69, 0x63, 0x65, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61,
+	0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61,
+	0x6c, 0x22, 0xdc, 0x01, 0x0a, 0x09, 0x50, 0x65, 0x65, 0x72, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12,
+	0x22, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0e, 0x2e,
+	0x50, 0x65, 0x65, 0x72, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74,
+	0x79, 0x70, 0x65, 0x12, 0x2e, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
 	0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x62, 0x75, 0x66, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x4d, 0x61, 0x73, 0x6b, 0x52, 0x09, 0x66,
-	0x69, 0x65, 0x6c, 0x64, 0x4d, 0x61, 0x73, 0x6b, 0x22, 0x70, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x50,
-	0x65, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x73,
-	0x6b, 0x69, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x73, 0x6b, 0x69, 0x70, 0x12,
-	0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05,
-	0x6c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x18,
-	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x12, 0x1b, 0x0a,
-	0x09, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x08, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x49, 0x64, 0x22, 0x68, 0x0a, 0x10, 0x47, 0x65,
-	0x74, 0x50, 0x65, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x23,
-	0x0a, 0x05, 0x70, 0x65, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e,
-	0x50, 0x65, 0x65, 0x72, 0x41, 0x62, 0x72, 0x69, 0x64, 0x67, 0x65, 0x64, 0x52, 0x05, 0x70, 0x65,
-	0x65, 0x72, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x05, 0x52, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x12, 0x19, 0x0a, 0x08, 0x68, 0x61, 0x73,
-	0x5f, 0x6e, 0x65, 0x78, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x68, 0x61, 0x73,
-	0x4e, 0x65, 0x78, 0x74, 0x22, 0x52, 0x0a, 0x14, 0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64,
-	0x46, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x04,
-	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65,
-	0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04,
-	0x73, 0x69, 0x7a, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x32, 0xc5, 0x0c, 0x0a, 0x0b, 0x50, 0x65, 0x65,
-	0x72, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x84, 0x01, 0x0a, 0x03, 0x41, 0x64, 0x64,
-	0x12, 0x0f, 0x2e, 0x41, 0x64, 0x64, 0x50, 0x65, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x1a, 0x10, 0x2e, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x49, 0x64, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x22, 0x5a, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x0f, 0x22, 0x0a, 0x2f, 0x61, 0x70,
-	0x69, 0x2f, 0x70, 0x65, 0x65, 0x72, 0x73, 0x3a, 0x01, 0x2a, 0x92, 0x41, 0x42, 0x0a, 0x0b, 0x50,
-	0x65, 0x65, 0x72, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x08, 0x41, 0x64, 0x64, 0x20,
-	0x70, 0x65, 0x65, 0x72, 0x1a, 0x17, 0x41, 0x64, 0x64, 0x20, 0x70, 0x65, 0x65, 0x72, 0x20, 0x74,
-	0x6f, 0x20, 0x74, 0x68, 0x65, 0x20, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x62, 0x10, 0x0a,
-	0x0e, 0x0a, 0x0a, 0x41, 0x70, 0x69, 0x4b, 0x65, 0x79, 0x41, 0x75, 0x74, 0x68, 0x12, 0x00, 0x12,
-	0xa7, 0x01, 0x0a, 0x06, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x12, 0x10, 0x2e, 0x45, 0x6e, 0x74,
-	0x69, 0x74, 0x79, 0x49, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67,
-	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45,
-	0x6d, 0x70, 0x74, 0x79, 0x22, 0x73, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x14, 0x2a, 0x0f, 0x2f, 0x61,
-	0x70, 0x69, 0x2f, 0x70, 0x65, 0x65, 0x72, 0x73, 0x2f, 0x7b, 0x69, 0x64, 0x7d, 0x3a, 0x01, 0x2a,
-	0x92, 0x41, 0x56, 0x0a, 0x0b, 0x50, 0x65, 0x65, 0x72, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
-	0x12, 0x11, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x20, 0x70, 0x65, 0x65, 0x72, 0x20, 0x62, 0x79,
-	0x20, 0x69, 0x64, 0x1a, 0x22, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x20, 0x70, 0x65, 0x65, 0x72,
-	0x20, 0x62, 0x79, 0x20, 0x69, 0x64, 0x20, 0x66, 0x72, 0x6f, 0x6d, 0x20, 0x74, 0x68, 0x65, 0x20,
-	0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x62, 0x10, 0x0a, 0x0e, 0x0a, 0x0a, 0x41, 0x70, 0x69,
-	0x4b, 0x65, 0x79, 0x41, 0x75, 0x74, 0x68, 0x12, 0x00, 0x12, 0xcb, 0x01, 0x0a, 0x06, 0x55, 0x70,
-	0x64, 0x61, 0x74, 0x65, 0x12, 0x12, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x50, 0x65, 0x65,
-	0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
-	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79,
-	0x22, 0x94, 0x01, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x37, 0x1a, 0x14, 0x2f, 0x61, 0x70, 0x69, 0x2f,
+	0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x04, 0x74,
+	0x69, 0x6d, 0x65, 0x12, 0x19, 0x0a, 0x04, 0x70, 0x65, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x05, 0x2e, 0x50, 0x65, 0x65, 0x72, 0x52, 0x04, 0x70, 0x65, 0x65, 0x72, 0x12, 0x2e,
+	0x0a, 0x13, 0x72, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x5f,
+	0x64, 0x65, 0x6c, 0x74, 0x61, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x11, 0x72, 0x65, 0x63,
+	0x65, 0x69, 0x76, 0x65, 0x42, 0x79, 0x74, 0x65, 0x73, 0x44, 0x65, 0x6c, 0x74, 0x61, 0x12, 0x30,
+	0x0a, 0x14, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x6d, 0x69, 0x74, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73,
+	0x5f, 0x64, 0x65, 0x6c, 0x74, 0x61, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x12, 0x74, 0x72,
+	0x61, 0x6e, 0x73, 0x6d, 0x69, 0x74, 0x42, 0x79, 0x74, 0x65, 0x73, 0x44, 0x65, 0x6c, 0x74, 0x61,
+	0x2a, 0x3f, 0x0a, 0x0b, 0x51, 0x75, 0x6f, 0x74, 0x61, 0x50, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x12,
+	0x16, 0x0a, 0x12, 0x51, 0x55, 0x4f, 0x54, 0x41, 0x5f, 0x50, 0x45, 0x52, 0x49, 0x4f, 0x44, 0x5f,
+	0x54, 0x4f, 0x54, 0x41, 0x4c, 0x10, 0x00, 0x12, 0x18, 0x0a, 0x14, 0x51, 0x55, 0x4f, 0x54, 0x41,
+	0x5f, 0x50, 0x45, 0x52, 0x49, 0x4f, 0x44, 0x5f, 0x4d, 0x4f, 0x4e, 0x54, 0x48, 0x4c, 0x59, 0x10,
+	0x01, 0x2a, 0x82, 0x02, 0x0a, 0x0d, 0x50, 0x65, 0x65, 0x72, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x54,
+	0x79, 0x70, 0x65, 0x12, 0x1c, 0x0a, 0x18, 0x50, 0x45, 0x45, 0x52, 0x5f, 0x45, 0x56, 0x45, 0x4e,
+	0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x53, 0x4e, 0x41, 0x50, 0x53, 0x48, 0x4f, 0x54, 0x10,
+	0x00, 0x12, 0x19, 0x0a, 0x15, 0x50, 0x45, 0x45, 0x52, 0x5f, 0x45, 0x56, 0x45, 0x4e, 0x54, 0x5f,
+	0x54, 0x59, 0x50, 0x45, 0x5f, 0x41, 0x44, 0x44, 0x45, 0x44, 0x10, 0x01, 0x12, 0x1b, 0x0a, 0x17,
+	0x50, 0x45, 0x45, 0x52, 0x5f, 0x45, 0x56, 0x45, 0x4e, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f,
+	0x52, 0x45, 0x4d, 0x4f, 0x56, 0x45, 0x44, 0x10, 0x02, 0x12, 0x1b, 0x0a, 0x17, 0x50, 0x45, 0x45,
+	0x52, 0x5f, 0x45, 0x56, 0x45, 0x4e, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x45, 0x4e, 0x41,
+	0x42, 0x4c, 0x45, 0x44, 0x10, 0x03, 0x12, 0x1c, 0x0a, 0x18, 0x50, 0x45, 0x45, 0x52, 0x5f, 0x45,
+	0x56, 0x45, 0x4e, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x44, 0x49, 0x53, 0x41, 0x42, 0x4c,
+	0x45, 0x44, 0x10, 0x04, 0x12, 0x1d, 0x0a, 0x19, 0x50, 0x45, 0x45, 0x52, 0x5f, 0x45, 0x56, 0x45,
+	0x4e, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x48, 0x41, 0x4e, 0x44, 0x53, 0x48, 0x41, 0x4b,
+	0x45, 0x10, 0x05, 0x12, 0x24, 0x0a, 0x20, 0x50, 0x45, 0x45, 0x52, 0x5f, 0x45, 0x56, 0x45, 0x4e,
+	0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x45, 0x4e, 0x44, 0x50, 0x4f, 0x49, 0x4e, 0x54, 0x5f,
+	0x43, 0x48, 0x41, 0x4e, 0x47, 0x45, 0x44, 0x10, 0x06, 0x12, 0x1b, 0x0a, 0x17, 0x50, 0x45, 0x45,
+	0x52, 0x5f, 0x45, 0x56, 0x45, 0x4e, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x54, 0x52, 0x41,
+	0x46, 0x46, 0x49, 0x43, 0x10, 0x07, 0x32, 0xdc, 0x17, 0x0a, 0x0b, 0x50, 0x65, 0x65, 0x72, 0x53,
+	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x84, 0x01, 0x0a, 0x03, 0x41, 0x64, 0x64, 0x12, 0x0f,
+	0x2e, 0x41, 0x64, 0x64, 0x50, 0x65, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x10, 0x2e, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x49, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x22, 0x5a, 0x92, 0x41, 0x42, 0x0a, 0x0b, 0x50, 0x65, 0x65, 0x72, 0x53, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x12, 0x08, 0x41, 0x64, 0x64, 0x20, 0x70, 0x65, 0x65, 0x72, 0x1a, 0x17, 0x41,
+	0x64, 0x64, 0x20, 0x70, 0x65, 0x65, 0x72, 0x20, 0x74, 0x6f, 0x20, 0x74, 0x68, 0x65, 0x20, 0x73,
+	0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x62, 0x10, 0x0a, 0x0e, 0x0a, 0x0a, 0x41, 0x70, 0x69, 0x4b,
+	0x65, 0x79, 0x41, 0x75, 0x74, 0x68, 0x12, 0x00, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x0f, 0x22, 0x0a,
+	0x2f, 0x61, 0x70, 0x69, 0x2f, 0x70, 0x65, 0x65, 0x72, 0x73, 0x3a, 0x01, 0x2a, 0x12, 0xab, 0x02,
+	0x0a, 0x07, 0x42, 0x75, 0x6c, 0x6b, 0x41, 0x64, 0x64, 0x12, 0x14, 0x2e, 0x42, 0x75, 0x6c, 0x6b,
+	0x41, 0x64, 0x64, 0x50, 0x65, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x15, 0x2e, 0x42, 0x75, 0x6c, 0x6b, 0x41, 0x64, 0x64, 0x50, 0x65, 0x65, 0x72, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0xf2, 0x01, 0x92, 0x41, 0xd4, 0x01, 0x0a, 0x0b, 0x50,
+	0x65, 0x65, 0x72, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x11, 0x41, 0x64, 0x64, 0x20,
+	0x70, 0x65, 0x65, 0x72, 0x73, 0x20, 0x69, 0x6e, 0x20, 0x62, 0x75, 0x6c, 0x6b, 0x1a, 0x9f, 0x01,
+	0x41, 0x64, 0x64, 0x20, 0x70, 0x65, 0x65, 0x72, 0x73, 0x20, 0x74, 0x6f, 0x20, 0x74, 0x68, 0x65,
+	0x20, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x20, 0x66, 0x72, 0x6f, 0x6d, 0x20, 0x61, 0x20, 0x6c,
+	0x69, 0x73, 0x74, 0x20, 0x6f, 0x66, 0x20, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x20, 0x6f,
+	0x72, 0x20, 0x43, 0x53, 0x56, 0x20, 0x77, 0x69, 0x74, 0x68, 0x20, 0x6e, 0x61, 0x6d, 0x65, 0x2c,
+	0x20, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x20, 0x61, 0x6e, 0x64, 0x20, 0x64, 0x65, 0x73, 0x63, 0x72,
+	0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x20, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x73, 0x2e, 0x20,
+	0x45, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x20, 0x66, 0x61, 0x69, 0x6c, 0x69, 0x6e, 0x67, 0x20,
+	0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x20, 0x61, 0x72, 0x65, 0x20, 0x73,
+	0x6b, 0x69, 0x70, 0x70, 0x65, 0x64, 0x20, 0x61, 0x6e, 0x64, 0x20, 0x72, 0x65, 0x70, 0x6f, 0x72,
+	0x74, 0x65, 0x64, 0x20, 0x69, 0x6e, 0x20, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x2e, 0x62,
+	0x10, 0x0a, 0x0e, 0x0a, 0x0a, 0x41, 0x70, 0x69, 0x4b, 0x65, 0x79, 0x41, 0x75, 0x74, 0x68, 0x12,
+	0x00, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x14, 0x22, 0x0f, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x70, 0x65,
+	0x65, 0x72, 0x73, 0x2f, 0x62, 0x75, 0x6c, 0x6b, 0x3a, 0x01, 0x2a, 0x12, 0xda, 0x01, 0x0a, 0x0d,
+	0x42, 0x75, 0x6c, 0x6b, 0x41, 0x64, 0x64, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x12, 0x14, 0x2e,
+	0x42, 0x75, 0x6c, 0x6b, 0x41, 0x64, 0x64, 0x50, 0x65, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x42, 0x75, 0x6c, 0x6b, 0x41, 0x64, 0x64, 0x50, 0x65, 0x65,
+	0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x99, 0x01, 0x92, 0x41, 0x95,
+	0x01, 0x0a, 0x0b, 0x50, 0x65, 0x65, 0x72, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x1f,
+	0x41, 0x64, 0x64, 0x20, 0x70, 0x65, 0x65, 0x72, 0x73, 0x20, 0x69, 0x6e, 0x20, 0x62, 0x75, 0x6c,
+	0x6b, 0x20, 0x66, 0x72, 0x6f, 0x6d, 0x20, 0x61, 0x20, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x1a,
+	0x53, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x2d, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x69, 0x6e,
+	0x67, 0x20, 0x76, 0x61, 0x72, 0x69, 0x61, 0x6e, 0x74, 0x20, 0x6f, 0x66, 0x20, 0x42, 0x75, 0x6c,
+	0x6b, 0x41, 0x64, 0x64, 0x2c, 0x20, 0x65, 0x76, 0x65, 0x72, 0x79, 0x20, 0x6d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x20, 0x73, 0x68, 0x6f, 0x75, 0x6c, 0x64, 0x20, 0x72, 0x65, 0x66, 0x65, 0x72,
+	0x20, 0x74, 0x6f, 0x20, 0x74, 0x68, 0x65, 0x20, 0x73, 0x61, 0x6d, 0x65, 0x20, 0x64, 0x65, 0x76,
+	0x69, 0x63, 0x65, 0x2e, 0x62, 0x10, 0x0a, 0x0e, 0x0a, 0x0a, 0x41, 0x70, 0x69, 0x4b, 0x65, 0x79,
+	0x41, 0x75, 0x74, 0x68, 0x12, 0x00, 0x28, 0x01, 0x12, 0xa7, 0x01, 0x0a, 0x06, 0x52, 0x65, 0x6d,
+	0x6f, 0x76, 0x65, 0x12, 0x10, 0x2e, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x49, 0x64, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x73, 0x92,
+	0x41, 0x56, 0x0a, 0x0b, 0x50, 0x65, 0x65, 0x72, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12,
+	0x11, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x20, 0x70, 0x65, 0x65, 0x72, 0x20, 0x62, 0x79, 0x20,
+	0x69, 0x64, 0x1a, 0x22, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x20, 0x70, 0x65, 0x65, 0x72, 0x20,
+	0x62, 0x79, 0x20, 0x69, 0x64, 0x20, 0x66, 0x72, 0x6f, 0x6d, 0x20, 0x74, 0x68, 0x65, 0x20, 0x73,
+	0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x62, 0x10, 0x0a, 0x0e, 0x0a, 0x0a, 0x41, 0x70, 0x69, 0x4b,
+	0x65, 0x79, 0x41, 0x75, 0x74, 0x68, 0x12, 0x00, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x14, 0x2a, 0x0f,
+	0x2f, 0x61, 0x70, 0x69, 0x2f, 0x70, 0x65, 0x65, 0x72, 0x73, 0x2f, 0x7b, 0x69, 0x64, 0x7d, 0x3a,
+	0x01, 0x2a, 0x12, 0xcb, 0x01, 0x0a, 0x06, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x12, 0x2e,
+	0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x50, 0x65, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x94, 0x01, 0x92, 0x41, 0x54, 0x0a,
+	0x0b, 0x50, 0x65, 0x65, 0x72, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x11, 0x55, 0x70,
+	0x64, 0x61, 0x74, 0x65, 0x20, 0x70, 0x65, 0x65, 0x72, 0x20, 0x62, 0x79, 0x20, 0x69, 0x64, 0x1a,
+	0x20, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x20, 0x70, 0x65, 0x65, 0x72, 0x20, 0x62, 0x79, 0x20,
+	0x69, 0x64, 0x20, 0x6f, 0x6e, 0x20, 0x74, 0x68, 0x65, 0x20, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72,
+	0x2e, 0x62, 0x10, 0x0a, 0x0e, 0x0a, 0x0a, 0x41, 0x70, 0x69, 0x4b, 0x65, 0x79, 0x41, 0x75, 0x74,
+	0x68, 0x12, 0x00, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x37, 0x1a, 0x14, 0x2f, 0x61, 0x70, 0x69, 0x2f,
 	0x70, 0x65, 0x65, 0x72, 0x73, 0x2f, 0x7b, 0x70, 0x65, 0x65, 0x72, 0x2e, 0x69, 0x64, 0x7d, 0x3a,
 	0x01, 0x2a, 0x5a, 0x1c, 0x32, 0x14, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x70, 0x65, 0x65, 0x72, 0x73,
 	0x2f, 0x7b, 0x70, 0x65, 0x65, 0x72, 0x2e, 0x69, 0x64, 0x7d, 0x3a, 0x04, 0x70, 0x65, 0x65, 0x72,
-	0x92, 0x41, 0x54, 0x0a, 0x0b, 0x50, 0x65, 0x65, 0x72, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
-	0x12, 0x11, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x20, 0x70, 0x65, 0x65, 0x72, 0x20, 0x62, 0x79,
-	0x20, 0x69, 0x64, 0x1a, 0x20, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x20, 0x70, 0x65, 0x65, 0x72,
-	0x20, 0x62, 0x79, 0x20, 0x69, 0x64, 0x20, 0x6f, 0x6e, 0x20, 0x74, 0x68, 0x65, 0x20, 0x73, 0x65,
-	0x72, 0x76, 0x65, 0x72, 0x2e, 0x62, 0x10, 0x0a, 0x0e, 0x0a, 0x0a, 0x41, 0x70, 0x69, 0x4b, 0x65,
-	0x79, 0x41, 0x75, 0x74, 0x68, 0x12, 0x00, 0x12, 0x8a, 0x01, 0x0a, 0x03, 0x47, 0x65, 0x74, 0x12,
-	0x10, 0x2e, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x49, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x1a, 0x05, 0x2e, 0x50, 0x65, 0x65, 0x72, 0x22, 0x6a, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x11,
-	0x12, 0x0f, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x70, 0x65, 0x65, 0x72, 0x73, 0x2f, 0x7b, 0x69, 0x64,
-	0x7d, 0x92, 0x41, 0x50, 0x0a, 0x0b, 0x50, 0x65, 0x65, 0x72, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
-	0x65, 0x12, 0x0e, 0x47, 0x65, 0x74, 0x20, 0x70, 0x65, 0x65, 0x72, 0x20, 0x62, 0x79, 0x20, 0x69,
-	0x64, 0x1a, 0x1f, 0x47, 0x65, 0x74, 0x20, 0x70, 0x65, 0x65, 0x72, 0x20, 0x62, 0x79, 0x20, 0x69,
+	0x12, 0x8a, 0x01, 0x0a, 0x03, 0x47, 0x65, 0x74, 0x12, 0x10, 0x2e, 0x45, 0x6e, 0x74, 0x69, 0x74,
+	0x79, 0x49, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x05, 0x2e, 0x50, 0x65, 0x65,
+	0x72, 0x22, 0x6a, 0x92, 0x41, 0x50, 0x0a, 0x0b, 0x50, 0x65, 0x65, 0x72, 0x53, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x12, 0x0e, 0x47, 0x65, 0x74, 0x20, 0x70, 0x65, 0x65, 0x72, 0x20, 0x62, 0x79,
+	0x20, 0x69, 0x64, 0x1a, 0x1f, 0x47, 0x65, 0x74, 0x20, 0x70, 0x65, 0x65, 0x72, 0x20, 0x62, 0x79,
+	0x20, 0x69, 0x64, 0x20, 0x66, 0x72, 0x6f, 0x6d, 0x20, 0x74, 0x68, 0x65, 0x20, 0x73, 0x65, 0x72,
+	0x76, 0x65, 0x72, 0x2e, 0x62, 0x10, 0x0a, 0x0e, 0x0a, 0x0a, 0x41, 0x70, 0x69, 0x4b, 0x65, 0x79,
+	0x41, 0x75, 0x74, 0x68, 0x12, 0x00, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x11, 0x12, 0x0f, 0x2f, 0x61,
+	0x70, 0x69, 0x2f, 0x70, 0x65, 0x65, 0x72, 0x73, 0x2f, 0x7b, 0x69, 0x64, 0x7d, 0x12, 0x8a, 0x01,
+	0x0a, 0x06, 0x47, 0x65, 0x74, 0x41, 0x6c, 0x6c, 0x12, 0x10, 0x2e, 0x47, 0x65, 0x74, 0x50, 0x65,
+	0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x11, 0x2e, 0x47, 0x65, 0x74,
+	0x50, 0x65, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x5b, 0x92,
+	0x41, 0x46, 0x0a, 0x0b, 0x50, 0x65, 0x65, 0x72, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12,
+	0x09, 0x47, 0x65, 0x74, 0x20, 0x70, 0x65, 0x65, 0x72, 0x73, 0x1a, 0x1a, 0x47, 0x65, 0x74, 0x20,
+	0x70, 0x65, 0x65, 0x72, 0x73, 0x20, 0x66, 0x72, 0x6f, 0x6d, 0x20, 0x74, 0x68, 0x65, 0x20, 0x73,
+	0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x62, 0x10, 0x0a, 0x0e, 0x0a, 0x0a, 0x41, 0x70, 0x69, 0x4b,
+	0x65, 0x79, 0x41, 0x75, 0x74, 0x68, 0x12, 0x00, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x0c, 0x12, 0x0a,
+	0x2f, 0x61, 0x70, 0x69, 0x2f, 0x70, 0x65, 0x65, 0x72, 0x73, 0x12, 0xae, 0x01, 0x0a, 0x06, 0x45,
+	0x6e, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x10, 0x2e, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x49, 0x64,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22,
+	0x7a, 0x92, 0x41, 0x56, 0x0a, 0x0b, 0x50, 0x65, 0x65, 0x72, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x12, 0x11, 0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x20, 0x70, 0x65, 0x65, 0x72, 0x20, 0x62,
+	0x79, 0x20, 0x69, 0x64, 0x1a, 0x22, 0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x20, 0x70, 0x65, 0x65,
+	0x72, 0x20, 0x62, 0x79, 0x20, 0x69, 0x64, 0x20, 0x66, 0x72, 0x6f, 0x6d, 0x20, 0x74, 0x68, 0x65,
+	0x20, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x62, 0x10, 0x0a, 0x0e, 0x0a, 0x0a, 0x41, 0x70,
+	0x69, 0x4b, 0x65, 0x79, 0x41, 0x75, 0x74, 0x68, 0x12, 0x00, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1b,
+	0x22, 0x16, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x70, 0x65, 0x65, 0x72, 0x73, 0x2f, 0x7b, 0x69, 0x64,
+	0x7d, 0x2f, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x3a, 0x01, 0x2a, 0x12, 0xb2, 0x01, 0x0a, 0x07,
+	0x44, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x10, 0x2e, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79,
+	0x49, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74,
+	0x79, 0x22, 0x7d, 0x92, 0x41, 0x58, 0x0a, 0x0b, 0x50, 0x65, 0x65, 0x72, 0x53, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x12, 0x12, 0x44, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x20, 0x70, 0x65, 0x65,
+	0x72, 0x20, 0x62, 0x79, 0x20, 0x69, 0x64, 0x1a, 0x23, 0x44, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65,
+	0x20, 0x70, 0x65, 0x65, 0x72, 0x20, 0x62, 0x79, 0x20, 0x69, 0x64, 0x20, 0x66, 0x72, 0x6f, 0x6d,
+	0x20, 0x74, 0x68, 0x65, 0x20, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x62, 0x10, 0x0a, 0x0e,
+	0x0a, 0x0a, 0x41, 0x70, 0x69, 0x4b, 0x65, 0x79, 0x41, 0x75, 0x74, 0x68, 0x12, 0x00, 0x82, 0xd3,
+	0xe4, 0x93, 0x02, 0x1c, 0x22, 0x17, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x70, 0x65, 0x65, 0x72, 0x73,
+	0x2f, 0x7b, 0x69, 0x64, 0x7d, 0x2f, 0x64, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x3a, 0x01, 0x2a,
+	0x12, 0xc5, 0x01, 0x0a, 0x0e, 0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x43, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x12, 0x10, 0x2e, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x49, 0x64, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64,
+	0x46, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x89, 0x01, 0x92,
+	0x41, 0x68, 0x0a, 0x0b, 0x50, 0x65, 0x65, 0x72, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12,
+	0x1a, 0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x20, 0x70, 0x65, 0x65, 0x72, 0x20, 0x63,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x20, 0x62, 0x79, 0x20, 0x69, 0x64, 0x1a, 0x2b, 0x44, 0x6f, 0x77,
+	0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x20, 0x70, 0x65, 0x65, 0x72, 0x20, 0x63, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x20, 0x62, 0x79, 0x20, 0x69, 0x64, 0x20, 0x66, 0x72, 0x6f, 0x6d, 0x20, 0x74, 0x68, 0x65,
+	0x20, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x62, 0x10, 0x0a, 0x0e, 0x0a, 0x0a, 0x41, 0x70,
+	0x69, 0x4b, 0x65, 0x79, 0x41, 0x75, 0x74, 0x68, 0x12, 0x00, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x18,
+	0x12, 0x16, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x70, 0x65, 0x65, 0x72, 0x73, 0x2f, 0x7b, 0x69, 0x64,
+	0x7d, 0x2f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0xc3, 0x01, 0x0a, 0x0e, 0x44, 0x6f, 0x77,
+	0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x51, 0x52, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x10, 0x2e, 0x45, 0x6e,
+	0x74, 0x69, 0x74, 0x79, 0x49, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e,
+	0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x87, 0x01, 0x92, 0x41, 0x6a, 0x0a, 0x0b, 0x50, 0x65, 0x65, 0x72,
+	0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x1b, 0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61,
+	0x64, 0x20, 0x70, 0x65, 0x65, 0x72, 0x20, 0x71, 0x72, 0x2d, 0x63, 0x6f, 0x64, 0x65, 0x20, 0x62,
+	0x79, 0x20, 0x69, 0x64, 0x1a, 0x2c, 0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x20, 0x70,
+	0x65, 0x65, 0x72, 0x20, 0x71, 0x72, 0x2d, 0x63, 0x6f, 0x64, 0x65, 0x20, 0x62, 0x79, 0x20, 0x69,
 	0x64, 0x20, 0x66, 0x72, 0x6f, 0x6d, 0x20, 0x74, 0x68, 0x65, 0x20, 0x73, 0x65, 0x72, 0x76, 0x65,
 	0x72, 0x2e, 0x62, 0x10, 0x0a, 0x0e, 0x0a, 0x0a, 0x41, 0x70, 0x69, 0x4b, 0x65, 0x79, 0x41, 0x75,
-	0x74, 0x68, 0x12, 0x00, 0x12, 0x8a, 0x01, 0x0a, 0x06, 0x47, 0x65, 0x74, 0x41, 0x6c, 0x6c, 0x12,
-	0x10, 0x2e, 0x47, 0x65, 0x74, 0x50, 0x65, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x1a, 0x11, 0x2e, 0x47, 0x65, 0x74, 0x50, 0x65, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x5b, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x0c, 0x12, 0x0a, 0x2f, 0x61,
-	0x70, 0x69, 0x2f, 0x70, 0x65, 0x65, 0x72, 0x73, 0x92, 0x41, 0x46, 0x0a, 0x0b, 0x50, 0x65, 0x65,
-	0x72, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x09, 0x47, 0x65, 0x74, 0x20, 0x70, 0x65,
-	0x65, 0x72, 0x73, 0x1a, 0x1a, 0x47, 0x65, 0x74, 0x20, 0x70, 0x65, 0x65, 0x72, 0x73, 0x20, 0x66,
-	0x72, 0x6f, 0x6d, 0x20, 0x74, 0x68, 0x65, 0x20, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x62,
-	0x10, 0x0a, 0x0e, 0x0a, 0x0a, 0x41, 0x70, 0x69, 0x4b, 0x65, 0x79, 0x41, 0x75, 0x74, 0x68, 0x12,
-	0x00, 0x12, 0xae, 0x01, 0x0a, 0x06, 0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x10, 0x2e, 0x45,
-	0x6e, 0x74, 0x69, 0x74, 0x79, 0x49, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16,
-	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
-	0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x7a, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1b, 0x22, 0x16,
-	0x2f, 0x61, 0x70, 0x69, 0x2f, 0x70, 0x65, 0x65, 0x72, 0x73, 0x2f, 0x7b, 0x69, 0x64, 0x7d, 0x2f,
-	0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x3a, 0x01, 0x2a, 0x92, 0x41, 0x56, 0x0a, 0x0b, 0x50, 0x65,
-	0x65, 0x72, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x11, 0x45, 0x6e, 0x61, 0x62, 0x6c,
-	0x65, 0x20, 0x70, 0x65, 0x65, 0x72, 0x20, 0x62, 0x79, 0x20, 0x69, 0x64, 0x1a, 0x22, 0x45, 0x6e,
-	0x61, 0x62, 0x6c, 0x65, 0x20, 0x70, 0x65, 0x65, 0x72, 0x20, 0x62, 0x79, 0x20, 0x69, 0x64, 0x20,
-	0x66, 0x72, 0x6f, 0x6d, 0x20, 0x74, 0x68, 0x65, 0x20, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e,
-	0x62, 0x10, 0x0a, 0x0e, 0x0a, 0x0a, 0x41, 0x70, 0x69, 0x4b, 0x65, 0x79, 0x41, 0x75, 0x74, 0x68,
-	0x12, 0x00, 0x12, 0xb2, 0x01, 0x0a, 0x07, 0x44, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x10,
-	0x2e, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x49, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
-	0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x7d, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1c,
-	0x22, 0x17, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x70, 0x65, 0x65, 0x72, 0x73, 0x2f, 0x7b, 0x69, 0x64,
-	0x7d, 0x2f, 0x64, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x3a, 0x01, 0x2a, 0x92, 0x41, 0x58, 0x0a,
-	0x0b, 0x50, 0x65, 0x65, 0x72, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x12, 0x44, 0x69,
-	0x73, 0x61, 0x62, 0x6c, 0x65, 0x20, 0x70, 0x65, 0x65, 0x72, 0x20, 0x62, 0x79, 0x20, 0x69, 0x64,
-	0x1a, 0x23, 0x44, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x20, 0x70, 0x65, 0x65, 0x72, 0x20, 0x62,
-	0x79, 0x20, 0x69, 0x64, 0x20, 0x66, 0x72, 0x6f, 0x6d, 0x20, 0x74, 0x68, 0x65, 0x20, 0x73, 0x65,
-	0x72, 0x76, 0x65, 0x72, 0x2e, 0x62, 0x10, 0x0a, 0x0e, 0x0a, 0x0a, 0x41, 0x70, 0x69, 0x4b, 0x65,
-	0x79, 0x41, 0x75, 0x74, 0x68, 0x12, 0x00, 0x12, 0xc5, 0x01, 0x0a, 0x0e, 0x44, 0x6f, 0x77, 0x6e,
-	0x6c, 0x6f, 0x61, 0x64, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x10, 0x2e, 0x45, 0x6e, 0x74,
-	0x69, 0x74, 0x79, 0x49, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x44,
-	0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x22, 0x89, 0x01, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x18, 0x12, 0x16, 0x2f, 0x61,
-	0x70, 0x69, 0x2f, 0x70, 0x65, 0x65, 0x72, 0x73, 0x2f, 0x7b, 0x69, 0x64, 0x7d, 0x2f, 0x63, 0x6f,
-	0x6e, 0x66, 0x69, 0x67, 0x92, 0x41, 0x68, 0x0a, 0x0b, 0x50, 0x65, 0x65, 0x72, 0x53, 0x65, 0x72,
-	0x76, 0x69, 0x63, 0x65, 0x12, 0x1a, 0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x20, 0x70,
-	0x65, 0x65, 0x72, 0x20, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x20, 0x62, 0x79, 0x20, 0x69, 0x64,
-	0x1a, 0x2b, 0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x20, 0x70, 0x65, 0x65, 0x72, 0x20,
-	0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x20, 0x62, 0x79, 0x20, 0x69, 0x64, 0x20, 0x66, 0x72, 0x6f,
-	0x6d, 0x20, 0x74, 0x68, 0x65, 0x20, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x62, 0x10, 0x0a,
-	0x0e, 0x0a, 0x0a, 0x41, 0x70, 0x69, 0x4b, 0x65, 0x79, 0x41, 0x75, 0x74, 0x68, 0x12, 0x00, 0x12,
-	0xc3, 0x01, 0x0a, 0x0e, 0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x51, 0x52, 0x43, 0x6f,
-	0x64, 0x65, 0x12, 0x10, 0x2e, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x49, 0x64, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x46,
-	0x69, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x87, 0x01, 0x82, 0xd3,
-	0xe4, 0x93, 0x02, 0x14, 0x12, 0x12, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x70, 0x65, 0x65, 0x72, 0x73,
-	0x2f, 0x7b, 0x69, 0x64, 0x7d, 0x2f, 0x71, 0x72, 0x92, 0x41, 0x6a, 0x0a, 0x0b, 0x50, 0x65, 0x65,
-	0x72, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x1b, 0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f,
-	0x61, 0x64, 0x20, 0x70, 0x65, 0x65, 0x72, 0x20, 0x71, 0x72, 0x2d, 0x63, 0x6f, 0x64, 0x65, 0x20,
-	0x62, 0x79, 0x20, 0x69, 0x64, 0x1a, 0x2c, 0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x20,
-	0x70, 0x65, 0x65, 0x72, 0x20, 0x71, 0x72, 0x2d, 0x63, 0x6f, 0x64, 0x65, 0x20, 0x62, 0x79, 0x20,
-	0x69, 0x64, 0x20, 0x66, 0x72, 0x6f, 0x6d, 0x20, 0x74, 0x68, 0x65, 0x20, 0x73, 0x65, 0x72, 0x76,
-	0x65, 0x72, 0x2e, 0x62, 0x10, 0x0a, 0x0e, 0x0a, 0x0a, 0x41, 0x70, 0x69, 0x4b, 0x65, 0x79, 0x41,
-	0x75, 0x74, 0x68, 0x12, 0x00, 0x1a, 0x29, 0x92, 0x41, 0x26, 0x12, 0x24, 0x53, 0x65, 0x72, 0x76,
-	0x69, 0x63, 0x65, 0x20, 0x74, 0x6f, 0x20, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x65,
-	0x20, 0x77, 0x69, 0x72, 0x65, 0x67, 0x75, 0x61, 0x72, 0x64, 0x20, 0x70, 0x65, 0x65, 0x72, 0x73,
-	0x42, 0x09, 0x5a, 0x07, 0x2e, 0x2f, 0x3b, 0x77, 0x67, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x33,
+	0x74, 0x68, 0x12, 0x00, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x14, 0x12, 0x12, 0x2f, 0x61, 0x70, 0x69,
+	0x2f, 0x70, 0x65, 0x65, 0x72, 0x73, 0x2f, 0x7b, 0x69, 0x64, 0x7d, 0x2f, 0x71, 0x72, 0x12, 0xcc,
+	0x01, 0x0a, 0x0a, 0x52, 0x65, 0x73, 0x65, 0x74, 0x51, 0x75, 0x6f, 0x74, 0x61, 0x12, 0x10, 0x2e,
+	0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x49, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x93, 0x01, 0x92, 0x41, 0x6a, 0x0a, 0x0b, 0x50,
+	0x65, 0x65, 0x72, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x16, 0x52, 0x65, 0x73, 0x65,
+	0x74, 0x20, 0x70, 0x65, 0x65, 0x72, 0x20, 0x71, 0x75, 0x6f, 0x74, 0x61, 0x20, 0x62, 0x79, 0x20,
+	0x69, 0x64, 0x1a, 0x31, 0x52, 0x65, 0x73, 0x65, 0x74, 0x20, 0x74, 0x72, 0x61, 0x66, 0x66, 0x69,
+	0x63, 0x20, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x65, 0x64, 0x20, 0x61, 0x67, 0x61, 0x69,
+	0x6e, 0x73, 0x74, 0x20, 0x70, 0x65, 0x65, 0x72, 0x20, 0x71, 0x75, 0x6f, 0x74, 0x61, 0x20, 0x62,
+	0x79, 0x20, 0x69, 0x64, 0x2e, 0x62, 0x10, 0x0a, 0x0e, 0x0a, 0x0a, 0x41, 0x70, 0x69, 0x4b, 0x65,
+	0x79, 0x41, 0x75, 0x74, 0x68, 0x12, 0x00, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x20, 0x22, 0x1b, 0x2f,
+	0x61, 0x70, 0x69, 0x2f, 0x70, 0x65, 0x65, 0x72, 0x73, 0x2f, 0x7b, 0x69, 0x64, 0x7d, 0x2f, 0x72,
+	0x65, 0x73, 0x65, 0x74, 0x2d, 0x71, 0x75, 0x6f, 0x74, 0x61, 0x3a, 0x01, 0x2a, 0x12, 0x8a, 0x02,
+	0x0a, 0x0a, 0x52, 0x6f, 0x74, 0x61, 0x74, 0x65, 0x4b, 0x65, 0x79, 0x73, 0x12, 0x16, 0x2e, 0x52,
+	0x6f, 0x74, 0x61, 0x74, 0x65, 0x50, 0x65, 0x65, 0x72, 0x4b, 0x65, 0x79, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x05, 0x2e, 0x50, 0x65, 0x65, 0x72, 0x22, 0xdc, 0x01, 0x92, 0x41,
+	0xb2, 0x01, 0x0a, 0x0b, 0x50, 0x65, 0x65, 0x72, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12,
+	0x16, 0x52, 0x6f, 0x74, 0x61, 0x74, 0x65, 0x20, 0x70, 0x65, 0x65, 0x72, 0x20, 0x6b, 0x65, 0x79,
+	0x73, 0x20, 0x62, 0x79, 0x20, 0x69, 0x64, 0x1a, 0x79, 0x52, 0x65, 0x70, 0x6c, 0x61, 0x63, 0x65,
+	0x20, 0x70, 0x65, 0x65, 0x72, 0x20, 0x6b, 0x65, 0x79, 0x70, 0x61, 0x69, 0x72, 0x20, 0x61, 0x6e,
+	0x64, 0x20, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x6c, 0x79, 0x20, 0x70, 0x72, 0x65,
+	0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x20, 0x6b, 0x65, 0x79, 0x20, 0x6b, 0x65, 0x65, 0x70, 0x69,
+	0x6e, 0x67, 0x20, 0x69, 0x74, 0x73, 0x20, 0x69, 0x64, 0x2c, 0x20, 0x6e, 0x61, 0x6d, 0x65, 0x20,
+	0x61, 0x6e, 0x64, 0x20, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x2e, 0x20, 0x50,
+	0x65, 0x65, 0x72, 0x20, 0x6e, 0x65, 0x65, 0x64, 0x73, 0x20, 0x61, 0x20, 0x6e, 0x65, 0x77, 0x20,
+	0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x20, 0x61, 0x66, 0x74, 0x65, 0x72, 0x77, 0x61, 0x72, 0x64,
+	0x73, 0x2e, 0x62, 0x10, 0x0a, 0x0e, 0x0a, 0x0a, 0x41, 0x70, 0x69, 0x4b, 0x65, 0x79, 0x41, 0x75,
+	0x74, 0x68, 0x12, 0x00, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x20, 0x22, 0x1b, 0x2f, 0x61, 0x70, 0x69,
+	0x2f, 0x70, 0x65, 0x65, 0x72, 0x73, 0x2f, 0x7b, 0x69, 0x64, 0x7d, 0x2f, 0x72, 0x6f, 0x74, 0x61,
+	0x74, 0x65, 0x2d, 0x6b, 0x65, 0x79, 0x73, 0x3a, 0x01, 0x2a, 0x12, 0xd7, 0x01, 0x0a, 0x05, 0x57,
+	0x61, 0x74, 0x63, 0x68, 0x12, 0x11, 0x2e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x50, 0x65, 0x65, 0x72,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0a, 0x2e, 0x50, 0x65, 0x65, 0x72, 0x45, 0x76,
+	0x65, 0x6e, 0x74, 0x22, 0xac, 0x01, 0x92, 0x41, 0x8b, 0x01, 0x0a, 0x0b, 0x50, 0x65, 0x65, 0x72,
+	0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x10, 0x57, 0x61, 0x74, 0x63, 0x68, 0x20, 0x70,
+	0x65, 0x65, 0x72, 0x20, 0x62, 0x79, 0x20, 0x69, 0x64, 0x1a, 0x58, 0x53, 0x74, 0x72, 0x65, 0x61,
+	0x6d, 0x20, 0x70, 0x65, 0x65, 0x72, 0x20, 0x73, 0x74, 0x61, 0x74, 0x65, 0x20, 0x63, 0x68, 0x61,
+	0x6e, 0x67, 0x65, 0x73, 0x3a, 0x20, 0x68, 0x61, 0x6e, 0x64, 0x73, 0x68, 0x61, 0x6b, 0x65, 0x73,
+	0x2c, 0x20, 0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x20, 0x63, 0x68, 0x61, 0x6e, 0x67,
+	0x65, 0x73, 0x2c, 0x20, 0x74, 0x72, 0x61, 0x66, 0x66, 0x69, 0x63, 0x20, 0x61, 0x6e, 0x64, 0x20,
+	0x65, 0x6e, 0x61, 0x62, 0x6c, 0x69, 0x6e, 0x67, 0x2f, 0x64, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x69,
+	0x6e, 0x67, 0x2e, 0x62, 0x10, 0x0a, 0x0e, 0x0a, 0x0a, 0x41, 0x70, 0x69, 0x4b, 0x65, 0x79, 0x41,
+	0x75, 0x74, 0x68, 0x12, 0x00, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x17, 0x12, 0x15, 0x2f, 0x61, 0x70,
+	0x69, 0x2f, 0x70, 0x65, 0x65, 0x72, 0x73, 0x2f, 0x7b, 0x69, 0x64, 0x7d, 0x2f, 0x77, 0x61, 0x74,
+	0x63, 0x68, 0x30, 0x01, 0x12, 0xd3, 0x01, 0x0a, 0x0b, 0x57, 0x61, 0x74, 0x63, 0x68, 0x44, 0x65,
+	0x76, 0x69, 0x63, 0x65, 0x12, 0x18, 0x2e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x44, 0x65, 0x76, 0x69,
+	0x63, 0x65, 0x50, 0x65, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0a,
+	0x2e, 0x50, 0x65, 0x65, 0x72, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x22, 0x9b, 0x01, 0x92, 0x41, 0x6c,
+	0x0a, 0x0b, 0x50, 0x65, 0x65, 0x72, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x19, 0x57,
+	0x61, 0x74, 0x63, 0x68, 0x20, 0x70, 0x65, 0x65, 0x72, 0x73, 0x20, 0x6f, 0x66, 0x20, 0x74, 0x68,
+	0x65, 0x20, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x1a, 0x30, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d,
+	0x20, 0x73, 0x74, 0x61, 0x74, 0x65, 0x20, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x20, 0x6f,
+	0x66, 0x20, 0x61, 0x6c, 0x6c, 0x20, 0x70, 0x65, 0x65, 0x72, 0x73, 0x20, 0x6f, 0x66, 0x20, 0x74,
+	0x68, 0x65, 0x20, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x62, 0x10, 0x0a, 0x0e, 0x0a, 0x0a,
+	0x41, 0x70, 0x69, 0x4b, 0x65, 0x79, 0x41, 0x75, 0x74, 0x68, 0x12, 0x00, 0x82, 0xd3, 0xe4, 0x93,
+	0x02, 0x26, 0x12, 0x24, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73,
+	0x2f, 0x7b, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x7d, 0x2f, 0x70, 0x65, 0x65,
+	0x72, 0x73, 0x2f, 0x77, 0x61, 0x74, 0x63, 0x68, 0x30, 0x01, 0x1a, 0x29, 0x92, 0x41, 0x26, 0x12,
+	0x24, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x20, 0x74, 0x6f, 0x20, 0x63, 0x6f, 0x6e, 0x66,
+	0x69, 0x67, 0x75, 0x72, 0x65, 0x20, 0x77, 0x69, 0x72, 0x65, 0x67, 0x75, 0x61, 0x72, 0x64, 0x20,
+	0x70, 0x65, 0x65, 0x72, 0x73, 0x42, 0x09, 0x5a, 0x07, 0x2e, 0x2f, 0x3b, 0x77, 0x67, 0x70, 0x62,
+	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -1063,49 +2219,89 @@ func file_peer_service_proto_rawDescGZIP() []byte {
 	return file_peer_service_proto_rawDescData
 }
 
-var file_peer_service_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_peer_service_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_peer_service_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
 var file_peer_service_proto_goTypes = []interface{}{
-	(*Peer)(nil),                 // 0: Peer
-	(*PeerAbridged)(nil),         // 1: PeerAbridged
-	(*AddPeerRequest)(nil),       // 2: AddPeerRequest
-	(*UpdatePeerData)(nil),       // 3: UpdatePeerData
-	(*UpdatePeerRequest)(nil),    // 4: UpdatePeerRequest
-	(*GetPeersRequest)(nil),      // 5: GetPeersRequest
-	(*GetPeersResponse)(nil),     // 6: GetPeersResponse
-	(*DownloadFileResponse)(nil), // 7: DownloadFileResponse
-	(*timestamp.Timestamp)(nil),  // 8: google.protobuf.Timestamp
-	(*field_mask.FieldMask)(nil), // 9: google.protobuf.FieldMask
-	(*EntityIdRequest)(nil),      // 10: EntityIdRequest
-	(*empty.Empty)(nil),          // 11: google.protobuf.Empty
+	(QuotaPeriod)(0),                // 0: QuotaPeriod
+	(PeerEventType)(0),              // 1: PeerEventType
+	(*Peer)(nil),                    // 2: Peer
+	(*PeerAbridged)(nil),            // 3: PeerAbridged
+	(*AddPeerRequest)(nil),          // 4: AddPeerRequest
+	(*BulkPeerEntry)(nil),           // 5: BulkPeerEntry
+	(*BulkAddPeersRequest)(nil),     // 6: BulkAddPeersRequest
+	(*FieldViolation)(nil),          // 7: FieldViolation
+	(*BulkAddPeerResult)(nil),       // 8: BulkAddPeerResult
+	(*BulkAddPeersResponse)(nil),    // 9: BulkAddPeersResponse
+	(*UpdatePeerData)(nil),          // 10: UpdatePeerData
+	(*UpdatePeerRequest)(nil),       // 11: UpdatePeerRequest
+	(*GetPeersRequest)(nil),         // 12: GetPeersRequest
+	(*GetPeersResponse)(nil),        // 13: GetPeersResponse
+	(*DownloadFileResponse)(nil),    // 14: DownloadFileResponse
+	(*RotatePeerKeysRequest)(nil),   // 15: RotatePeerKeysRequest
+	(*WatchPeerRequest)(nil),        // 16: WatchPeerRequest
+	(*WatchDevicePeersRequest)(nil), // 17: WatchDevicePeersRequest
+	(*PeerEvent)(nil),               // 18: PeerEvent
+	(*timestamp.Timestamp)(nil),     // 19: google.protobuf.Timestamp
+	(*field_mask.FieldMask)(nil),    // 20: google.protobuf.FieldMask
+	(*EntityIdRequest)(nil),         // 21: EntityIdRequest
+	(*empty.Empty)(nil),             // 22: google.protobuf.Empty
 }
 var file_peer_service_proto_depIdxs = []int32{
-	8,  // 0: Peer.last_handshake:type_name -> google.protobuf.Timestamp
-	3,  // 1: UpdatePeerRequest.peer:type_name -> UpdatePeerData
-	9,  // 2: UpdatePeerRequest.field_mask:type_name -> google.protobuf.FieldMask
-	1,  // 3: GetPeersResponse.peers:type_name -> PeerAbridged
-	2,  // 4: PeerService.Add:input_type -> AddPeerRequest
-	10, // 5: PeerService.Remove:input_type -> EntityIdRequest
-	4,  // 6: PeerService.Update:input_type -> UpdatePeerRequest
-	10, // 7: PeerService.Get:input_type -> EntityIdRequest
-	5,  // 8: PeerService.GetAll:input_type -> GetPeersRequest
-	10, // 9: PeerService.Enable:input_type -> EntityIdRequest
-	10, // 10: PeerService.Disable:input_type -> EntityIdRequest
-	10, // 11: PeerService.DownloadConfig:input_type -> EntityIdRequest
-	10, // 12: PeerService.DownloadQRCode:input_type -> EntityIdRequest
-	10, // 13: PeerService.Add:output_type -> EntityIdRequest
-	11, // 14: PeerService.Remove:output_type -> google.protobuf.Empty
-	11, // 15: PeerService.Update:output_type -> google.protobuf.Empty
-	0,  // 16: PeerService.Get:output_type -> Peer
-	6,  // 17: PeerService.GetAll:output_type -> GetPeersResponse
-	11, // 18: PeerService.Enable:output_type -> google.protobuf.Empty
-	11, // 19: PeerService.Disable:output_type -> google.protobuf.Empty
-	7,  // 20: PeerService.DownloadConfig:output_type -> DownloadFileResponse
-	7,  // 21: PeerService.DownloadQRCode:output_type -> DownloadFileResponse
-	13, // [13:22] is the sub-list for method output_type
-	4,  // [4:13] is the sub-list for method input_type
-	4,  // [4:4] is the sub-list for extension type_name
-	4,  // [4:4] is the sub-list for extension extendee
-	0,  // [0:4] is the sub-list for field type_name
+	19, // 0: Peer.last_handshake:type_name -> google.protobuf.Timestamp
+	19, // 1: Peer.expires_at:type_name -> google.protobuf.Timestamp
+	0,  // 2: Peer.quota_period:type_name -> QuotaPeriod
+	19, // 3: Peer.usage_period_start:type_name -> google.protobuf.Timestamp
+	19, // 4: PeerAbridged.expires_at:type_name -> google.protobuf.Timestamp
+	0,  // 5: PeerAbridged.quota_period:type_name -> QuotaPeriod
+	19, // 6: AddPeerRequest.expires_at:type_name -> google.protobuf.Timestamp
+	0,  // 7: AddPeerRequest.quota_period:type_name -> QuotaPeriod
+	5,  // 8: BulkAddPeersRequest.peers:type_name -> BulkPeerEntry
+	2,  // 9: BulkAddPeerResult.peer:type_name -> Peer
+	7,  // 10: BulkAddPeerResult.errors:type_name -> FieldViolation
+	8,  // 11: BulkAddPeersResponse.results:type_name -> BulkAddPeerResult
+	19, // 12: UpdatePeerData.expires_at:type_name -> google.protobuf.Timestamp
+	0,  // 13: UpdatePeerData.quota_period:type_name -> QuotaPeriod
+	10, // 14: UpdatePeerRequest.peer:type_name -> UpdatePeerData
+	20, // 15: UpdatePeerRequest.field_mask:type_name -> google.protobuf.FieldMask
+	3,  // 16: GetPeersResponse.peers:type_name -> PeerAbridged
+	1,  // 17: PeerEvent.type:type_name -> PeerEventType
+	19, // 18: PeerEvent.time:type_name -> google.protobuf.Timestamp
+	2,  // 19: PeerEvent.peer:type_name -> Peer
+	4,  // 20: PeerService.Add:input_type -> AddPeerRequest
+	6,  // 21: PeerService.BulkAdd:input_type -> BulkAddPeersRequest
+	6,  // 22: PeerService.BulkAddStream:input_type -> BulkAddPeersRequest
+	21, // 23: PeerService.Remove:input_type -> EntityIdRequest
+	11, // 24: PeerService.Update:input_type -> UpdatePeerRequest
+	21, // 25: PeerService.Get:input_type -> EntityIdRequest
+	12, // 26: PeerService.GetAll:input_type -> GetPeersRequest
+	21, // 27: PeerService.Enable:input_type -> EntityIdRequest
+	21, // 28: PeerService.Disable:input_type -> EntityIdRequest
+	21, // 29: PeerService.DownloadConfig:input_type -> EntityIdRequest
+	21, // 30: PeerService.DownloadQRCode:input_type -> EntityIdRequest
+	21, // 31: PeerService.ResetQuota:input_type -> EntityIdRequest
+	15, // 32: PeerService.RotateKeys:input_type -> RotatePeerKeysRequest
+	16, // 33: PeerService.Watch:input_type -> WatchPeerRequest
+	17, // 34: PeerService.WatchDevice:input_type -> WatchDevicePeersRequest
+	21, // 35: PeerService.Add:output_type -> EntityIdRequest
+	9,  // 36: PeerService.BulkAdd:output_type -> BulkAddPeersResponse
+	9,  // 37: PeerService.BulkAddStream:output_type -> BulkAddPeersResponse
+	22, // 38: PeerService.Remove:output_type -> google.protobuf.Empty
+	22, // 39: PeerService.Update:output_type -> google.protobuf.Empty
+	2,  // 40: PeerService.Get:output_type -> Peer
+	13, // 41: PeerService.GetAll:output_type -> GetPeersResponse
+	22, // 42: PeerService.Enable:output_type -> google.protobuf.Empty
+	22, // 43: PeerService.Disable:output_type -> google.protobuf.Empty
+	14, // 44: PeerService.DownloadConfig:output_type -> DownloadFileResponse
+	14, // 45: PeerService.DownloadQRCode:output_type -> DownloadFileResponse
+	22, // 46: PeerService.ResetQuota:output_type -> google.protobuf.Empty
+	2,  // 47: PeerService.RotateKeys:output_type -> Peer
+	18, // 48: PeerService.Watch:output_type -> PeerEvent
+	18, // 49: PeerService.WatchDevice:output_type -> PeerEvent
+	35, // [35:50] is the sub-list for method output_type
+	20, // [20:35] is the sub-list for method input_type
+	20, // [20:20] is the sub-list for extension type_name
+	20, // [20:20] is the sub-list for extension extendee
+	0,  // [0:20] is the sub-list for field type_name
 }
 
 func init() { file_peer_service_proto_init() }
@@ -1152,7 +2348,7 @@ func file_peer_service_proto_init() {
 			}
 		}
 		file_peer_service_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*UpdatePeerData); i {
+			switch v := v.(*BulkPeerEntry); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1164,7 +2360,7 @@ func file_peer_service_proto_init() {
 			}
 		}
 		file_peer_service_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*UpdatePeerRequest); i {
+			switch v := v.(*BulkAddPeersRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1176,7 +2372,7 @@ func file_peer_service_proto_init() {
 			}
 		}
 		file_peer_service_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetPeersRequest); i {
+			switch v := v.(*FieldViolation); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1188,7 +2384,7 @@ func file_peer_service_proto_init() {
 			}
 		}
 		file_peer_service_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetPeersResponse); i {
+			switch v := v.(*BulkAddPeerResult); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1200,6 +2396,66 @@ func file_peer_service_proto_init() {
 			}
 		}
 		file_peer_service_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BulkAddPeersResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_peer_service_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdatePeerData); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_peer_service_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdatePeerRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_peer_service_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetPeersRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_peer_service_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetPeersResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_peer_service_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*DownloadFileResponse); i {
 			case 0:
 				return &v.state
@@ -1211,19 +2467,68 @@ func file_peer_service_proto_init() {
 				return nil
 			}
 		}
+		file_peer_service_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RotatePeerKeysRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_peer_service_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WatchPeerRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_peer_service_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WatchDevicePeersRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_peer_service_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PeerEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_peer_service_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   8,
+			NumEnums:      2,
+			NumMessages:   17,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_peer_service_proto_goTypes,
 		DependencyIndexes: file_peer_service_proto_depIdxs,
+		EnumInfos:         file_peer_service_proto_enumTypes,
 		MessageInfos:      file_peer_service_proto_msgTypes,
 	}.Build()
 	File_peer_service_proto = out.File
@@ -24,6 +24,9 @@
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type PeerServiceClient interface {
 	Add(ctx context.Context, in *AddPeerRequest, opts ...grpc.CallOption) (*EntityIdRequest, error)
+	BulkAdd(ctx context.Context, in *BulkAddPeersRequest, opts ...grpc.CallOption) (*BulkAddPeersResponse, error)
+	// BulkAddStream collects entries from all received messages and adds them as a single bulk request.
+	BulkAddStream(ctx context.Context, opts ...grpc.CallOption) (PeerService_BulkAddStreamClient, error)
 	Remove(ctx context.Context, in *EntityIdRequest, opts ...grpc.CallOption) (*empty.Empty, error)
 	Update(ctx context.Context, in *UpdatePeerRequest, opts ...grpc.CallOption) (*empty.Empty, error)
 	Get(ctx context.Context, in *EntityIdRequest, opts ...grpc.CallOption) (*Peer, error)
@@ -32,6 +35,10 @@ type PeerServiceClient interface {
 	Disable(ctx context.Context, in *EntityIdRequest, opts ...grpc.CallOption) (*empty.Empty, error)
 	DownloadConfig(ctx context.Context, in *EntityIdRequest, opts ...grpc.CallOption) (*DownloadFileResponse, error)
 	DownloadQRCode(ctx context.Context, in *EntityIdRequest, opts ...grpc.CallOption) (*DownloadFileResponse, error)
+	ResetQuota(ctx context.Context, in *EntityIdRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	RotateKeys(ctx context.Context, in *RotatePeerKeysRequest, opts ...grpc.CallOption) (*Peer, error)
+	Watch(ctx context.Context, in *WatchPeerRequest, opts ...grpc.CallOption) (PeerService_WatchClient, error)
+	WatchDevice(ctx context.Context, in *WatchDevicePeersRequest, opts ...grpc.CallOption) (PeerService_WatchDeviceClient, error)
 }
 
 type peerServiceClient struct {
@@ -51,6 +58,49 @@ func (c *peerServiceClient) Add(ctx context.Context, in *AddPeerRequest, opts ..
 	return out, nil
 }
 
+func (c *peerServiceClient) BulkAdd(ctx context.Context, in *BulkAddPeersRequest, opts ...grpc.CallOption) (*BulkAddPeersResponse, error) {
+	out := new(BulkAddPeersResponse)
+	err := c.cc.Invoke(ctx, "/PeerService/BulkAdd", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *peerServiceClient) BulkAddStream(ctx context.Context, opts ...grpc.CallOption) (PeerService_BulkAddStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &PeerService_ServiceDesc.Streams[0], "/PeerService/BulkAddStream", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &peerServiceBulkAddStreamClient{stream}
+	return x, nil
+}
+
+type PeerService_BulkAddStreamClient interface {
+	Send(*BulkAddPeersRequest) error
+	CloseAndRecv() (*BulkAddPeersResponse, error)
+	grpc.ClientStream
+}
+
+type peerServiceBulkAddStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *peerServiceBulkAddStreamClient) Send(m *BulkAddPeersRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *peerServiceBulkAddStreamClient) CloseAndRecv() (*BulkAddPeersResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(BulkAddPeersResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *peerServiceClient) Remove(ctx context.Context, in *EntityIdRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
 	out := new(empty.Empty)
 	err := c.cc.Invoke(ctx, "/PeerService/Remove", in, out, opts...)
@@ -123,11 +173,96 @@ func (c *peerServiceClient) DownloadQRCode(ctx context.Context, in *EntityIdRequ
 	return out, nil
 }
 
+func (c *peerServiceClient) ResetQuota(ctx context.Context, in *EntityIdRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/PeerService/ResetQuota", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *peerServiceClient) RotateKeys(ctx context.Context, in *RotatePeerKeysRequest, opts ...grpc.CallOption) (*Peer, error) {
+	out := new(Peer)
+	err := c.cc.Invoke(ctx, "/PeerService/RotateKeys", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *peerServiceClient) Watch(ctx context.Context, in *WatchPeerRequest, opts ...grpc.CallOption) (PeerService_WatchClient, error) {
+	stream, err := c.cc.NewStream(ctx, &PeerService_ServiceDesc.Streams[1], "/PeerService/Watch", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &peerServiceWatchClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type PeerService_WatchClient interface {
+	Recv() (*PeerEvent, error)
+	grpc.ClientStream
+}
+
+type peerServiceWatchClient struct {
+	grpc.ClientStream
+}
+
+func (x *peerServiceWatchClient) Recv() (*PeerEvent, error) {
+	m := new(PeerEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *peerServiceClient) WatchDevice(ctx context.Context, in *WatchDevicePeersRequest, opts ...grpc.CallOption) (PeerService_WatchDeviceClient, error) {
+	stream, err := c.cc.NewStream(ctx, &PeerService_ServiceDesc.Streams[2], "/PeerService/WatchDevice", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &peerServiceWatchDeviceClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type PeerService_WatchDeviceClient interface {
+	Recv() (*PeerEvent, error)
+	grpc.ClientStream
+}
+
+type peerServiceWatchDeviceClient struct {
+	grpc.ClientStream
+}
+
+func (x *peerServiceWatchDeviceClient) Recv() (*PeerEvent, error) {
+	m := new(PeerEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // PeerServiceServer is the server API for PeerService service.
 // All implementations must embed UnimplementedPeerServiceServer
 // for forward compatibility
 type PeerServiceServer interface {
 	Add(context.Context, *AddPeerRequest) (*EntityIdRequest, error)
+	BulkAdd(context.Context, *BulkAddPeersRequest) (*BulkAddPeersResponse, error)
+	// BulkAddStream collects entries from all received messages and adds them as a single bulk request.
+	BulkAddStream(PeerService_BulkAddStreamServer) error
 	Remove(context.Context, *EntityIdRequest) (*empty.Empty, error)
 	Update(context.Context, *UpdatePeerRequest) (*empty.Empty, error)
 	Get(context.Context, *EntityIdRequest) (*Peer, error)
@@ -136,6 +271,10 @@ type PeerServiceServer interface {
 	Disable(context.Context, *EntityIdRequest) (*empty.Empty, error)
 	DownloadConfig(context.Context, *EntityIdRequest) (*DownloadFileResponse, error)
 	DownloadQRCode(context.Context, *EntityIdRequest) (*DownloadFileResponse, error)
+	ResetQuota(context.Context, *EntityIdRequest) (*empty.Empty, error)
+	RotateKeys(context.Context, *RotatePeerKeysRequest) (*Peer, error)
+	Watch(*WatchPeerRequest, PeerService_WatchServer) error
+	WatchDevice(*WatchDevicePeersRequest, PeerService_WatchDeviceServer) error
 	mustEmbedUnimplementedPeerServiceServer()
 }
 
@@ -146,6 +285,12 @@ type UnimplementedPeerServiceServer struct {
 func (UnimplementedPeerServiceServer) Add(context.Context, *AddPeerRequest) (*EntityIdRequest, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Add not implemented")
 }
+func (UnimplementedPeerServiceServer) BulkAdd(context.Context, *BulkAddPeersRequest) (*BulkAddPeersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BulkAdd not implemented")
+}
+func (UnimplementedPeerServiceServer) BulkAddStream(PeerService_BulkAddStreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method BulkAddStream not implemented")
+}
 func (UnimplementedPeerServiceServer) Remove(context.Context, *EntityIdRequest) (*empty.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Remove not implemented")
 }
@@ -170,6 +315,18 @@ func (UnimplementedPeerServiceServer) DownloadConfig(context.Context, *EntityIdR
 func (UnimplementedPeerServiceServer) DownloadQRCode(context.Context, *EntityIdRequest) (*DownloadFileResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DownloadQRCode not implemented")
 }
+func (UnimplementedPeerServiceServer) ResetQuota(context.Context, *EntityIdRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResetQuota not implemented")
+}
+func (UnimplementedPeerServiceServer) RotateKeys(context.Context, *RotatePeerKeysRequest) (*Peer, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RotateKeys not implemented")
+}
+func (UnimplementedPeerServiceServer) Watch(*WatchPeerRequest, PeerService_WatchServer) error {
+	return status.Errorf(codes.Unimplemented, "method Watch not implemented")
+}
+func (UnimplementedPeerServiceServer) WatchDevice(*WatchDevicePeersRequest, PeerService_WatchDeviceServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchDevice not implemented")
+}
 func (UnimplementedPeerServiceServer) mustEmbedUnimplementedPeerServiceServer() {}
 
 // UnsafePeerServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -201,6 +358,50 @@ func _PeerService_Add_Handler(srv interface{}, ctx context.Context, dec func(int
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PeerService_BulkAdd_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BulkAddPeersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PeerServiceServer).BulkAdd(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/PeerService/BulkAdd",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PeerServiceServer).BulkAdd(ctx, req.(*BulkAddPeersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PeerService_BulkAddStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(PeerServiceServer).BulkAddStream(&peerServiceBulkAddStreamServer{stream})
+}
+
+type PeerService_BulkAddStreamServer interface {
+	SendAndClose(*BulkAddPeersResponse) error
+	Recv() (*BulkAddPeersRequest, error)
+	grpc.ServerStream
+}
+
+type peerServiceBulkAddStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *peerServiceBulkAddStreamServer) SendAndClose(m *BulkAddPeersResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *peerServiceBulkAddStreamServer) Recv() (*BulkAddPeersRequest, error) {
+	m := new(BulkAddPeersRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func _PeerService_Remove_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(EntityIdRequest)
 	if err := dec(in); err != nil {
@@ -345,6 +546,84 @@ func _PeerService_DownloadQRCode_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PeerService_ResetQuota_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EntityIdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PeerServiceServer).ResetQuota(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/PeerService/ResetQuota",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PeerServiceServer).ResetQuota(ctx, req.(*EntityIdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PeerService_RotateKeys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RotatePeerKeysRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PeerServiceServer).RotateKeys(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/PeerService/RotateKeys",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PeerServiceServer).RotateKeys(ctx, req.(*RotatePeerKeysRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PeerService_Watch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchPeerRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(PeerServiceServer).Watch(m, &peerServiceWatchServer{stream})
+}
+
+type PeerService_WatchServer interface {
+	Send(*PeerEvent) error
+	grpc.ServerStream
+}
+
+type peerServiceWatchServer struct {
+	grpc.ServerStream
+}
+
+func (x *peerServiceWatchServer) Send(m *PeerEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _PeerService_WatchDevice_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchDevicePeersRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(PeerServiceServer).WatchDevice(m, &peerServiceWatchDeviceServer{stream})
+}
+
+type PeerService_WatchDeviceServer interface {
+	Send(*PeerEvent) error
+	grpc.ServerStream
+}
+
+type peerServiceWatchDeviceServer struct {
+	grpc.ServerStream
+}
+
+func (x *peerServiceWatchDeviceServer) Send(m *PeerEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 // PeerService_ServiceDesc is the grpc.ServiceDesc for PeerService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -356,6 +635,10 @@ func _PeerService_DownloadQRCode_Handler(srv interface{}, ctx context.Context, d
 			MethodName: "Add",
 			Handler:    _PeerService_Add_Handler,
 		},
+		{
+			MethodName: "BulkAdd",
+			Handler:    _PeerService_BulkAdd_Handler,
+		},
 		{
 			MethodName: "Remove",
 			Handler:    _PeerService_Remove_Handler,
@@ -388,7 +671,31 @@ func _PeerService_DownloadQRCode_Handler(srv interface{}, ctx context.Context, d
 			MethodName: "DownloadQRCode",
 			Handler:    _PeerService_DownloadQRCode_Handler,
 		},
+		{
+			MethodName: "ResetQuota",
+			Handler:    _PeerService_ResetQuota_Handler,
+		},
+		{
+			MethodName: "RotateKeys",
+			Handler:    _PeerService_RotateKeys_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "BulkAddStream",
+			Handler:       _PeerService_BulkAddStream_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "Watch",
+			Handler:       _PeerService_Watch_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "WatchDevice",
+			Handler:       _PeerService_WatchDevice_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "peer_service.proto",
 }
@@ -1,11 +1,37 @@
 package app
 
+import "time"
+
 type Config struct {
-	IsProduction bool     `env:"PRODUCTION"`
-	Host         string   `env:"HOST" envDefault:"localhost"`
-	Port         int      `env:"PORT" envDefault:"3000"`
-	ServeSwagger bool     `env:"SWAGGER"`
-	Tokens       []string `env:"TOKENS" envSeparator:","`
+	IsProduction bool   `env:"PRODUCTION"`
+	Host         string `env:"HOST" envDefault:"localhost"`
+	Port         int    `env:"PORT" envDefault:"3000"`
+	ServeSwagger bool   `env:"SWAGGER"`
+	ServeMetrics bool   `env:"METRICS"`
+	// Tokens are in "secret[:role[:device_id;device_id...]]" format, token without role is an admin one.
+	Tokens []string `env:"TOKENS" envSeparator:","`
+
+	// InterfaceBackend is either wg-quick, netlink, userspace or netstack. Netlink applies device updates in place
+	// without dropping peer sessions, userspace does the same running interfaces with the embedded wireguard-go,
+	// so no kernel module is needed. Only these two support per-device network namespaces.
+	// Userspace interfaces are still TUN links routing peer traffic through the host, so the process needs
+	// CAP_NET_ADMIN and access to /dev/net/tun (e.g. --cap-add NET_ADMIN --device /dev/net/tun in docker).
+	// Netstack runs the embedded wireguard-go on in-process network stacks and needs neither, peer traffic
+	// ends inside the process then and is not routed to the host.
+	InterfaceBackend string `env:"INTERFACE_BACKEND" envDefault:"wg-quick"`
+
+	// SchedulerInterval is how often peer expiration and quotas are enforced and device key cutover is checked.
+	SchedulerInterval time.Duration `env:"SCHEDULER_INTERVAL" envDefault:"1m"`
+	// ReconcileInterval of zero disables reconciliation of interfaces with the database,
+	// drift is only reported and never fixed when ReconcileDriftOnly is set.
+	ReconcileInterval  time.Duration `env:"RECONCILE_INTERVAL" envDefault:"1m"`
+	ReconcileDriftOnly bool          `env:"RECONCILE_DRIFT_ONLY"`
+
+	JWTSecret       string `env:"JWT_SECRET"`
+	JWTJWKSFile     string `env:"JWT_JWKS_FILE"`
+	JWTAudience     string `env:"JWT_AUDIENCE"`
+	JWTRoleClaim    string `env:"JWT_ROLE_CLAIM" envDefault:"role"`
+	JWTDevicesClaim string `env:"JWT_DEVICES_CLAIM" envDefault:"devices"`
 
 	DBHost               string `env:"DB_HOST,required"`
 	DBPort               int    `env:"DB_PORT" envDefault:"5432"`
@@ -19,4 +45,16 @@ type Config struct {
 	CaCert string `env:"CACERT"`
 	Cert   string `env:"CERT"`
 	Key    string `env:"KEY"`
+	// ClientCaCert enables mutual TLS, client certificates are verified against this CA bundle,
+	// certificates of REST clients are forwarded to gRPC handlers by the gateway.
+	ClientCaCert string `env:"CLIENT_CACERT"`
+
+	// MasterKey is base64 encoded 32 bytes key encrypting private and preshared keys in the database,
+	// keys are stored unencrypted when neither it nor MasterKeyFile is set. Keys stored before it was set
+	// are encrypted by rotate-master-key command, NewMasterKey may be the same key.
+	MasterKey     string `env:"MASTER_KEY"`
+	MasterKeyFile string `env:"MASTER_KEY_FILE"`
+	// NewMasterKey and NewMasterKeyFile are read by rotate-master-key command only.
+	NewMasterKey     string `env:"NEW_MASTER_KEY"`
+	NewMasterKeyFile string `env:"NEW_MASTER_KEY_FILE"`
 }
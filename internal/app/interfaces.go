@@ -4,6 +4,7 @@
 import (
 	"context"
 	"database/sql"
+	"time"
 
 	"github.com/AZhur771/wg-grpc-api/internal/dto"
 	"github.com/AZhur771/wg-grpc-api/internal/entity"
@@ -20,6 +21,21 @@ type WgCtrl interface {
 	ConfigureDevice(name string, cfg wgtypes.Config) error
 }
 
+// InterfaceBackend manages lifecycle of wireguard interfaces of devices.
+type InterfaceBackend interface {
+	// Create brings interface of the device up, existing interface gets device settings and keeps its peers.
+	Create(dev *entity.Device) error
+	// Configure applies key and peer changes to the interface.
+	Configure(name string, cfg wgtypes.Config) error
+	// Destroy removes interface of the device, missing interface is not an error.
+	Destroy(dev *entity.Device) error
+	// Device returns state of the interface, error wraps os.ErrNotExist when it is missing.
+	Device(name string) (*wgtypes.Device, error)
+	// Devices lists all wireguard interfaces of the host including the ones not managed by the service,
+	// interfaces of foreign network namespaces are only listed if devices were moved there.
+	Devices() ([]*wgtypes.Device, error)
+}
+
 type PeerService interface {
 	Add(ctx context.Context, dt dto.AddPeerDTO) (*entity.Peer, error)
 	Update(ctx context.Context, dt dto.UpdatePeerDTO, mask fieldmask_utils.Mask) (*entity.Peer, error)
@@ -30,6 +46,10 @@ type PeerService interface {
 	Disable(ctx context.Context, id uuid.UUID) error
 	DownloadConfig(ctx context.Context, id uuid.UUID) (dto.DownloadFileDTO, error)
 	DownloadQRCode(ctx context.Context, id uuid.UUID) (dto.DownloadFileDTO, error)
+	ResetQuota(ctx context.Context, id uuid.UUID) error
+	RotateKeys(ctx context.Context, dt dto.RotatePeerKeysDTO) (*entity.Peer, error)
+	BulkAdd(ctx context.Context, dt dto.BulkAddPeersDTO) (dto.BulkAddPeersResponseDTO, error)
+	Watch(ctx context.Context, deviceID, peerID uuid.UUID, interval time.Duration, send func(entity.PeerEvent) error) error
 }
 
 type DeviceService interface {
@@ -39,6 +59,10 @@ type DeviceService interface {
 	Get(ctx context.Context, id uuid.UUID) (*entity.Device, error)
 	GetAll(ctx context.Context, dt dto.GetDevicesRequestDTO) (dto.GetDevicesResponseDTO, error)
 	ConfigureDevice(device string, config wgtypes.PeerConfig) error
+	ConfigurePeers(device string, configs []wgtypes.PeerConfig) error
+	RotateKey(ctx context.Context, dt dto.RotateDeviceKeyDTO) (dto.RotateDeviceKeyResponseDTO, error)
+	Import(ctx context.Context, dt dto.ImportDeviceDTO) (dto.ImportDeviceResponseDTO, error)
+	LastReconcileReport() (*entity.ReconcileReport, error)
 	GetConfiguredPeer(dev string, publicKey wgtypes.Key) (wgtypes.Peer, error)
 	GetConfiguredPeers(dev string) ([]wgtypes.Peer, error)
 }
@@ -46,9 +70,14 @@ type DeviceService interface {
 type PeerRepo interface {
 	Add(ctx context.Context, tx *sqlx.Tx, peer *entity.Peer) (*entity.Peer, error)
 	Update(ctx context.Context, tx *sqlx.Tx, peer *entity.Peer) (*entity.Peer, error)
+	UpdateUsage(ctx context.Context, tx *sqlx.Tx, peer *entity.Peer) error
+	UpdateKeys(ctx context.Context, tx *sqlx.Tx, peer *entity.Peer) error
 	Remove(ctx context.Context, tx *sqlx.Tx, id uuid.UUID) error
 	Get(ctx context.Context, tx *sqlx.Tx, id uuid.UUID) (*entity.Peer, error)
 	GetAll(ctx context.Context, tx *sqlx.Tx, skip, limit int, search string, deviceID uuid.UUID) ([]*entity.Peer, error)
+	GetExpired(ctx context.Context, tx *sqlx.Tx, now time.Time) ([]*entity.Peer, error)
+	IsAddressTaken(ctx context.Context, tx *sqlx.Tx, deviceID, excludePeerID uuid.UUID, addr string) (bool, error)
+	GetRoutes(ctx context.Context, tx *sqlx.Tx, deviceID, excludePeerID uuid.UUID) ([]string, error)
 	Count(ctx context.Context, tx *sqlx.Tx, deviceID uuid.UUID) (int, error)
 	BeginTxx(ctx context.Context, options *sql.TxOptions) (*sqlx.Tx, error)
 }
@@ -60,6 +89,33 @@ type DeviceRepo interface {
 	Get(ctx context.Context, tx *sqlx.Tx, id uuid.UUID) (*entity.Device, error)
 	GetAll(ctx context.Context, tx *sqlx.Tx, skip, limit int, search string) ([]*entity.Device, error)
 	Count(ctx context.Context, tx *sqlx.Tx) (int, error)
-	GenerateAddress(ctx context.Context, tx *sqlx.Tx, dev *entity.Device) (string, error)
+	GenerateAddress(ctx context.Context, tx *sqlx.Tx, dev *entity.Device, cidr string) (string, error)
+	UpdateKey(ctx context.Context, tx *sqlx.Tx, dev *entity.Device) error
 	BeginTxx(ctx context.Context, options *sql.TxOptions) (*sqlx.Tx, error)
 }
+
+type APIKeyService interface {
+	Create(ctx context.Context, dt dto.CreateAPIKeyDTO) (*entity.APIKey, string, error)
+	GetAll(ctx context.Context, dt dto.GetAPIKeysRequestDTO) (dto.GetAPIKeysResponseDTO, error)
+	Revoke(ctx context.Context, id uuid.UUID) error
+}
+
+type APIKeyRepo interface {
+	Add(ctx context.Context, tx *sqlx.Tx, key *entity.APIKey) (*entity.APIKey, error)
+	Get(ctx context.Context, tx *sqlx.Tx, id uuid.UUID) (*entity.APIKey, error)
+	GetAll(ctx context.Context, tx *sqlx.Tx, skip, limit int) ([]*entity.APIKey, error)
+	Count(ctx context.Context, tx *sqlx.Tx) (int, error)
+	Revoke(ctx context.Context, tx *sqlx.Tx, id uuid.UUID, at time.Time) error
+	UpdateLastUsed(ctx context.Context, tx *sqlx.Tx, id uuid.UUID, at time.Time) error
+}
+
+type AuditService interface {
+	Record(ctx context.Context, action entity.AuditAction, entityType string, entityID uuid.UUID, before, after map[string]string)
+	List(ctx context.Context, dt dto.GetAuditRecordsRequestDTO) (dto.GetAuditRecordsResponseDTO, error)
+}
+
+type AuditRepo interface {
+	Add(ctx context.Context, tx *sqlx.Tx, record *entity.AuditRecord) (*entity.AuditRecord, error)
+	GetAll(ctx context.Context, tx *sqlx.Tx, skip, limit int, filter entity.AuditFilter) ([]*entity.AuditRecord, error)
+	Count(ctx context.Context, tx *sqlx.Tx, filter entity.AuditFilter) (int, error)
+}
@@ -8,6 +8,7 @@
 	context "context"
 	sql "database/sql"
 	reflect "reflect"
+	time "time"
 
 	dto "github.com/AZhur771/wg-grpc-api/internal/dto"
 	entity "github.com/AZhur771/wg-grpc-api/internal/entity"
@@ -99,6 +100,101 @@ func (mr *MockWgCtrlMockRecorder) Devices() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Devices", reflect.TypeOf((*MockWgCtrl)(nil).Devices))
 }
 
+// MockInterfaceBackend is a mock of InterfaceBackend interface.
+type MockInterfaceBackend struct {
+	ctrl     *gomock.Controller
+	recorder *MockInterfaceBackendMockRecorder
+}
+
+// MockInterfaceBackendMockRecorder is the mock recorder for MockInterfaceBackend.
+type MockInterfaceBackendMockRecorder struct {
+	mock *MockInterfaceBackend
+}
+
+// NewMockInterfaceBackend creates a new mock instance.
+func NewMockInterfaceBackend(ctrl *gomock.Controller) *MockInterfaceBackend {
+	mock := &MockInterfaceBackend{ctrl: ctrl}
+	mock.recorder = &MockInterfaceBackendMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInterfaceBackend) EXPECT() *MockInterfaceBackendMockRecorder {
+	return m.recorder
+}
+
+// Configure mocks base method.
+func (m *MockInterfaceBackend) Configure(name string, cfg wgtypes.Config) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Configure", name, cfg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Configure indicates an expected call of Configure.
+func (mr *MockInterfaceBackendMockRecorder) Configure(name, cfg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Configure", reflect.TypeOf((*MockInterfaceBackend)(nil).Configure), name, cfg)
+}
+
+// Create mocks base method.
+func (m *MockInterfaceBackend) Create(dev *entity.Device) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", dev)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockInterfaceBackendMockRecorder) Create(dev interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockInterfaceBackend)(nil).Create), dev)
+}
+
+// Destroy mocks base method.
+func (m *MockInterfaceBackend) Destroy(dev *entity.Device) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Destroy", dev)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Destroy indicates an expected call of Destroy.
+func (mr *MockInterfaceBackendMockRecorder) Destroy(dev interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Destroy", reflect.TypeOf((*MockInterfaceBackend)(nil).Destroy), dev)
+}
+
+// Device mocks base method.
+func (m *MockInterfaceBackend) Device(name string) (*wgtypes.Device, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Device", name)
+	ret0, _ := ret[0].(*wgtypes.Device)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Device indicates an expected call of Device.
+func (mr *MockInterfaceBackendMockRecorder) Device(name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Device", reflect.TypeOf((*MockInterfaceBackend)(nil).Device), name)
+}
+
+// Devices mocks base method.
+func (m *MockInterfaceBackend) Devices() ([]*wgtypes.Device, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Devices")
+	ret0, _ := ret[0].([]*wgtypes.Device)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Devices indicates an expected call of Devices.
+func (mr *MockInterfaceBackendMockRecorder) Devices() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Devices", reflect.TypeOf((*MockInterfaceBackend)(nil).Devices))
+}
+
 // MockPeerService is a mock of PeerService interface.
 type MockPeerService struct {
 	ctrl     *gomock.Controller
@@ -137,6 +233,21 @@ func (mr *MockPeerServiceMockRecorder) Add(ctx, dt interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockPeerService)(nil).Add), ctx, dt)
 }
 
+// BulkAdd mocks base method.
+func (m *MockPeerService) BulkAdd(ctx context.Context, dt dto.BulkAddPeersDTO) (dto.BulkAddPeersResponseDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BulkAdd", ctx, dt)
+	ret0, _ := ret[0].(dto.BulkAddPeersResponseDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BulkAdd indicates an expected call of BulkAdd.
+func (mr *MockPeerServiceMockRecorder) BulkAdd(ctx, dt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkAdd", reflect.TypeOf((*MockPeerService)(nil).BulkAdd), ctx, dt)
+}
+
 // Disable mocks base method.
 func (m *MockPeerService) Disable(ctx context.Context, id uuid.UUID) error {
 	m.ctrl.T.Helper()
@@ -239,6 +350,35 @@ func (mr *MockPeerServiceMockRecorder) Remove(ctx, id interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Remove", reflect.TypeOf((*MockPeerService)(nil).Remove), ctx, id)
 }
 
+// ResetQuota mocks base method.
+func (m *MockPeerService) ResetQuota(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetQuota", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetQuota indicates an expected call of ResetQuota.
+func (mr *MockPeerServiceMockRecorder) ResetQuota(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetQuota", reflect.TypeOf((*MockPeerService)(nil).ResetQuota), ctx, id)
+}
+
+// RotateKeys mocks base method.
+func (m *MockPeerService) RotateKeys(ctx context.Context, dt dto.RotatePeerKeysDTO) (*entity.Peer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RotateKeys", ctx, dt)
+	ret0, _ := ret[0].(*entity.Peer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RotateKeys indicates an expected call of RotateKeys.
+func (mr *MockPeerServiceMockRecorder) RotateKeys(ctx, dt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateKeys", reflect.TypeOf((*MockPeerService)(nil).RotateKeys), ctx, dt)
+}
+
 // Update mocks base method.
 func (m *MockPeerService) Update(ctx context.Context, dt dto.UpdatePeerDTO, mask fieldmask_utils.Mask) (*entity.Peer, error) {
 	m.ctrl.T.Helper()
@@ -254,6 +394,20 @@ func (mr *MockPeerServiceMockRecorder) Update(ctx, dt, mask interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockPeerService)(nil).Update), ctx, dt, mask)
 }
 
+// Watch mocks base method.
+func (m *MockPeerService) Watch(ctx context.Context, deviceID, peerID uuid.UUID, interval time.Duration, send func(entity.PeerEvent) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Watch", ctx, deviceID, peerID, interval, send)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Watch indicates an expected call of Watch.
+func (mr *MockPeerServiceMockRecorder) Watch(ctx, deviceID, peerID, interval, send interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Watch", reflect.TypeOf((*MockPeerService)(nil).Watch), ctx, deviceID, peerID, interval, send)
+}
+
 // MockDeviceService is a mock of DeviceService interface.
 type MockDeviceService struct {
 	ctrl     *gomock.Controller
@@ -306,6 +460,20 @@ func (mr *MockDeviceServiceMockRecorder) ConfigureDevice(device, config interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfigureDevice", reflect.TypeOf((*MockDeviceService)(nil).ConfigureDevice), device, config)
 }
 
+// ConfigurePeers mocks base method.
+func (m *MockDeviceService) ConfigurePeers(device string, configs []wgtypes.PeerConfig) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfigurePeers", device, configs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ConfigurePeers indicates an expected call of ConfigurePeers.
+func (mr *MockDeviceServiceMockRecorder) ConfigurePeers(device, configs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfigurePeers", reflect.TypeOf((*MockDeviceService)(nil).ConfigurePeers), device, configs)
+}
+
 // Get mocks base method.
 func (m *MockDeviceService) Get(ctx context.Context, id uuid.UUID) (*entity.Device, error) {
 	m.ctrl.T.Helper()
@@ -366,6 +534,36 @@ func (mr *MockDeviceServiceMockRecorder) GetConfiguredPeers(dev interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConfiguredPeers", reflect.TypeOf((*MockDeviceService)(nil).GetConfiguredPeers), dev)
 }
 
+// Import mocks base method.
+func (m *MockDeviceService) Import(ctx context.Context, dt dto.ImportDeviceDTO) (dto.ImportDeviceResponseDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Import", ctx, dt)
+	ret0, _ := ret[0].(dto.ImportDeviceResponseDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Import indicates an expected call of Import.
+func (mr *MockDeviceServiceMockRecorder) Import(ctx, dt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Import", reflect.TypeOf((*MockDeviceService)(nil).Import), ctx, dt)
+}
+
+// LastReconcileReport mocks base method.
+func (m *MockDeviceService) LastReconcileReport() (*entity.ReconcileReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LastReconcileReport")
+	ret0, _ := ret[0].(*entity.ReconcileReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LastReconcileReport indicates an expected call of LastReconcileReport.
+func (mr *MockDeviceServiceMockRecorder) LastReconcileReport() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LastReconcileReport", reflect.TypeOf((*MockDeviceService)(nil).LastReconcileReport))
+}
+
 // Remove mocks base method.
 func (m *MockDeviceService) Remove(ctx context.Context, id uuid.UUID) error {
 	m.ctrl.T.Helper()
@@ -380,6 +578,21 @@ func (mr *MockDeviceServiceMockRecorder) Remove(ctx, id interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Remove", reflect.TypeOf((*MockDeviceService)(nil).Remove), ctx, id)
 }
 
+// RotateKey mocks base method.
+func (m *MockDeviceService) RotateKey(ctx context.Context, dt dto.RotateDeviceKeyDTO) (dto.RotateDeviceKeyResponseDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RotateKey", ctx, dt)
+	ret0, _ := ret[0].(dto.RotateDeviceKeyResponseDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RotateKey indicates an expected call of RotateKey.
+func (mr *MockDeviceServiceMockRecorder) RotateKey(ctx, dt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateKey", reflect.TypeOf((*MockDeviceService)(nil).RotateKey), ctx, dt)
+}
+
 // Update mocks base method.
 func (m *MockDeviceService) Update(ctx context.Context, dt dto.UpdateDeviceDTO, mask fieldmask_utils.Mask) (*entity.Device, error) {
 	m.ctrl.T.Helper()
@@ -493,6 +706,51 @@ func (mr *MockPeerRepoMockRecorder) GetAll(ctx, tx, skip, limit, search, deviceI
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockPeerRepo)(nil).GetAll), ctx, tx, skip, limit, search, deviceID)
 }
 
+// GetExpired mocks base method.
+func (m *MockPeerRepo) GetExpired(ctx context.Context, tx *sqlx.Tx, now time.Time) ([]*entity.Peer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExpired", ctx, tx, now)
+	ret0, _ := ret[0].([]*entity.Peer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExpired indicates an expected call of GetExpired.
+func (mr *MockPeerRepoMockRecorder) GetExpired(ctx, tx, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExpired", reflect.TypeOf((*MockPeerRepo)(nil).GetExpired), ctx, tx, now)
+}
+
+// GetRoutes mocks base method.
+func (m *MockPeerRepo) GetRoutes(ctx context.Context, tx *sqlx.Tx, deviceID, excludePeerID uuid.UUID) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRoutes", ctx, tx, deviceID, excludePeerID)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRoutes indicates an expected call of GetRoutes.
+func (mr *MockPeerRepoMockRecorder) GetRoutes(ctx, tx, deviceID, excludePeerID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRoutes", reflect.TypeOf((*MockPeerRepo)(nil).GetRoutes), ctx, tx, deviceID, excludePeerID)
+}
+
+// IsAddressTaken mocks base method.
+func (m *MockPeerRepo) IsAddressTaken(ctx context.Context, tx *sqlx.Tx, deviceID, excludePeerID uuid.UUID, addr string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsAddressTaken", ctx, tx, deviceID, excludePeerID, addr)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsAddressTaken indicates an expected call of IsAddressTaken.
+func (mr *MockPeerRepoMockRecorder) IsAddressTaken(ctx, tx, deviceID, excludePeerID, addr interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsAddressTaken", reflect.TypeOf((*MockPeerRepo)(nil).IsAddressTaken), ctx, tx, deviceID, excludePeerID, addr)
+}
+
 // Remove mocks base method.
 func (m *MockPeerRepo) Remove(ctx context.Context, tx *sqlx.Tx, id uuid.UUID) error {
 	m.ctrl.T.Helper()
@@ -522,6 +780,34 @@ func (mr *MockPeerRepoMockRecorder) Update(ctx, tx, peer interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockPeerRepo)(nil).Update), ctx, tx, peer)
 }
 
+// UpdateKeys mocks base method.
+func (m *MockPeerRepo) UpdateKeys(ctx context.Context, tx *sqlx.Tx, peer *entity.Peer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateKeys", ctx, tx, peer)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateKeys indicates an expected call of UpdateKeys.
+func (mr *MockPeerRepoMockRecorder) UpdateKeys(ctx, tx, peer interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateKeys", reflect.TypeOf((*MockPeerRepo)(nil).UpdateKeys), ctx, tx, peer)
+}
+
+// UpdateUsage mocks base method.
+func (m *MockPeerRepo) UpdateUsage(ctx context.Context, tx *sqlx.Tx, peer *entity.Peer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUsage", ctx, tx, peer)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateUsage indicates an expected call of UpdateUsage.
+func (mr *MockPeerRepoMockRecorder) UpdateUsage(ctx, tx, peer interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUsage", reflect.TypeOf((*MockPeerRepo)(nil).UpdateUsage), ctx, tx, peer)
+}
+
 // MockDeviceRepo is a mock of DeviceRepo interface.
 type MockDeviceRepo struct {
 	ctrl     *gomock.Controller
@@ -591,18 +877,18 @@ func (mr *MockDeviceRepoMockRecorder) Count(ctx, tx interface{}) *gomock.Call {
 }
 
 // GenerateAddress mocks base method.
-func (m *MockDeviceRepo) GenerateAddress(ctx context.Context, tx *sqlx.Tx, dev *entity.Device) (string, error) {
+func (m *MockDeviceRepo) GenerateAddress(ctx context.Context, tx *sqlx.Tx, dev *entity.Device, cidr string) (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GenerateAddress", ctx, tx, dev)
+	ret := m.ctrl.Call(m, "GenerateAddress", ctx, tx, dev, cidr)
 	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GenerateAddress indicates an expected call of GenerateAddress.
-func (mr *MockDeviceRepoMockRecorder) GenerateAddress(ctx, tx, dev interface{}) *gomock.Call {
+func (mr *MockDeviceRepoMockRecorder) GenerateAddress(ctx, tx, dev, cidr interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateAddress", reflect.TypeOf((*MockDeviceRepo)(nil).GenerateAddress), ctx, tx, dev)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateAddress", reflect.TypeOf((*MockDeviceRepo)(nil).GenerateAddress), ctx, tx, dev, cidr)
 }
 
 // Get mocks base method.
@@ -663,3 +949,314 @@ func (mr *MockDeviceRepoMockRecorder) Update(ctx, tx, dev interface{}) *gomock.C
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockDeviceRepo)(nil).Update), ctx, tx, dev)
 }
+
+// UpdateKey mocks base method.
+func (m *MockDeviceRepo) UpdateKey(ctx context.Context, tx *sqlx.Tx, dev *entity.Device) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateKey", ctx, tx, dev)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateKey indicates an expected call of UpdateKey.
+func (mr *MockDeviceRepoMockRecorder) UpdateKey(ctx, tx, dev interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateKey", reflect.TypeOf((*MockDeviceRepo)(nil).UpdateKey), ctx, tx, dev)
+}
+
+// MockAPIKeyService is a mock of APIKeyService interface.
+type MockAPIKeyService struct {
+	ctrl     *gomock.Controller
+	recorder *MockAPIKeyServiceMockRecorder
+}
+
+// MockAPIKeyServiceMockRecorder is the mock recorder for MockAPIKeyService.
+type MockAPIKeyServiceMockRecorder struct {
+	mock *MockAPIKeyService
+}
+
+// NewMockAPIKeyService creates a new mock instance.
+func NewMockAPIKeyService(ctrl *gomock.Controller) *MockAPIKeyService {
+	mock := &MockAPIKeyService{ctrl: ctrl}
+	mock.recorder = &MockAPIKeyServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAPIKeyService) EXPECT() *MockAPIKeyServiceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockAPIKeyService) Create(ctx context.Context, dt dto.CreateAPIKeyDTO) (*entity.APIKey, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, dt)
+	ret0, _ := ret[0].(*entity.APIKey)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockAPIKeyServiceMockRecorder) Create(ctx, dt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockAPIKeyService)(nil).Create), ctx, dt)
+}
+
+// GetAll mocks base method.
+func (m *MockAPIKeyService) GetAll(ctx context.Context, dt dto.GetAPIKeysRequestDTO) (dto.GetAPIKeysResponseDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx, dt)
+	ret0, _ := ret[0].(dto.GetAPIKeysResponseDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockAPIKeyServiceMockRecorder) GetAll(ctx, dt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockAPIKeyService)(nil).GetAll), ctx, dt)
+}
+
+// Revoke mocks base method.
+func (m *MockAPIKeyService) Revoke(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revoke", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Revoke indicates an expected call of Revoke.
+func (mr *MockAPIKeyServiceMockRecorder) Revoke(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revoke", reflect.TypeOf((*MockAPIKeyService)(nil).Revoke), ctx, id)
+}
+
+// MockAPIKeyRepo is a mock of APIKeyRepo interface.
+type MockAPIKeyRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockAPIKeyRepoMockRecorder
+}
+
+// MockAPIKeyRepoMockRecorder is the mock recorder for MockAPIKeyRepo.
+type MockAPIKeyRepoMockRecorder struct {
+	mock *MockAPIKeyRepo
+}
+
+// NewMockAPIKeyRepo creates a new mock instance.
+func NewMockAPIKeyRepo(ctrl *gomock.Controller) *MockAPIKeyRepo {
+	mock := &MockAPIKeyRepo{ctrl: ctrl}
+	mock.recorder = &MockAPIKeyRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAPIKeyRepo) EXPECT() *MockAPIKeyRepoMockRecorder {
+	return m.recorder
+}
+
+// Add mocks base method.
+func (m *MockAPIKeyRepo) Add(ctx context.Context, tx *sqlx.Tx, key *entity.APIKey) (*entity.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Add", ctx, tx, key)
+	ret0, _ := ret[0].(*entity.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockAPIKeyRepoMockRecorder) Add(ctx, tx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockAPIKeyRepo)(nil).Add), ctx, tx, key)
+}
+
+// Count mocks base method.
+func (m *MockAPIKeyRepo) Count(ctx context.Context, tx *sqlx.Tx) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Count", ctx, tx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Count indicates an expected call of Count.
+func (mr *MockAPIKeyRepoMockRecorder) Count(ctx, tx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockAPIKeyRepo)(nil).Count), ctx, tx)
+}
+
+// Get mocks base method.
+func (m *MockAPIKeyRepo) Get(ctx context.Context, tx *sqlx.Tx, id uuid.UUID) (*entity.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, tx, id)
+	ret0, _ := ret[0].(*entity.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockAPIKeyRepoMockRecorder) Get(ctx, tx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockAPIKeyRepo)(nil).Get), ctx, tx, id)
+}
+
+// GetAll mocks base method.
+func (m *MockAPIKeyRepo) GetAll(ctx context.Context, tx *sqlx.Tx, skip, limit int) ([]*entity.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx, tx, skip, limit)
+	ret0, _ := ret[0].([]*entity.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockAPIKeyRepoMockRecorder) GetAll(ctx, tx, skip, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockAPIKeyRepo)(nil).GetAll), ctx, tx, skip, limit)
+}
+
+// Revoke mocks base method.
+func (m *MockAPIKeyRepo) Revoke(ctx context.Context, tx *sqlx.Tx, id uuid.UUID, at time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revoke", ctx, tx, id, at)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Revoke indicates an expected call of Revoke.
+func (mr *MockAPIKeyRepoMockRecorder) Revoke(ctx, tx, id, at interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revoke", reflect.TypeOf((*MockAPIKeyRepo)(nil).Revoke), ctx, tx, id, at)
+}
+
+// UpdateLastUsed mocks base method.
+func (m *MockAPIKeyRepo) UpdateLastUsed(ctx context.Context, tx *sqlx.Tx, id uuid.UUID, at time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateLastUsed", ctx, tx, id, at)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateLastUsed indicates an expected call of UpdateLastUsed.
+func (mr *MockAPIKeyRepoMockRecorder) UpdateLastUsed(ctx, tx, id, at interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLastUsed", reflect.TypeOf((*MockAPIKeyRepo)(nil).UpdateLastUsed), ctx, tx, id, at)
+}
+
+// MockAuditService is a mock of AuditService interface.
+type MockAuditService struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuditServiceMockRecorder
+}
+
+// MockAuditServiceMockRecorder is the mock recorder for MockAuditService.
+type MockAuditServiceMockRecorder struct {
+	mock *MockAuditService
+}
+
+// NewMockAuditService creates a new mock instance.
+func NewMockAuditService(ctrl *gomock.Controller) *MockAuditService {
+	mock := &MockAuditService{ctrl: ctrl}
+	mock.recorder = &MockAuditServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuditService) EXPECT() *MockAuditServiceMockRecorder {
+	return m.recorder
+}
+
+// List mocks base method.
+func (m *MockAuditService) List(ctx context.Context, dt dto.GetAuditRecordsRequestDTO) (dto.GetAuditRecordsResponseDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, dt)
+	ret0, _ := ret[0].(dto.GetAuditRecordsResponseDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockAuditServiceMockRecorder) List(ctx, dt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockAuditService)(nil).List), ctx, dt)
+}
+
+// Record mocks base method.
+func (m *MockAuditService) Record(ctx context.Context, action entity.AuditAction, entityType string, entityID uuid.UUID, before, after map[string]string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Record", ctx, action, entityType, entityID, before, after)
+}
+
+// Record indicates an expected call of Record.
+func (mr *MockAuditServiceMockRecorder) Record(ctx, action, entityType, entityID, before, after interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Record", reflect.TypeOf((*MockAuditService)(nil).Record), ctx, action, entityType, entityID, before, after)
+}
+
+// MockAuditRepo is a mock of AuditRepo interface.
+type MockAuditRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuditRepoMockRecorder
+}
+
+// MockAuditRepoMockRecorder is the mock recorder for MockAuditRepo.
+type MockAuditRepoMockRecorder struct {
+	mock *MockAuditRepo
+}
+
+// NewMockAuditRepo creates a new mock instance.
+func NewMockAuditRepo(ctrl *gomock.Controller) *MockAuditRepo {
+	mock := &MockAuditRepo{ctrl: ctrl}
+	mock.recorder = &MockAuditRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuditRepo) EXPECT() *MockAuditRepoMockRecorder {
+	return m.recorder
+}
+
+// Add mocks base method.
+func (m *MockAuditRepo) Add(ctx context.Context, tx *sqlx.Tx, record *entity.AuditRecord) (*entity.AuditRecord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Add", ctx, tx, record)
+	ret0, _ := ret[0].(*entity.AuditRecord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockAuditRepoMockRecorder) Add(ctx, tx, record interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockAuditRepo)(nil).Add), ctx, tx, record)
+}
+
+// Count mocks base method.
+func (m *MockAuditRepo) Count(ctx context.Context, tx *sqlx.Tx, filter entity.AuditFilter) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Count", ctx, tx, filter)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Count indicates an expected call of Count.
+func (mr *MockAuditRepoMockRecorder) Count(ctx, tx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockAuditRepo)(nil).Count), ctx, tx, filter)
+}
+
+// GetAll mocks base method.
+func (m *MockAuditRepo) GetAll(ctx context.Context, tx *sqlx.Tx, skip, limit int, filter entity.AuditFilter) ([]*entity.AuditRecord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx, tx, skip, limit, filter)
+	ret0, _ := ret[0].([]*entity.AuditRecord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockAuditRepoMockRecorder) GetAll(ctx, tx, skip, limit, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockAuditRepo)(nil).GetAll), ctx, tx, skip, limit, filter)
+}
@@ -0,0 +1,288 @@
+package auth_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/AZhur771/wg-grpc-api/internal/auth"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+var deviceIDMock = "acda9b63-45ae-4352-995c-82202086cac4"
+
+func withAPIKey(key string) context.Context {
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-api-key", key))
+}
+
+func TestTokenAuthenticator(t *testing.T) {
+	authenticator, err := auth.NewTokenAuthenticator([]string{
+		"admin-secret",
+		"helpdesk-secret:peer-operator:" + deviceIDMock,
+		"viewer-secret:read-only",
+	})
+	require.NoError(t, err)
+
+	identity, err := authenticator.Authenticate(withAPIKey("admin-secret"))
+	require.NoError(t, err)
+	require.Equal(t, auth.RoleAdmin, identity.Role)
+	require.False(t, identity.IsScoped())
+
+	identity, err = authenticator.Authenticate(withAPIKey("helpdesk-secret"))
+	require.NoError(t, err)
+	require.Equal(t, auth.RolePeerOperator, identity.Role)
+	require.True(t, identity.CanAccessDevice(uuid.MustParse(deviceIDMock)))
+	require.False(t, identity.CanAccessDevice(uuid.New()))
+
+	identity, err = authenticator.Authenticate(withAPIKey("viewer-secret"))
+	require.NoError(t, err)
+	require.Equal(t, auth.RoleReadOnly, identity.Role)
+
+	_, err = authenticator.Authenticate(withAPIKey("unknown"))
+	require.ErrorIs(t, err, auth.ErrInvalidCredentials)
+
+	_, err = authenticator.Authenticate(context.Background())
+	require.ErrorIs(t, err, auth.ErrNoCredentials)
+
+	_, err = auth.NewTokenAuthenticator([]string{"secret:superuser"})
+	require.Error(t, err)
+
+	_, err = auth.NewTokenAuthenticator([]string{"secret:peer-operator:not-uuid"})
+	require.Error(t, err)
+}
+
+func TestRequiredRole(t *testing.T) {
+	require.True(t, auth.RolePeerOperator.Allows(auth.RequiredRole("/PeerService/Enable")))
+	require.True(t, auth.RolePeerOperator.Allows(auth.RequiredRole("/PeerService/DownloadConfig")))
+	require.True(t, auth.RolePeerOperator.Allows(auth.RequiredRole("/DeviceService/Get")))
+	require.False(t, auth.RolePeerOperator.Allows(auth.RequiredRole("/DeviceService/Add")))
+	require.False(t, auth.RolePeerOperator.Allows(auth.RequiredRole("/DeviceService/Remove")))
+	require.False(t, auth.RoleReadOnly.Allows(auth.RequiredRole("/PeerService/Disable")))
+	require.False(t, auth.RolePeerOperator.Allows(auth.RequiredRole("/UnknownService/Method")))
+	require.True(t, auth.RoleAdmin.Allows(auth.RequiredRole("/UnknownService/Method")))
+}
+
+type keyVerifierMock map[string]*auth.Identity
+
+func (k keyVerifierMock) VerifyKey(ctx context.Context, key string) (*auth.Identity, error) {
+	if identity, ok := k[key]; ok {
+		return identity, nil
+	}
+
+	return nil, auth.ErrInvalidCredentials
+}
+
+func TestChainAuthenticator(t *testing.T) {
+	tokenAuthenticator, err := auth.NewTokenAuthenticator([]string{"bootstrap"})
+	require.NoError(t, err)
+
+	authenticator := auth.ChainAuthenticator{
+		tokenAuthenticator,
+		auth.NewKeyAuthenticator(keyVerifierMock{
+			"managed-key": {Name: "managed", Role: auth.RoleReadOnly},
+		}),
+	}
+
+	identity, err := authenticator.Authenticate(withAPIKey("bootstrap"))
+	require.NoError(t, err)
+	require.Equal(t, auth.RoleAdmin, identity.Role)
+
+	identity, err = authenticator.Authenticate(withAPIKey("managed-key"))
+	require.NoError(t, err)
+	require.Equal(t, "managed", identity.Name)
+
+	_, err = authenticator.Authenticate(withAPIKey("unknown"))
+	require.ErrorIs(t, err, auth.ErrInvalidCredentials)
+
+	_, err = authenticator.Authenticate(context.Background())
+	require.ErrorIs(t, err, auth.ErrNoCredentials)
+}
+
+func withBearer(t *testing.T, secret string, claims jwt.MapClaims) context.Context {
+	t.Helper()
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(secret))
+	require.NoError(t, err)
+
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+token))
+}
+
+func TestJWTAuthenticator(t *testing.T) {
+	authenticator, err := auth.NewJWTAuthenticator(auth.JWTConfig{
+		Secret:       "jwt-secret",
+		Audience:     "wg-grpc-api",
+		RoleClaim:    "role",
+		DevicesClaim: "devices",
+	})
+	require.NoError(t, err)
+
+	validClaims := func() jwt.MapClaims {
+		return jwt.MapClaims{
+			"sub":     "alice",
+			"aud":     "wg-grpc-api",
+			"exp":     time.Now().Add(time.Hour).Unix(),
+			"role":    "peer-operator",
+			"devices": []string{deviceIDMock},
+		}
+	}
+
+	identity, err := authenticator.Authenticate(withBearer(t, "jwt-secret", validClaims()))
+	require.NoError(t, err)
+	require.Equal(t, "jwt-alice", identity.Name)
+	require.Equal(t, auth.RolePeerOperator, identity.Role)
+	require.True(t, identity.CanAccessDevice(uuid.MustParse(deviceIDMock)))
+	require.False(t, identity.CanAccessDevice(uuid.New()))
+
+	_, err = authenticator.Authenticate(context.Background())
+	require.True(t, errors.Is(err, auth.ErrNoCredentials))
+
+	invalid := map[string]func(jwt.MapClaims) (jwt.MapClaims, string){
+		"wrong secret": func(c jwt.MapClaims) (jwt.MapClaims, string) { return c, "other-secret" },
+		"expired": func(c jwt.MapClaims) (jwt.MapClaims, string) {
+			c["exp"] = time.Now().Add(-time.Minute).Unix()
+			return c, "jwt-secret"
+		},
+		"no expiration": func(c jwt.MapClaims) (jwt.MapClaims, string) {
+			delete(c, "exp")
+			return c, "jwt-secret"
+		},
+		"not yet valid": func(c jwt.MapClaims) (jwt.MapClaims, string) {
+			c["nbf"] = time.Now().Add(time.Hour).Unix()
+			return c, "jwt-secret"
+		},
+		"wrong audience": func(c jwt.MapClaims) (jwt.MapClaims, string) {
+			c["aud"] = "other-api"
+			return c, "jwt-secret"
+		},
+		"no role": func(c jwt.MapClaims) (jwt.MapClaims, string) {
+			delete(c, "role")
+			return c, "jwt-secret"
+		},
+		"malformed devices": func(c jwt.MapClaims) (jwt.MapClaims, string) {
+			c["devices"] = []string{"not-a-uuid"}
+			return c, "jwt-secret"
+		},
+	}
+
+	for name, modify := range invalid {
+		claims, secret := modify(validClaims())
+
+		_, err := authenticator.Authenticate(withBearer(t, secret, claims))
+		require.Truef(t, errors.Is(err, auth.ErrInvalidCredentials), "%s: %v", name, err)
+	}
+}
+
+func withClientCert(cert *x509.Certificate) context.Context {
+	return peer.NewContext(context.Background(), &peer.Peer{
+		AuthInfo: credentials.TLSInfo{
+			State: tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{cert}}},
+		},
+	})
+}
+
+func TestCertAuthenticator(t *testing.T) {
+	authenticator, err := auth.NewCertAuthenticator()
+	require.NoError(t, err)
+
+	identity, err := authenticator.Authenticate(withClientCert(&x509.Certificate{
+		Subject: pkix.Name{CommonName: "automation", OrganizationalUnit: []string{"ops", "peer-operator"}},
+		URIs:    []*url.URL{{Scheme: "urn", Opaque: "wg-grpc-api:device:" + deviceIDMock}},
+	}))
+	require.NoError(t, err)
+	require.Equal(t, "cert-automation", identity.Name)
+	require.Equal(t, auth.RolePeerOperator, identity.Role)
+	require.True(t, identity.CanAccessDevice(uuid.MustParse(deviceIDMock)))
+	require.False(t, identity.CanAccessDevice(uuid.New()))
+
+	identity, err = authenticator.Authenticate(withClientCert(&x509.Certificate{
+		Subject:  pkix.Name{OrganizationalUnit: []string{"admin"}},
+		DNSNames: []string{"ci.example.com"},
+	}))
+	require.NoError(t, err)
+	require.Equal(t, "cert-ci.example.com", identity.Name)
+	require.False(t, identity.IsScoped())
+
+	_, err = authenticator.Authenticate(withClientCert(&x509.Certificate{
+		Subject: pkix.Name{CommonName: "localhost"},
+	}))
+	require.True(t, errors.Is(err, auth.ErrNoCredentials))
+
+	_, err = authenticator.Authenticate(withClientCert(&x509.Certificate{
+		Subject: pkix.Name{CommonName: "automation", OrganizationalUnit: []string{"admin"}},
+		URIs:    []*url.URL{{Scheme: "urn", Opaque: "wg-grpc-api:device:not-a-uuid"}},
+	}))
+	require.True(t, errors.Is(err, auth.ErrInvalidCredentials))
+
+	_, err = authenticator.Authenticate(context.Background())
+	require.True(t, errors.Is(err, auth.ErrNoCredentials))
+}
+
+func generateTestCert(t *testing.T, subject pkix.Name) *x509.Certificate {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      subject,
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+
+	raw, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	cert, err := x509.ParseCertificate(raw)
+	require.NoError(t, err)
+
+	return cert
+}
+
+func TestCertAuthenticator_Gateway(t *testing.T) {
+	authenticator, err := auth.NewCertAuthenticator()
+	require.NoError(t, err)
+
+	cert := generateTestCert(t, pkix.Name{CommonName: "automation", OrganizationalUnit: []string{"peer-operator"}})
+
+	req := &http.Request{TLS: &tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{cert}}}}
+	md := authenticator.GatewayMetadata(context.Background(), req)
+
+	// certificate the gateway connects with has no role, so the forwarded one is used
+	ctx := metadata.NewIncomingContext(withClientCert(&x509.Certificate{}), md)
+	identity, err := authenticator.Authenticate(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "cert-automation", identity.Name)
+	require.Equal(t, auth.RolePeerOperator, identity.Role)
+
+	// certificate forwarded by anything else than the gateway of this process is rejected
+	another, err := auth.NewCertAuthenticator()
+	require.NoError(t, err)
+
+	_, err = another.Authenticate(ctx)
+	require.True(t, errors.Is(err, auth.ErrInvalidCredentials))
+
+	forged := metadata.Pairs("x-forwarded-client-cert", md.Get("x-forwarded-client-cert")[0])
+	_, err = authenticator.Authenticate(metadata.NewIncomingContext(context.Background(), forged))
+	require.True(t, errors.Is(err, auth.ErrInvalidCredentials))
+
+	// requests without verified certificate are passed as is
+	require.Nil(t, authenticator.GatewayMetadata(context.Background(), &http.Request{}))
+	require.True(t, auth.IsGatewayMetadata("X-Forwarded-Client-Cert"))
+	require.False(t, auth.IsGatewayMetadata("x-api-key"))
+}
@@ -0,0 +1,148 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"crypto/x509"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+const (
+	// deviceURIPrefix marks URI SANs limiting certificate to the device, e.g. urn:wg-grpc-api:device:<device_id>.
+	deviceURIPrefix = "wg-grpc-api:device:"
+
+	// forwardedCertHeader carries client certificate verified by the REST gateway,
+	// it is trusted only along with gatewaySecretHeader holding the secret of this process.
+	forwardedCertHeader = "x-forwarded-client-cert"
+	gatewaySecretHeader = "x-gateway-secret"
+
+	gatewaySecretSize = 32
+)
+
+// CertAuthenticator derives identity from verified client certificate:
+// name is taken from the subject common name (or the first DNS SAN),
+// role from the subject organizational unit and device scope from URI SANs.
+// Certificates of REST clients are forwarded by the gateway, see GatewayMetadata.
+type CertAuthenticator struct {
+	gatewaySecret string
+}
+
+func NewCertAuthenticator() (*CertAuthenticator, error) {
+	secret := make([]byte, gatewaySecretSize)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, fmt.Errorf("cert authenticator: %w", err)
+	}
+
+	return &CertAuthenticator{
+		gatewaySecret: base64.RawURLEncoding.EncodeToString(secret),
+	}, nil
+}
+
+// GatewayMetadata forwards client certificate verified by the REST gateway to the gRPC server,
+// it is used as the gateway metadata annotator.
+func (a *CertAuthenticator) GatewayMetadata(_ context.Context, r *http.Request) metadata.MD {
+	if r.TLS == nil || len(r.TLS.VerifiedChains) == 0 || len(r.TLS.VerifiedChains[0]) == 0 {
+		return nil
+	}
+
+	return metadata.Pairs(
+		forwardedCertHeader, base64.StdEncoding.EncodeToString(r.TLS.VerifiedChains[0][0].Raw),
+		gatewaySecretHeader, a.gatewaySecret,
+	)
+}
+
+// IsGatewayMetadata reports whether the key is set by the gateway only, such headers of REST requests must be dropped.
+func IsGatewayMetadata(key string) bool {
+	key = strings.ToLower(key)
+	return key == forwardedCertHeader || key == gatewaySecretHeader
+}
+
+func (a *CertAuthenticator) Authenticate(ctx context.Context) (*Identity, error) {
+	if md, ok := metadata.FromIncomingContext(ctx); ok && len(md[forwardedCertHeader]) > 0 {
+		return a.forwarded(md)
+	}
+
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return nil, ErrNoCredentials
+	}
+
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(tlsInfo.State.VerifiedChains) == 0 || len(tlsInfo.State.VerifiedChains[0]) == 0 {
+		return nil, ErrNoCredentials
+	}
+
+	return certIdentity(tlsInfo.State.VerifiedChains[0][0])
+}
+
+// forwarded returns identity of the client certificate forwarded by the gateway of this process.
+func (a *CertAuthenticator) forwarded(md metadata.MD) (*Identity, error) {
+	secrets := md[gatewaySecretHeader]
+	if len(secrets) != 1 || subtle.ConstantTimeCompare([]byte(secrets[0]), []byte(a.gatewaySecret)) != 1 {
+		return nil, fmt.Errorf("%w: certificate is not forwarded by the gateway", ErrInvalidCredentials)
+	}
+
+	raw, err := base64.StdEncoding.DecodeString(md[forwardedCertHeader][0])
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid forwarded certificate: %s", ErrInvalidCredentials, err)
+	}
+
+	cert, err := x509.ParseCertificate(raw)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid forwarded certificate: %s", ErrInvalidCredentials, err)
+	}
+
+	return certIdentity(cert)
+}
+
+func certIdentity(cert *x509.Certificate) (*Identity, error) {
+	var (
+		role  Role
+		found bool
+	)
+
+	for _, ou := range cert.Subject.OrganizationalUnit {
+		if r, err := ParseRole(ou); err == nil {
+			role, found = r, true
+			break
+		}
+	}
+
+	// certificate without a role (e.g. the one gateway connects with) is not used for authentication
+	if !found {
+		return nil, ErrNoCredentials
+	}
+
+	name := cert.Subject.CommonName
+	if name == "" && len(cert.DNSNames) > 0 {
+		name = cert.DNSNames[0]
+	}
+
+	identity := &Identity{
+		Name: "cert-" + name,
+		Role: role,
+	}
+
+	for _, uri := range cert.URIs {
+		if uri.Scheme != "urn" || !strings.HasPrefix(uri.Opaque, deviceURIPrefix) {
+			continue
+		}
+
+		deviceID, err := uuid.Parse(strings.TrimPrefix(uri.Opaque, deviceURIPrefix))
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid device in certificate: %s", ErrInvalidCredentials, err)
+		}
+		identity.DeviceIDs = append(identity.DeviceIDs, deviceID)
+	}
+
+	return identity, nil
+}
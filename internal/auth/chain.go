@@ -0,0 +1,59 @@
+package auth
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc/metadata"
+)
+
+// KeyVerifier returns identity of the owner of the API key.
+type KeyVerifier interface {
+	VerifyKey(ctx context.Context, key string) (*Identity, error)
+}
+
+// KeyAuthenticator checks x-api-key header against keys managed through the API.
+type KeyAuthenticator struct {
+	verifier KeyVerifier
+}
+
+func NewKeyAuthenticator(verifier KeyVerifier) *KeyAuthenticator {
+	return &KeyAuthenticator{
+		verifier: verifier,
+	}
+}
+
+func (a *KeyAuthenticator) Authenticate(ctx context.Context) (*Identity, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil, ErrNoCredentials
+	}
+
+	values := md[apiKeyHeader]
+	if len(values) == 0 {
+		return nil, ErrNoCredentials
+	}
+
+	return a.verifier.VerifyKey(ctx, values[0])
+}
+
+// ChainAuthenticator tries authenticators in order until one of them recognizes the credentials.
+type ChainAuthenticator []Authenticator
+
+func (c ChainAuthenticator) Authenticate(ctx context.Context) (*Identity, error) {
+	res := ErrNoCredentials
+
+	for _, authenticator := range c {
+		identity, err := authenticator.Authenticate(ctx)
+		switch {
+		case err == nil:
+			return identity, nil
+		case errors.Is(err, ErrInvalidCredentials):
+			res = err
+		case !errors.Is(err, ErrNoCredentials):
+			return nil, err
+		}
+	}
+
+	return nil, res
+}
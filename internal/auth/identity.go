@@ -0,0 +1,46 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// Identity is an authenticated API client.
+type Identity struct {
+	Name string
+	Role Role
+	// DeviceIDs limits access to the listed devices, empty list means all devices.
+	DeviceIDs []uuid.UUID
+}
+
+// IsScoped reports whether identity is limited to particular devices.
+func (i *Identity) IsScoped() bool {
+	return len(i.DeviceIDs) > 0
+}
+
+// CanAccessDevice reports whether identity may act on the device.
+func (i *Identity) CanAccessDevice(id uuid.UUID) bool {
+	if !i.IsScoped() {
+		return true
+	}
+
+	for _, deviceID := range i.DeviceIDs {
+		if deviceID == id {
+			return true
+		}
+	}
+
+	return false
+}
+
+type identityKey struct{}
+
+func NewContext(ctx context.Context, identity *Identity) context.Context {
+	return context.WithValue(ctx, identityKey{}, identity)
+}
+
+func FromContext(ctx context.Context) (*Identity, bool) {
+	identity, ok := ctx.Value(identityKey{}).(*Identity)
+	return identity, ok
+}
@@ -0,0 +1,161 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	authorizationHeader = "authorization"
+	bearerPrefix        = "bearer "
+)
+
+type JWTConfig struct {
+	// Secret verifies HS256 tokens.
+	Secret string
+	// JWKSFile holds public keys verifying RS256 and ES256 tokens.
+	JWKSFile string
+	// Audience is required to be in aud claim when set.
+	Audience string
+	// RoleClaim is a name of the claim holding the role.
+	RoleClaim string
+	// DevicesClaim is a name of the claim holding list of device ids the token is limited to.
+	DevicesClaim string
+}
+
+// JWTAuthenticator checks bearer tokens in Authorization header.
+type JWTAuthenticator struct {
+	secret       []byte
+	keys         map[string]interface{}
+	parser       *jwt.Parser
+	roleClaim    string
+	devicesClaim string
+}
+
+func NewJWTAuthenticator(cfg JWTConfig) (*JWTAuthenticator, error) {
+	a := &JWTAuthenticator{
+		secret:       []byte(cfg.Secret),
+		keys:         make(map[string]interface{}),
+		roleClaim:    cfg.RoleClaim,
+		devicesClaim: cfg.DevicesClaim,
+	}
+
+	if cfg.JWKSFile != "" {
+		keys, err := loadJWKS(cfg.JWKSFile)
+		if err != nil {
+			return nil, fmt.Errorf("jwt authenticator: %w", err)
+		}
+		a.keys = keys
+	}
+
+	methods := make([]string, 0, 3)
+	if len(a.secret) > 0 {
+		methods = append(methods, jwt.SigningMethodHS256.Alg())
+	}
+	if len(a.keys) > 0 {
+		methods = append(methods, jwt.SigningMethodRS256.Alg(), jwt.SigningMethodES256.Alg())
+	}
+
+	if len(methods) == 0 {
+		return nil, errors.New("jwt authenticator: neither secret nor jwks file is provided")
+	}
+
+	options := []jwt.ParserOption{jwt.WithValidMethods(methods)}
+	if cfg.Audience != "" {
+		options = append(options, jwt.WithAudience(cfg.Audience))
+	}
+
+	a.parser = jwt.NewParser(options...)
+
+	return a, nil
+}
+
+func (a *JWTAuthenticator) Authenticate(ctx context.Context) (*Identity, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil, ErrNoCredentials
+	}
+
+	values := md[authorizationHeader]
+	if len(values) == 0 || !strings.HasPrefix(strings.ToLower(values[0]), bearerPrefix) {
+		return nil, ErrNoCredentials
+	}
+
+	claims := jwt.MapClaims{}
+
+	// exp, nbf and aud claims are validated by the parser
+	if _, err := a.parser.ParseWithClaims(values[0][len(bearerPrefix):], claims, a.key); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidCredentials, err)
+	}
+
+	if exp, err := claims.GetExpirationTime(); err != nil || exp == nil {
+		return nil, fmt.Errorf("%w: token has no expiration time", ErrInvalidCredentials)
+	}
+
+	return a.identity(claims)
+}
+
+func (a *JWTAuthenticator) key(token *jwt.Token) (interface{}, error) {
+	if _, ok := token.Method.(*jwt.SigningMethodHMAC); ok {
+		return a.secret, nil
+	}
+
+	kid, _ := token.Header["kid"].(string)
+
+	if key, ok := a.keys[kid]; ok {
+		return key, nil
+	}
+
+	// token without key id is accepted only when there is no choice of keys
+	if kid == "" && len(a.keys) == 1 {
+		for _, key := range a.keys {
+			return key, nil
+		}
+	}
+
+	return nil, fmt.Errorf("unknown key id: %q", kid)
+}
+
+func (a *JWTAuthenticator) identity(claims jwt.MapClaims) (*Identity, error) {
+	subject, _ := claims.GetSubject()
+
+	roleName, ok := claims[a.roleClaim].(string)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s claim is missing", ErrInvalidCredentials, a.roleClaim)
+	}
+
+	role, err := ParseRole(roleName)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidCredentials, err)
+	}
+
+	identity := &Identity{
+		Name: "jwt-" + subject,
+		Role: role,
+	}
+
+	if devices, ok := claims[a.devicesClaim]; ok {
+		list, ok := devices.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("%w: %s claim is not a list", ErrInvalidCredentials, a.devicesClaim)
+		}
+
+		for _, item := range list {
+			id, _ := item.(string)
+
+			deviceID, err := uuid.Parse(id)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %s claim: %s", ErrInvalidCredentials, a.devicesClaim, err)
+			}
+			identity.DeviceIDs = append(identity.DeviceIDs, deviceID)
+		}
+	}
+
+	return identity, nil
+}
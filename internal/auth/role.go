@@ -0,0 +1,61 @@
+package auth
+
+import "github.com/AZhur771/wg-grpc-api/internal/role"
+
+// Role grants access to API methods, see role.Role.
+type Role = role.Role
+
+const (
+	RoleReadOnly     = role.ReadOnly
+	RolePeerOperator = role.PeerOperator
+	RoleAdmin        = role.Admin
+)
+
+func ParseRole(s string) (Role, error) {
+	return role.Parse(s)
+}
+
+// methodRoles maps full gRPC method names to roles required to call them.
+var methodRoles = map[string]Role{
+	"/DeviceService/Add":                RoleAdmin,
+	"/DeviceService/Remove":             RoleAdmin,
+	"/DeviceService/Update":             RoleAdmin,
+	"/DeviceService/RotateKey":          RoleAdmin,
+	"/DeviceService/ImportDevice":       RoleAdmin,
+	"/DeviceService/Get":                RoleReadOnly,
+	"/DeviceService/GetAll":             RoleReadOnly,
+	"/DeviceService/GetReconcileReport": RoleReadOnly,
+
+	"/PeerService/Add":            RolePeerOperator,
+	"/PeerService/BulkAdd":        RolePeerOperator,
+	"/PeerService/BulkAddStream":  RolePeerOperator,
+	"/PeerService/Remove":         RolePeerOperator,
+	"/PeerService/Update":         RolePeerOperator,
+	"/PeerService/Get":            RoleReadOnly,
+	"/PeerService/GetAll":         RoleReadOnly,
+	"/PeerService/Enable":         RolePeerOperator,
+	"/PeerService/Disable":        RolePeerOperator,
+	"/PeerService/DownloadConfig": RolePeerOperator,
+	"/PeerService/DownloadQRCode": RolePeerOperator,
+	"/PeerService/ResetQuota":     RolePeerOperator,
+	"/PeerService/RotateKeys":     RolePeerOperator,
+	"/PeerService/Watch":          RoleReadOnly,
+	"/PeerService/WatchDevice":    RoleReadOnly,
+
+	"/ApiKeyService/Create": RoleAdmin,
+	"/ApiKeyService/GetAll": RoleAdmin,
+	"/ApiKeyService/Revoke": RoleAdmin,
+
+	"/AuditService/List": RoleAdmin,
+
+	"/grpc.reflection.v1alpha.ServerReflection/ServerReflectionInfo": RoleReadOnly,
+}
+
+// RequiredRole returns role needed to call the method, unknown methods are available to admins only.
+func RequiredRole(fullMethod string) Role {
+	if required, ok := methodRoles[fullMethod]; ok {
+		return required
+	}
+
+	return RoleAdmin
+}
@@ -0,0 +1,107 @@
+package auth
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/metadata"
+)
+
+const apiKeyHeader = "x-api-key"
+
+var (
+	ErrNoCredentials      = errors.New("credentials are not provided")
+	ErrInvalidCredentials = errors.New("invalid credentials")
+)
+
+// Authenticator identifies API client by credentials found in incoming request metadata.
+type Authenticator interface {
+	Authenticate(ctx context.Context) (*Identity, error)
+}
+
+type token struct {
+	secret   []byte
+	identity *Identity
+}
+
+// TokenAuthenticator checks x-api-key header against statically configured tokens.
+type TokenAuthenticator struct {
+	tokens []token
+}
+
+// NewTokenAuthenticator parses tokens in "secret[:role[:device_id;device_id...]]" format,
+// token without role is an admin one.
+func NewTokenAuthenticator(tokens []string) (*TokenAuthenticator, error) {
+	res := &TokenAuthenticator{
+		tokens: make([]token, 0, len(tokens)),
+	}
+
+	for i, t := range tokens {
+		parts := strings.SplitN(t, ":", 3)
+
+		if parts[0] == "" {
+			return nil, fmt.Errorf("token authenticator: token #%d is empty", i)
+		}
+
+		identity := &Identity{
+			Name: fmt.Sprintf("token-%d", i),
+			Role: RoleAdmin,
+		}
+
+		if len(parts) > 1 {
+			role, err := ParseRole(parts[1])
+			if err != nil {
+				return nil, fmt.Errorf("token authenticator: token #%d: %w", i, err)
+			}
+			identity.Role = role
+		}
+
+		if len(parts) > 2 {
+			for _, id := range strings.Split(parts[2], ";") {
+				deviceID, err := uuid.Parse(id)
+				if err != nil {
+					return nil, fmt.Errorf("token authenticator: token #%d: %w", i, err)
+				}
+				identity.DeviceIDs = append(identity.DeviceIDs, deviceID)
+			}
+		}
+
+		res.tokens = append(res.tokens, token{
+			secret:   []byte(parts[0]),
+			identity: identity,
+		})
+	}
+
+	return res, nil
+}
+
+func (a *TokenAuthenticator) Authenticate(ctx context.Context) (*Identity, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil, ErrNoCredentials
+	}
+
+	values := md[apiKeyHeader]
+	if len(values) == 0 {
+		return nil, ErrNoCredentials
+	}
+
+	var identity *Identity
+
+	// every token is compared to keep response time independent of the matching position
+	for _, t := range a.tokens {
+		if subtle.ConstantTimeCompare(t.secret, []byte(values[0])) == 1 {
+			identity = t.identity
+		}
+	}
+
+	if identity == nil {
+		return nil, ErrInvalidCredentials
+	}
+
+	return identity, nil
+}
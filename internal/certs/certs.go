@@ -9,7 +9,9 @@
 	"google.golang.org/grpc/credentials"
 )
 
-func LoadTLSCredentials(certPath, keyPath string) (credentials.TransportCredentials, error) {
+// LoadServerTLSConfig loads server certificate, client certificates are required
+// and verified against the client CA bundle when its path is given.
+func LoadServerTLSConfig(certPath, keyPath, clientCACertPath string) (*tls.Config, error) {
 	serverCert, err := tls.LoadX509KeyPair(certPath, keyPath)
 	if err != nil {
 		return nil, err
@@ -21,18 +23,34 @@ func LoadTLSCredentials(certPath, keyPath string) (credentials.TransportCredenti
 		MinVersion:   tls.VersionTLS12,
 	}
 
-	return credentials.NewTLS(config), nil
+	if clientCACertPath != "" {
+		certPool, err := loadCertPool(clientCACertPath)
+		if err != nil {
+			return nil, err
+		}
+
+		config.ClientAuth = tls.RequireAndVerifyClientCert
+		config.ClientCAs = certPool
+	}
+
+	return config, nil
 }
 
-func LoadCATLSCredentials(caCertPath string) (credentials.TransportCredentials, error) {
-	pemServerCA, err := ioutil.ReadFile(caCertPath)
+func LoadTLSCredentials(certPath, keyPath, clientCACertPath string) (credentials.TransportCredentials, error) {
+	config, err := LoadServerTLSConfig(certPath, keyPath, clientCACertPath)
 	if err != nil {
 		return nil, err
 	}
 
-	certPool := x509.NewCertPool()
-	if !certPool.AppendCertsFromPEM(pemServerCA) {
-		return nil, fmt.Errorf("failed to add server CA's certificate")
+	return credentials.NewTLS(config), nil
+}
+
+// LoadCATLSCredentials loads client credentials trusting server CA,
+// client certificate is presented when its path is given.
+func LoadCATLSCredentials(caCertPath, certPath, keyPath string) (credentials.TransportCredentials, error) {
+	certPool, err := loadCertPool(caCertPath)
+	if err != nil {
+		return nil, err
 	}
 
 	config := &tls.Config{
@@ -40,5 +58,28 @@ func LoadCATLSCredentials(caCertPath string) (credentials.TransportCredentials,
 		MinVersion: tls.VersionTLS12,
 	}
 
+	if certPath != "" {
+		clientCert, err := tls.LoadX509KeyPair(certPath, keyPath)
+		if err != nil {
+			return nil, err
+		}
+
+		config.Certificates = []tls.Certificate{clientCert}
+	}
+
 	return credentials.NewTLS(config), nil
 }
+
+func loadCertPool(path string) (*x509.CertPool, error) {
+	pemCA, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	certPool := x509.NewCertPool()
+	if !certPool.AppendCertsFromPEM(pemCA) {
+		return nil, fmt.Errorf("failed to add CA's certificate from %s", path)
+	}
+
+	return certPool, nil
+}
@@ -0,0 +1,52 @@
+package dto
+
+import (
+	"github.com/AZhur771/wg-grpc-api/internal/auth"
+	"github.com/AZhur771/wg-grpc-api/internal/entity"
+	"github.com/google/uuid"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+)
+
+type CreateAPIKeyDTO struct {
+	Name      string
+	Role      auth.Role
+	DeviceIDs []uuid.UUID
+}
+
+type GetAPIKeysResponseDTO struct {
+	APIKeys []*entity.APIKey
+	Total   int
+	HasNext bool
+}
+
+type GetAPIKeysRequestDTO struct {
+	Skip  int
+	Limit int
+}
+
+func (p *GetAPIKeysRequestDTO) IsValid() []*errdetails.BadRequest_FieldViolation {
+	errors := make([]*errdetails.BadRequest_FieldViolation, 0)
+
+	if p.Skip < 0 {
+		errors = append(errors, &errdetails.BadRequest_FieldViolation{
+			Field:       "skip",
+			Description: "skip should not be less than zero",
+		})
+	}
+
+	if p.Limit < 0 {
+		errors = append(errors, &errdetails.BadRequest_FieldViolation{
+			Field:       "limit",
+			Description: "limit should not be less than zero",
+		})
+	}
+
+	if p.Limit > 100 {
+		errors = append(errors, &errdetails.BadRequest_FieldViolation{
+			Field:       "limit",
+			Description: "limit should not be more than 100",
+		})
+	}
+
+	return errors
+}
@@ -0,0 +1,61 @@
+package dto
+
+import (
+	"github.com/AZhur771/wg-grpc-api/internal/entity"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+)
+
+type GetAuditRecordsResponseDTO struct {
+	Records []*entity.AuditRecord
+	Total   int
+	HasNext bool
+}
+
+type GetAuditRecordsRequestDTO struct {
+	Skip   int
+	Limit  int
+	Filter entity.AuditFilter
+}
+
+func (p *GetAuditRecordsRequestDTO) IsValid() []*errdetails.BadRequest_FieldViolation {
+	errors := make([]*errdetails.BadRequest_FieldViolation, 0)
+
+	if p.Skip < 0 {
+		errors = append(errors, &errdetails.BadRequest_FieldViolation{
+			Field:       "skip",
+			Description: "skip should not be less than zero",
+		})
+	}
+
+	if p.Limit < 0 {
+		errors = append(errors, &errdetails.BadRequest_FieldViolation{
+			Field:       "limit",
+			Description: "limit should not be less than zero",
+		})
+	}
+
+	if p.Limit > 100 {
+		errors = append(errors, &errdetails.BadRequest_FieldViolation{
+			Field:       "limit",
+			Description: "limit should not be more than 100",
+		})
+	}
+
+	switch p.Filter.EntityType {
+	case "", entity.AuditEntityPeer, entity.AuditEntityDevice:
+	default:
+		errors = append(errors, &errdetails.BadRequest_FieldViolation{
+			Field:       "entity_type",
+			Description: "entity type should be either peer or device",
+		})
+	}
+
+	if !p.Filter.From.IsZero() && !p.Filter.To.IsZero() && !p.Filter.From.Before(p.Filter.To) {
+		errors = append(errors, &errdetails.BadRequest_FieldViolation{
+			Field:       "to",
+			Description: "to should be after from",
+		})
+	}
+
+	return errors
+}
@@ -13,7 +13,9 @@ type AddDeviceDTO struct {
 	Endpoint            string
 	FirewallMark        int
 	Address             string
+	Address6            string
 	Table               string
+	Netns               string
 	MTU                 int
 	DNS                 string
 	PersistentKeepAlive time.Duration
@@ -21,6 +23,7 @@ type AddDeviceDTO struct {
 	PreDown             string
 	PostUp              string
 	PostDown            string
+	ClientAllowedIPs    []string
 }
 
 type UpdateDeviceDTO struct {
@@ -29,7 +32,9 @@ type UpdateDeviceDTO struct {
 	Endpoint            string
 	FirewallMark        int
 	Address             string
+	Address6            string
 	Table               string
+	Netns               string
 	MTU                 int
 	DNS                 string
 	PersistentKeepAlive time.Duration
@@ -37,6 +42,33 @@ type UpdateDeviceDTO struct {
 	PreDown             string
 	PostUp              string
 	PostDown            string
+	ClientAllowedIPs    []string
+}
+
+type RotateDeviceKeyDTO struct {
+	ID uuid.UUID
+	// CutoverAt keeps the current key on the interface until given time, zero value rotates key right away.
+	CutoverAt time.Time
+}
+
+type RotateDeviceKeyResponseDTO struct {
+	Device *entity.Device
+	// Peers need fresh configs once the new key is applied to the interface.
+	Peers []*entity.Peer
+}
+
+type ImportDeviceDTO struct {
+	Name string
+	// Config is wg-quick config of the interface with its peers.
+	Config string
+	// Endpoint is a public host of the device, config listen port is used when port is omitted.
+	Endpoint    string
+	Description string
+}
+
+type ImportDeviceResponseDTO struct {
+	Device *entity.Device
+	Peers  []*entity.Peer
 }
 
 type GetDevicesResponseDTO struct {
@@ -1,6 +1,11 @@
 package dto
 
 import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
 	"time"
 
 	"github.com/AZhur771/wg-grpc-api/internal/entity"
@@ -12,11 +17,18 @@ type AddPeerDTO struct {
 	DeviceID            uuid.UUID
 	Name                string
 	Email               string
+	PublicKey           string
 	AddPresharedKey     bool
 	PersistentKeepAlive time.Duration
 	Description         string
 	DNS                 string
 	MTU                 int
+	ExpiresAt           time.Time
+	QuotaBytes          int64
+	QuotaPeriod         entity.QuotaPeriod
+	ClientAllowedIPs    []string
+	ExtraRoutes         []string
+	Addresses           []string
 }
 
 type UpdatePeerDTO struct {
@@ -29,6 +41,137 @@ type UpdatePeerDTO struct {
 	Description         string
 	DNS                 string
 	MTU                 int
+	ExpiresAt           time.Time
+	QuotaBytes          int64
+	QuotaPeriod         entity.QuotaPeriod
+	ClientAllowedIPs    []string
+	ExtraRoutes         []string
+	Addresses           []string
+}
+
+type RotatePeerKeysDTO struct {
+	ID uuid.UUID
+	// PublicKey is set when the new private key is generated on the client side.
+	PublicKey          string
+	RotatePresharedKey bool
+}
+
+// MaxBulkPeers limits number of peers added by a single bulk request.
+const MaxBulkPeers = 1000
+
+type BulkPeerEntryDTO struct {
+	Name        string
+	Email       string
+	Description string
+}
+
+type BulkAddPeersDTO struct {
+	DeviceID        uuid.UUID
+	AddPresharedKey bool
+	Peers           []BulkPeerEntryDTO
+}
+
+func (p *BulkAddPeersDTO) IsValid() []*errdetails.BadRequest_FieldViolation {
+	errors := make([]*errdetails.BadRequest_FieldViolation, 0)
+
+	if len(p.Peers) == 0 {
+		errors = append(errors, &errdetails.BadRequest_FieldViolation{
+			Field:       "peers",
+			Description: "peers should not be empty",
+		})
+	}
+
+	if len(p.Peers) > MaxBulkPeers {
+		errors = append(errors, &errdetails.BadRequest_FieldViolation{
+			Field:       "peers",
+			Description: fmt.Sprintf("peers should be %d entries max", MaxBulkPeers),
+		})
+	}
+
+	return errors
+}
+
+// BulkAddPeerResultDTO is an outcome of a single entry, peer is nil when entry failed validation.
+type BulkAddPeerResultDTO struct {
+	Index  int
+	Peer   *entity.Peer
+	Errors []*errdetails.BadRequest_FieldViolation
+}
+
+type BulkAddPeersResponseDTO struct {
+	Results []BulkAddPeerResultDTO
+	Added   int
+}
+
+// ParseBulkPeerEntriesCSV reads peer entries from CSV with a header row,
+// supported columns are name, email and description in any order.
+func ParseBulkPeerEntriesCSV(r io.Reader) ([]BulkPeerEntryDTO, []*errdetails.BadRequest_FieldViolation) {
+	invalid := func(description string) []*errdetails.BadRequest_FieldViolation {
+		return []*errdetails.BadRequest_FieldViolation{
+			{
+				Field:       "csv",
+				Description: description,
+			},
+		}
+	}
+
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, invalid("csv should have a header row")
+	}
+
+	if err != nil {
+		return nil, invalid(err.Error())
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, column := range header {
+		column = strings.ToLower(strings.TrimSpace(column))
+
+		switch column {
+		case "name", "email", "description":
+		default:
+			return nil, invalid(fmt.Sprintf("unknown column: %s", column))
+		}
+
+		columns[column] = i
+	}
+
+	if _, ok := columns["name"]; !ok {
+		return nil, invalid("name column is required")
+	}
+
+	value := func(record []string, column string) string {
+		if i, ok := columns[column]; ok {
+			return strings.TrimSpace(record[i])
+		}
+
+		return ""
+	}
+
+	entries := make([]BulkPeerEntryDTO, 0)
+
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return nil, invalid(err.Error())
+		}
+
+		entries = append(entries, BulkPeerEntryDTO{
+			Name:        value(record, "name"),
+			Email:       value(record, "email"),
+			Description: value(record, "description"),
+		})
+	}
+
+	return entries, nil
 }
 
 type DownloadFileDTO struct {
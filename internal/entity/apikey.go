@@ -0,0 +1,75 @@
+package entity
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"time"
+
+	"github.com/AZhur771/wg-grpc-api/internal/role"
+	"github.com/google/uuid"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+)
+
+// APIKey is a client credential managed through the API, only salted hash of its secret is stored.
+type APIKey struct {
+	ID         uuid.UUID
+	Name       string
+	Role       role.Role
+	DeviceIDs  []uuid.UUID
+	Salt       []byte
+	Hash       []byte
+	CreatedAt  time.Time
+	LastUsedAt time.Time
+	RevokedAt  time.Time
+}
+
+func (k *APIKey) IsValid() []*errdetails.BadRequest_FieldViolation {
+	errors := make([]*errdetails.BadRequest_FieldViolation, 0)
+
+	if k.Name == "" {
+		errors = append(errors, &errdetails.BadRequest_FieldViolation{
+			Field:       "name",
+			Description: "name is required",
+		})
+	}
+
+	if len(k.Name) > 40 {
+		errors = append(errors, &errdetails.BadRequest_FieldViolation{
+			Field:       "name",
+			Description: "name should be 40 characters max",
+		})
+	}
+
+	if k.Role != role.ReadOnly && k.Role != role.PeerOperator && k.Role != role.Admin {
+		errors = append(errors, &errdetails.BadRequest_FieldViolation{
+			Field:       "role",
+			Description: "unknown role",
+		})
+	}
+
+	return errors
+}
+
+// IsRevoked reports whether key was revoked and can no longer be used.
+func (k *APIKey) IsRevoked() bool {
+	return !k.RevokedAt.IsZero()
+}
+
+// SetSecret stores salted hash of the secret.
+func (k *APIKey) SetSecret(salt []byte, secret string) {
+	k.Salt = salt
+	k.Hash = k.hashSecret(secret)
+}
+
+// Verify reports whether secret matches the stored hash.
+func (k *APIKey) Verify(secret string) bool {
+	return subtle.ConstantTimeCompare(k.Hash, k.hashSecret(secret)) == 1
+}
+
+func (k *APIKey) hashSecret(secret string) []byte {
+	h := sha256.New()
+	h.Write(k.Salt)
+	h.Write([]byte(secret))
+
+	return h.Sum(nil)
+}
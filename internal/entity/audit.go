@@ -0,0 +1,138 @@
+package entity
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type AuditAction string
+
+const (
+	AuditActionAdd            AuditAction = "add"
+	AuditActionUpdate         AuditAction = "update"
+	AuditActionRemove         AuditAction = "remove"
+	AuditActionEnable         AuditAction = "enable"
+	AuditActionDisable        AuditAction = "disable"
+	AuditActionResetQuota     AuditAction = "reset_quota"
+	AuditActionRotateKeys     AuditAction = "rotate_keys"
+	AuditActionDownloadConfig AuditAction = "download_config"
+	AuditActionDownloadQRCode AuditAction = "download_qr_code"
+)
+
+const (
+	AuditEntityPeer   = "peer"
+	AuditEntityDevice = "device"
+)
+
+// AuditActorSystem is recorded for operations made by the service itself, e.g. by the scheduler.
+const AuditActorSystem = "system"
+
+// AuditChange is a value of entity field before and after the operation,
+// empty value means the field did not exist before or after it.
+type AuditChange struct {
+	Old string `json:"old"`
+	New string `json:"new"`
+}
+
+// AuditRecord describes who did what to which entity.
+type AuditRecord struct {
+	ID         uuid.UUID
+	Actor      string
+	Action     AuditAction
+	EntityType string
+	EntityID   uuid.UUID
+	Changes    map[string]AuditChange
+	CreatedAt  time.Time
+}
+
+// AuditFields returns peer fields tracked by audit log, keys are never included.
+func (p *Peer) AuditFields() map[string]string {
+	return map[string]string{
+		"device_id":             p.DeviceID.String(),
+		"name":                  p.Name,
+		"email":                 p.Email,
+		"description":           p.Description,
+		"public_key":            p.PublicKey.String(),
+		"has_preshared_key":     strconv.FormatBool(p.HasPresharedKey),
+		"persistent_keep_alive": p.PersistentKeepaliveInterval.String(),
+		"allowed_ips":           strings.Join(p.AllowedIPs, ","),
+		"client_allowed_ips":    strings.Join(p.ClientAllowedIPs, ","),
+		"extra_routes":          strings.Join(p.ExtraRoutes, ","),
+		"dns":                   p.DNS,
+		"mtu":                   strconv.Itoa(p.MTU),
+		"is_enabled":            strconv.FormatBool(p.IsEnabled),
+		"expires_at":            formatAuditTime(p.ExpiresAt),
+		"quota_bytes":           strconv.FormatInt(p.QuotaBytes, 10),
+		"quota_period":          p.QuotaPeriod.String(),
+	}
+}
+
+// AuditFields returns device fields tracked by audit log, keys are never included.
+func (d *Device) AuditFields() map[string]string {
+	pendingPublicKey := ""
+	if d.HasPendingKey() {
+		pendingPublicKey = d.PendingPublicKey.String()
+	}
+
+	return map[string]string{
+		"name":                  d.Name,
+		"description":           d.Description,
+		"public_key":            d.PublicKey.String(),
+		"endpoint":              d.Endpoint,
+		"address":               d.Address,
+		"address6":              d.Address6,
+		"firewall_mark":         strconv.Itoa(d.FirewallMark),
+		"table":                 d.Table,
+		"netns":                 d.Netns,
+		"mtu":                   strconv.Itoa(d.MTU),
+		"dns":                   d.DNS,
+		"persistent_keep_alive": d.PersistentKeepAlive.String(),
+		"pre_up":                d.PreUp,
+		"pre_down":              d.PreDown,
+		"post_up":               d.PostUp,
+		"post_down":             d.PostDown,
+		"client_allowed_ips":    strings.Join(d.ClientAllowedIPs, ","),
+		"pending_public_key":    pendingPublicKey,
+		"key_cutover_at":        formatAuditTime(d.KeyCutoverAt),
+	}
+}
+
+// DiffAuditFields returns fields which values differ, nil before or after
+// stands for entity which did not exist before or after the operation.
+func DiffAuditFields(before, after map[string]string) map[string]AuditChange {
+	changes := make(map[string]AuditChange)
+
+	for field, value := range after {
+		if old := before[field]; old != value {
+			changes[field] = AuditChange{Old: old, New: value}
+		}
+	}
+
+	for field, old := range before {
+		if _, ok := after[field]; !ok && old != "" {
+			changes[field] = AuditChange{Old: old}
+		}
+	}
+
+	return changes
+}
+
+func formatAuditTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+
+	return t.UTC().Format(time.RFC3339)
+}
+
+// AuditFilter selects audit records, zero fields do not restrict the selection.
+type AuditFilter struct {
+	Actor      string
+	EntityType string
+	EntityID   uuid.UUID
+	From       time.Time
+	To         time.Time
+}
@@ -2,7 +2,10 @@
 
 import (
 	"fmt"
+	"math"
 	"net"
+	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
@@ -11,19 +14,25 @@
 	"google.golang.org/genproto/googleapis/rpc/errdetails"
 )
 
+// netnsRe matches names ip netns accepts, so they never escape /var/run/netns.
+var netnsRe = regexp.MustCompile(`^[a-zA-Z0-9_-][a-zA-Z0-9_.-]{0,63}$`)
+
 type Device struct {
-	ID                  uuid.UUID
-	Name                string
-	Description         string
-	Type                wgtypes.DeviceType
-	PrivateKey          wgtypes.Key
-	PublicKey           wgtypes.Key
-	FirewallMark        int
-	MaxPeersCount       int
-	CurrentPeersCount   int
-	Endpoint            string
-	Address             string
-	Table               string
+	ID                uuid.UUID
+	Name              string
+	Description       string
+	Type              wgtypes.DeviceType
+	PrivateKey        wgtypes.Key
+	PublicKey         wgtypes.Key
+	FirewallMark      int
+	MaxPeersCount     int
+	CurrentPeersCount int
+	Endpoint          string
+	Address           string
+	Address6          string
+	Table             string
+	// Netns is a named network namespace the interface is moved to, its UDP socket stays in the init one.
+	Netns               string
 	MTU                 int
 	DNS                 string
 	PersistentKeepAlive time.Duration
@@ -31,6 +40,32 @@ type Device struct {
 	PreDown             string
 	PostUp              string
 	PostDown            string
+	ClientAllowedIPs    []string
+	// PendingPrivateKey replaces PrivateKey on the interface at KeyCutoverAt.
+	PendingPrivateKey wgtypes.Key
+	PendingPublicKey  wgtypes.Key
+	KeyCutoverAt      time.Time
+}
+
+// HasPendingKey reports whether device key rotation waits for cutover.
+func (d *Device) HasPendingKey() bool {
+	return !d.KeyCutoverAt.IsZero()
+}
+
+// SetKey replaces device keypair and drops pending key if any.
+func (d *Device) SetKey(privateKey wgtypes.Key) {
+	d.PrivateKey = privateKey
+	d.PublicKey = privateKey.PublicKey()
+	d.PendingPrivateKey = wgtypes.Key{}
+	d.PendingPublicKey = wgtypes.Key{}
+	d.KeyCutoverAt = time.Time{}
+}
+
+// SetPendingKey schedules device keypair replacement at cutover time.
+func (d *Device) SetPendingKey(privateKey wgtypes.Key, cutoverAt time.Time) {
+	d.PendingPrivateKey = privateKey
+	d.PendingPublicKey = privateKey.PublicKey()
+	d.KeyCutoverAt = cutoverAt
 }
 
 func (d *Device) IsValid() []*errdetails.BadRequest_FieldViolation {
@@ -65,7 +100,7 @@ func (d *Device) IsValid() []*errdetails.BadRequest_FieldViolation {
 		})
 	}
 
-	_, _, err = net.ParseCIDR(d.Address)
+	ip, _, err := net.ParseCIDR(d.Address)
 	if err != nil {
 		errors = append(errors, &errdetails.BadRequest_FieldViolation{
 			Field:       "address",
@@ -73,6 +108,23 @@ func (d *Device) IsValid() []*errdetails.BadRequest_FieldViolation {
 		})
 	}
 
+	if d.Address6 != "" {
+		ip6, _, err := net.ParseCIDR(d.Address6)
+		if err != nil || ip6.To4() != nil {
+			errors = append(errors, &errdetails.BadRequest_FieldViolation{
+				Field:       "address6",
+				Description: "address6 is not a valid CIDR IPv6 address",
+			})
+		}
+
+		if ip != nil && ip.To4() == nil {
+			errors = append(errors, &errdetails.BadRequest_FieldViolation{
+				Field:       "address6",
+				Description: "address6 can only be set along with IPv4 address",
+			})
+		}
+	}
+
 	if d.DNS != "" {
 		for _, addr := range strings.Split(d.DNS, ",") {
 			ip := net.ParseIP(strings.TrimSpace(addr))
@@ -85,6 +137,15 @@ func (d *Device) IsValid() []*errdetails.BadRequest_FieldViolation {
 		}
 	}
 
+	errors = append(errors, validateCIDRs("client_allowed_ips", d.ClientAllowedIPs)...)
+
+	if d.Netns != "" && !netnsRe.MatchString(d.Netns) {
+		errors = append(errors, &errdetails.BadRequest_FieldViolation{
+			Field:       "netns",
+			Description: "netns should be a name of a namespace under /var/run/netns",
+		})
+	}
+
 	if len(d.Description) > 40 {
 		errors = append(errors, &errdetails.BadRequest_FieldViolation{
 			Field:       "description",
@@ -100,17 +161,162 @@ func (d *Device) PopulateDynamicFields(wgdevice *wgtypes.Device) (*Device, error
 	d.FirewallMark = wgdevice.FirewallMark
 	d.CurrentPeersCount = len(wgdevice.Peers)
 
-	_, deviceNet, err := net.ParseCIDR(d.Address)
-	if err != nil {
-		return d, fmt.Errorf("device service: %w", err)
+	d.MaxPeersCount = math.MaxInt32
+
+	// every peer gets an address from each device network
+	for _, addr := range d.Addresses() {
+		_, deviceNet, err := net.ParseCIDR(addr)
+		if err != nil {
+			return d, fmt.Errorf("device service: %w", err)
+		}
+
+		if maxPeers := computeMaxPeers(deviceNet.Mask); maxPeers < d.MaxPeersCount {
+			d.MaxPeersCount = maxPeers
+		}
 	}
-	d.MaxPeersCount = computeMaxPeers(deviceNet.Mask)
 
 	return d, nil
 }
 
-// computeMaxPeers computes max peers from mask.
+// IsRunningOn reports whether the interface already runs with the key, listen port and firewall mark of the device,
+// such interface is kept as is instead of being recreated.
+func (d *Device) IsRunningOn(wgdev *wgtypes.Device) bool {
+	_, port, err := net.SplitHostPort(d.Endpoint)
+	if err != nil {
+		return false
+	}
+
+	listenPort, err := strconv.Atoi(port)
+	if err != nil {
+		return false
+	}
+
+	return wgdev.PrivateKey == d.PrivateKey && wgdev.ListenPort == listenPort && wgdev.FirewallMark == d.FirewallMark
+}
+
+// Addresses returns device CIDR addresses, IPv4 one goes first for dual-stack devices.
+func (d *Device) Addresses() []string {
+	addrs := []string{d.Address}
+
+	if d.Address6 != "" {
+		addrs = append(addrs, d.Address6)
+	}
+
+	return addrs
+}
+
+// HasIPv6 reports whether device has an IPv6 network.
+func (d *Device) HasIPv6() bool {
+	for _, addr := range d.Addresses() {
+		if ip, _, err := net.ParseCIDR(addr); err == nil && ip.To4() == nil {
+			return true
+		}
+	}
+
+	return false
+}
+
+// StaticPeerAddresses maps addresses requested for a peer to device networks they belong to,
+// result values are CIDR addresses with the mask of the network.
+func (d *Device) StaticPeerAddresses(addrs []string) (map[string]string, []*errdetails.BadRequest_FieldViolation) {
+	res := make(map[string]string, len(addrs))
+	errors := make([]*errdetails.BadRequest_FieldViolation, 0)
+
+	for _, addr := range addrs {
+		ip := net.ParseIP(addr)
+		if ip == nil {
+			ip, _, _ = net.ParseCIDR(addr)
+		}
+
+		if ip == nil {
+			errors = append(errors, &errdetails.BadRequest_FieldViolation{
+				Field:       "addresses",
+				Description: fmt.Sprintf("%s is not a valid IP address", addr),
+			})
+			continue
+		}
+
+		description := fmt.Sprintf("%s is outside of device networks", addr)
+
+		for _, cidr := range d.Addresses() {
+			deviceIP, deviceNet, err := net.ParseCIDR(cidr)
+			if err != nil || !deviceNet.Contains(ip) {
+				continue
+			}
+
+			ones, bits := deviceNet.Mask.Size()
+
+			switch {
+			case ip.Equal(deviceIP):
+				description = fmt.Sprintf("%s is the device address", addr)
+			case ip.Equal(deviceNet.IP) || (bits == net.IPv4len*8 && ip.Equal(broadcastAddress(deviceNet))):
+				description = fmt.Sprintf("%s is a reserved address of the device network", addr)
+			case res[cidr] != "":
+				description = fmt.Sprintf("%s: only one address per device network is allowed", addr)
+			default:
+				description = ""
+				res[cidr] = fmt.Sprintf("%s/%d", ip, ones)
+			}
+
+			break
+		}
+
+		if description != "" {
+			errors = append(errors, &errdetails.BadRequest_FieldViolation{
+				Field:       "addresses",
+				Description: description,
+			})
+		}
+	}
+
+	return res, errors
+}
+
+// validateCIDRs checks that every item of the list is a valid CIDR address.
+func validateCIDRs(field string, cidrs []string) []*errdetails.BadRequest_FieldViolation {
+	errors := make([]*errdetails.BadRequest_FieldViolation, 0)
+
+	for _, cidr := range cidrs {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			errors = append(errors, &errdetails.BadRequest_FieldViolation{
+				Field:       field,
+				Description: fmt.Sprintf("%s is not a valid CIDR address", cidr),
+			})
+		}
+	}
+
+	return errors
+}
+
+// broadcastAddress returns the last address of the network.
+func broadcastAddress(ipnet *net.IPNet) net.IP {
+	ip := make(net.IP, len(ipnet.IP))
+
+	for i := range ipnet.IP {
+		ip[i] = ipnet.IP[i] | ^ipnet.Mask[i]
+	}
+
+	return ip
+}
+
+// computeMaxPeers computes max peers from mask, capped to max int32 for huge IPv6 networks.
 func computeMaxPeers(mask net.IPMask) int {
-	ones, _ := mask.Size()
-	return (2 << (32 - ones - 1)) - 3
+	ones, bits := mask.Size()
+	hostBits := bits - ones
+
+	if hostBits >= 31 {
+		return math.MaxInt32
+	}
+
+	// network and device addresses are reserved, IPv4 broadcast address as well
+	reserved := 2
+	if bits == net.IPv4len*8 {
+		reserved = 3
+	}
+
+	if maxPeers := (1 << hostBits) - reserved; maxPeers > 0 {
+		return maxPeers
+	}
+
+	return 0
 }
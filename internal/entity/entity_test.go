@@ -1,6 +1,7 @@
 package entity_test
 
 import (
+	"math"
 	"net"
 	"testing"
 	"time"
@@ -173,4 +174,302 @@ func TestEntityDevice_IsValid(t *testing.T) {
 	testDevice.Address = "10.6.0.1/24"
 	errors = testDevice.IsValid()
 	require.Equal(t, 0, len(errors))
+
+	testDevice.Netns = "../customer"
+	errors = testDevice.IsValid()
+	require.Equal(t, 1, len(errors))
+	require.Equal(t, "netns", errors[0].GetField())
+
+	testDevice.Netns = "customer-1"
+	errors = testDevice.IsValid()
+	require.Equal(t, 0, len(errors))
+}
+
+func TestEntityPeer_IsExpired(t *testing.T) {
+	testPeer, err := generateTestPeer()
+	require.NoError(t, err)
+
+	now := time.Now()
+
+	require.False(t, testPeer.IsExpired(now))
+
+	testPeer.ExpiresAt = now.Add(time.Hour)
+	require.False(t, testPeer.IsExpired(now))
+
+	testPeer.ExpiresAt = now
+	require.True(t, testPeer.IsExpired(now))
+
+	testPeer.ExpiresAt = now.Add(-time.Hour)
+	require.True(t, testPeer.IsExpired(now))
+}
+
+func TestEntityPeer_AccountTraffic(t *testing.T) {
+	testPeer, err := generateTestPeer()
+	require.NoError(t, err)
+
+	testPeer.QuotaBytes = 500
+
+	testPeer.AccountTraffic(&wgtypes.Peer{ReceiveBytes: 100, TransmitBytes: 200})
+	require.Equal(t, int64(300), testPeer.UsedBytes())
+	require.False(t, testPeer.IsQuotaExceeded())
+
+	testPeer.AccountTraffic(&wgtypes.Peer{ReceiveBytes: 150, TransmitBytes: 250})
+	require.Equal(t, int64(400), testPeer.UsedBytes())
+
+	// kernel counters were reset by interface restart
+	testPeer.AccountTraffic(&wgtypes.Peer{ReceiveBytes: 50, TransmitBytes: 50})
+	require.Equal(t, int64(500), testPeer.UsedBytes())
+	require.True(t, testPeer.IsQuotaExceeded())
+}
+
+func TestEntityPeer_RenewQuotaPeriod(t *testing.T) {
+	testPeer, err := generateTestPeer()
+	require.NoError(t, err)
+
+	now := time.Date(2023, time.October, 15, 12, 0, 0, 0, time.UTC)
+
+	testPeer.Usage = entity.PeerUsage{
+		ReceiveBytes:     100,
+		TransmitBytes:    200,
+		LastReceiveBytes: 100,
+		PeriodStart:      time.Date(2023, time.September, 20, 0, 0, 0, 0, time.UTC),
+	}
+
+	require.False(t, testPeer.RenewQuotaPeriod(now))
+	require.Equal(t, int64(300), testPeer.UsedBytes())
+
+	testPeer.QuotaPeriod = entity.QuotaPeriodMonthly
+	require.True(t, testPeer.RenewQuotaPeriod(now))
+	require.Equal(t, int64(0), testPeer.UsedBytes())
+	require.Equal(t, int64(100), testPeer.Usage.LastReceiveBytes)
+	require.Equal(t, time.Date(2023, time.October, 1, 0, 0, 0, 0, time.UTC), testPeer.Usage.PeriodStart)
+
+	require.False(t, testPeer.RenewQuotaPeriod(now))
+}
+
+func TestEntityDevice_MaxPeersCount(t *testing.T) {
+	testDevice, err := generateTestDevice()
+	require.NoError(t, err)
+
+	testDevice, err = testDevice.PopulateDynamicFields(&wgtypes.Device{})
+	require.NoError(t, err)
+	require.Equal(t, 253, testDevice.MaxPeersCount)
+
+	testDevice.Address6 = "fd00::1/64"
+	testDevice, err = testDevice.PopulateDynamicFields(&wgtypes.Device{})
+	require.NoError(t, err)
+	require.Equal(t, 253, testDevice.MaxPeersCount)
+
+	testDevice.Address = "fd00:1::1/64"
+	testDevice.Address6 = ""
+	testDevice, err = testDevice.PopulateDynamicFields(&wgtypes.Device{})
+	require.NoError(t, err)
+	require.Equal(t, math.MaxInt32, testDevice.MaxPeersCount)
+
+	testDevice.Address = "fd00:1::1/120"
+	testDevice, err = testDevice.PopulateDynamicFields(&wgtypes.Device{})
+	require.NoError(t, err)
+	require.Equal(t, 254, testDevice.MaxPeersCount)
+}
+
+func TestEntityPeer_ToPeerConfig(t *testing.T) {
+	testPeer, err := generateTestPeer()
+	require.NoError(t, err)
+
+	testDevice, err := generateTestDevice()
+	require.NoError(t, err)
+
+	testPeer.AllowedIPs = []string{"10.6.0.2/24", "fd00::2/64"}
+
+	conf, err := testPeer.ToPeerConfig(testDevice)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(conf.AllowedIPs))
+	require.Equal(t, "10.6.0.2/32", conf.AllowedIPs[0].String())
+	require.Equal(t, "fd00::2/128", conf.AllowedIPs[1].String())
+}
+
+func TestEntityPeer_EffectiveClientAllowedIPs(t *testing.T) {
+	testPeer, err := generateTestPeer()
+	require.NoError(t, err)
+
+	testDevice, err := generateTestDevice()
+	require.NoError(t, err)
+
+	require.Equal(t, []string{"0.0.0.0/0"}, testPeer.EffectiveClientAllowedIPs(testDevice))
+
+	testDevice.Address6 = "fd00::1/64"
+	require.Equal(t, []string{"0.0.0.0/0", "::/0"}, testPeer.EffectiveClientAllowedIPs(testDevice))
+
+	testDevice.ClientAllowedIPs = []string{"192.168.0.0/24"}
+	require.Equal(t, []string{"192.168.0.0/24"}, testPeer.EffectiveClientAllowedIPs(testDevice))
+
+	testPeer.ClientAllowedIPs = []string{"10.10.0.0/16"}
+	require.Equal(t, []string{"10.10.0.0/16"}, testPeer.EffectiveClientAllowedIPs(testDevice))
+
+	testPeer.Name = "some_name"
+	testPeer.ClientAllowedIPs = []string{"10.10.0.0"}
+	require.Equal(t, 1, len(testPeer.IsValid()))
+}
+
+func TestEntityPeer_ExtraRoutes(t *testing.T) {
+	testPeer, err := generateTestPeer()
+	require.NoError(t, err)
+
+	testDevice, err := generateTestDevice()
+	require.NoError(t, err)
+
+	testPeer.Name = "some_name"
+	testPeer.AllowedIPs = []string{"10.6.0.2/24"}
+	testPeer.ExtraRoutes = []string{"192.168.50.1/24"}
+	require.Equal(t, 1, len(testPeer.IsValid()))
+
+	testPeer.ExtraRoutes = []string{"192.168.50.0/24", "192.168.50.128/25", "10.6.0.0/16", "172.16.0.0/12"}
+	require.Equal(t, 0, len(testPeer.IsValid()))
+
+	errors := testPeer.ValidateExtraRoutes(testDevice, []string{"172.16.5.0/24"})
+	require.Equal(t, 3, len(errors))
+	require.Contains(t, errors[0].Description, "192.168.50.128/25")
+	require.Contains(t, errors[1].Description, "device network")
+	require.Contains(t, errors[2].Description, "another peer")
+
+	testPeer.ExtraRoutes = []string{"192.168.50.0/24"}
+	require.Equal(t, 0, len(testPeer.ValidateExtraRoutes(testDevice, nil)))
+
+	conf, err := testPeer.ToPeerConfig(testDevice)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(conf.AllowedIPs))
+	require.Equal(t, "10.6.0.2/32", conf.AllowedIPs[0].String())
+	require.Equal(t, "192.168.50.0/24", conf.AllowedIPs[1].String())
+}
+
+func TestEntityDevice_StaticPeerAddresses(t *testing.T) {
+	testDevice, err := generateTestDevice()
+	require.NoError(t, err)
+
+	testDevice.Address6 = "fd00::1/64"
+
+	addrs, errors := testDevice.StaticPeerAddresses([]string{"10.6.0.10", "fd00::10"})
+	require.Equal(t, 0, len(errors))
+	require.Equal(t, "10.6.0.10/24", addrs["10.6.0.1/24"])
+	require.Equal(t, "fd00::10/64", addrs["fd00::1/64"])
+
+	_, errors = testDevice.StaticPeerAddresses([]string{
+		"10.6.0.1", "10.6.0.0", "10.6.0.255", "10.7.0.10", "invalid", "10.6.0.10", "10.6.0.11",
+	})
+	require.Equal(t, 6, len(errors))
+	require.Contains(t, errors[0].Description, "device address")
+	require.Contains(t, errors[1].Description, "reserved")
+	require.Contains(t, errors[2].Description, "reserved")
+	require.Contains(t, errors[3].Description, "outside")
+	require.Contains(t, errors[4].Description, "not a valid IP")
+	require.Contains(t, errors[5].Description, "only one address")
+}
+
+func TestEntityPeer_DiffPeer(t *testing.T) {
+	prev, err := generateTestPeer()
+	require.NoError(t, err)
+
+	now := time.Now()
+	prev.IsEnabled = true
+	prev.ReceiveBytes = 100
+	prev.TransmitBytes = 100
+
+	cur := *prev
+	require.Equal(t, 0, len(entity.DiffPeer(prev, &cur, now)))
+
+	cur.LastHandshakeTime = now
+	cur.Endpoint = &net.UDPAddr{IP: net.ParseIP("1.2.3.4"), Port: 51820}
+	cur.ReceiveBytes = 150
+	cur.TransmitBytes = 100
+
+	events := entity.DiffPeer(prev, &cur, now)
+	require.Equal(t, 3, len(events))
+	require.Equal(t, entity.PeerEventHandshake, events[0].Type)
+	require.Equal(t, entity.PeerEventEndpointChanged, events[1].Type)
+	require.Equal(t, entity.PeerEventTraffic, events[2].Type)
+	require.Equal(t, int64(50), events[2].ReceiveBytesDelta)
+	require.Equal(t, int64(0), events[2].TransmitBytesDelta)
+
+	next := cur
+	next.IsEnabled = false
+
+	events = entity.DiffPeer(&cur, &next, now)
+	require.Equal(t, 1, len(events))
+	require.Equal(t, entity.PeerEventDisabled, events[0].Type)
+}
+
+func TestEntityAPIKey_Verify(t *testing.T) {
+	key := &entity.APIKey{
+		ID:   uuid.MustParse(uuidMock),
+		Name: "helpdesk",
+	}
+	require.Equal(t, 0, len(key.IsValid()))
+
+	key.SetSecret([]byte("salt"), "secret")
+	require.True(t, key.Verify("secret"))
+	require.False(t, key.Verify("another secret"))
+
+	// same secret with another salt gives another hash
+	another := &entity.APIKey{}
+	another.SetSecret([]byte("pepper"), "secret")
+	require.NotEqual(t, key.Hash, another.Hash)
+
+	require.False(t, key.IsRevoked())
+	key.RevokedAt = time.Now()
+	require.True(t, key.IsRevoked())
+}
+
+func TestEntityPeer_DiffAuditFields(t *testing.T) {
+	before, err := generateTestPeer()
+	require.NoError(t, err)
+	before.IsEnabled = true
+
+	after := *before
+	require.Equal(t, 0, len(entity.DiffAuditFields(before.AuditFields(), after.AuditFields())))
+
+	after.IsEnabled = false
+	after.Name = "renamed"
+
+	changes := entity.DiffAuditFields(before.AuditFields(), after.AuditFields())
+	require.Equal(t, map[string]entity.AuditChange{
+		"is_enabled": {Old: "true", New: "false"},
+		"name":       {Old: before.Name, New: "renamed"},
+	}, changes)
+
+	// keys never get into audit log
+	for _, change := range entity.DiffAuditFields(nil, after.AuditFields()) {
+		require.NotEqual(t, privateKeyMock, change.New)
+		require.NotEqual(t, presharedKeyMock, change.New)
+	}
+
+	changes = entity.DiffAuditFields(before.AuditFields(), nil)
+	require.Equal(t, before.Name, changes["name"].Old)
+	require.Equal(t, "", changes["name"].New)
+	_, ok := changes["email"]
+	require.False(t, ok)
+}
+
+func TestEntityDevice_SetPendingKey(t *testing.T) {
+	dev, err := generateTestDevice()
+	require.NoError(t, err)
+	require.False(t, dev.HasPendingKey())
+
+	oldPublicKey := dev.PublicKey
+
+	newKey, err := wgtypes.GeneratePrivateKey()
+	require.NoError(t, err)
+
+	cutoverAt := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	dev.SetPendingKey(newKey, cutoverAt)
+
+	// current key stays in use until cutover
+	require.True(t, dev.HasPendingKey())
+	require.Equal(t, oldPublicKey, dev.PublicKey)
+	require.Equal(t, newKey.PublicKey(), dev.PendingPublicKey)
+	require.Equal(t, newKey.PublicKey().String(), dev.AuditFields()["pending_public_key"])
+
+	dev.SetKey(dev.PendingPrivateKey)
+	require.False(t, dev.HasPendingKey())
+	require.Equal(t, newKey.PublicKey(), dev.PublicKey)
+	require.Equal(t, "", dev.AuditFields()["pending_public_key"])
 }
@@ -12,6 +12,37 @@
 	"google.golang.org/genproto/googleapis/rpc/errdetails"
 )
 
+type QuotaPeriod int
+
+const (
+	// QuotaPeriodTotal quota is never renewed automatically.
+	QuotaPeriodTotal QuotaPeriod = iota
+	// QuotaPeriodMonthly quota is renewed at the beginning of every calendar month (UTC).
+	QuotaPeriodMonthly
+)
+
+func (q QuotaPeriod) String() string {
+	switch q {
+	case QuotaPeriodTotal:
+		return "total"
+	case QuotaPeriodMonthly:
+		return "monthly"
+	default:
+		return "unknown"
+	}
+}
+
+func ParseQuotaPeriod(s string) (QuotaPeriod, error) {
+	switch s {
+	case "", "total":
+		return QuotaPeriodTotal, nil
+	case "monthly":
+		return QuotaPeriodMonthly, nil
+	default:
+		return QuotaPeriodTotal, fmt.Errorf("unknown quota period: %s", s)
+	}
+}
+
 type Peer struct {
 	ID                          uuid.UUID
 	DeviceID                    uuid.UUID
@@ -29,10 +60,28 @@ type Peer struct {
 	DNS                         string
 	MTU                         int
 	ProtocolVersion             int
+	HasPrivateKey               bool
 	HasPresharedKey             bool
 	IsEnabled                   bool
 	IsActive                    bool
 	Description                 string
+	ExpiresAt                   time.Time
+	QuotaBytes                  int64
+	QuotaPeriod                 QuotaPeriod
+	Usage                       PeerUsage
+	ClientAllowedIPs            []string
+	ExtraRoutes                 []string
+}
+
+// PeerUsage is peer traffic accounted within the current quota period.
+// Kernel counters are reset whenever peer or interface is reconfigured,
+// so last seen counters are stored to compute deltas between observations.
+type PeerUsage struct {
+	ReceiveBytes      int64
+	TransmitBytes     int64
+	LastReceiveBytes  int64
+	LastTransmitBytes int64
+	PeriodStart       time.Time
 }
 
 func (p *Peer) IsValid() []*errdetails.BadRequest_FieldViolation {
@@ -67,6 +116,37 @@ func (p *Peer) IsValid() []*errdetails.BadRequest_FieldViolation {
 		}
 	}
 
+	if p.QuotaBytes < 0 {
+		errors = append(errors, &errdetails.BadRequest_FieldViolation{
+			Field:       "quota_bytes",
+			Description: "quota should not be less than zero",
+		})
+	}
+
+	if p.QuotaPeriod != QuotaPeriodTotal && p.QuotaPeriod != QuotaPeriodMonthly {
+		errors = append(errors, &errdetails.BadRequest_FieldViolation{
+			Field:       "quota_period",
+			Description: fmt.Sprintf("unknown quota period: %d", p.QuotaPeriod),
+		})
+	}
+
+	errors = append(errors, validateCIDRs("client_allowed_ips", p.ClientAllowedIPs)...)
+
+	for _, route := range p.ExtraRoutes {
+		ip, ipnet, err := net.ParseCIDR(route)
+		if err != nil {
+			errors = append(errors, &errdetails.BadRequest_FieldViolation{
+				Field:       "extra_routes",
+				Description: fmt.Sprintf("%s is not a valid CIDR address", route),
+			})
+		} else if !ip.Equal(ipnet.IP) {
+			errors = append(errors, &errdetails.BadRequest_FieldViolation{
+				Field:       "extra_routes",
+				Description: fmt.Sprintf("%s is not a network address, use %s", route, ipnet),
+			})
+		}
+	}
+
 	if len(p.Description) > 40 {
 		errors = append(errors, &errdetails.BadRequest_FieldViolation{
 			Field:       "description",
@@ -77,6 +157,121 @@ func (p *Peer) IsValid() []*errdetails.BadRequest_FieldViolation {
 	return errors
 }
 
+// IsExpired reports whether peer expiration deadline has passed, zero deadline never expires.
+func (p *Peer) IsExpired(now time.Time) bool {
+	return !p.ExpiresAt.IsZero() && !p.ExpiresAt.After(now)
+}
+
+// EffectiveClientAllowedIPs returns networks routed through the tunnel on the client side:
+// peer own list, device default list or everything (full tunnel) if both are empty.
+func (p *Peer) EffectiveClientAllowedIPs(dev *Device) []string {
+	if len(p.ClientAllowedIPs) > 0 {
+		return p.ClientAllowedIPs
+	}
+
+	if len(dev.ClientAllowedIPs) > 0 {
+		return dev.ClientAllowedIPs
+	}
+
+	allowedIPs := []string{"0.0.0.0/0"}
+	if dev.HasIPv6() {
+		allowedIPs = append(allowedIPs, "::/0")
+	}
+
+	return allowedIPs
+}
+
+// ValidateExtraRoutes checks that routed subnets overlap neither each other nor device networks
+// nor routes taken by other peers of the device.
+func (p *Peer) ValidateExtraRoutes(dev *Device, taken []string) []*errdetails.BadRequest_FieldViolation {
+	errors := make([]*errdetails.BadRequest_FieldViolation, 0)
+
+	deviceNetworks := parseNetworks(dev.Addresses())
+	takenNetworks := parseNetworks(taken)
+	routes := make([]*net.IPNet, 0, len(p.ExtraRoutes))
+
+	for _, route := range p.ExtraRoutes {
+		_, ipnet, err := net.ParseCIDR(route)
+		if err != nil {
+			continue // reported by IsValid
+		}
+
+		var description string
+
+		switch {
+		case overlapsAny(ipnet, deviceNetworks):
+			description = fmt.Sprintf("route %s overlaps device network", route)
+		case overlapsAny(ipnet, takenNetworks):
+			description = fmt.Sprintf("route %s overlaps route of another peer", route)
+		case overlapsAny(ipnet, routes):
+			description = fmt.Sprintf("route %s overlaps another route of the peer", route)
+		}
+
+		if description != "" {
+			errors = append(errors, &errdetails.BadRequest_FieldViolation{
+				Field:       "extra_routes",
+				Description: description,
+			})
+		}
+
+		routes = append(routes, ipnet)
+	}
+
+	return errors
+}
+
+// UsedBytes returns traffic in both directions accounted within the current quota period.
+func (p *Peer) UsedBytes() int64 {
+	return p.Usage.ReceiveBytes + p.Usage.TransmitBytes
+}
+
+// IsQuotaExceeded reports whether peer used up its quota, zero quota is unlimited.
+func (p *Peer) IsQuotaExceeded() bool {
+	return p.QuotaBytes > 0 && p.UsedBytes() >= p.QuotaBytes
+}
+
+// AccountTraffic adds traffic observed on the interface since the last call to peer usage.
+func (p *Peer) AccountTraffic(wgpeer *wgtypes.Peer) {
+	p.Usage.ReceiveBytes += counterDelta(p.Usage.LastReceiveBytes, wgpeer.ReceiveBytes)
+	p.Usage.TransmitBytes += counterDelta(p.Usage.LastTransmitBytes, wgpeer.TransmitBytes)
+	p.Usage.LastReceiveBytes = wgpeer.ReceiveBytes
+	p.Usage.LastTransmitBytes = wgpeer.TransmitBytes
+}
+
+// RenewQuotaPeriod resets usage if monthly quota period is over, returns true if it was reset.
+func (p *Peer) RenewQuotaPeriod(now time.Time) bool {
+	if p.QuotaPeriod != QuotaPeriodMonthly {
+		return false
+	}
+
+	now = now.UTC()
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+	if !p.Usage.PeriodStart.Before(monthStart) {
+		return false
+	}
+
+	p.ResetUsage(monthStart)
+
+	return true
+}
+
+// ResetUsage starts a new quota period, last seen counters are kept to account further traffic only.
+func (p *Peer) ResetUsage(periodStart time.Time) {
+	p.Usage.ReceiveBytes = 0
+	p.Usage.TransmitBytes = 0
+	p.Usage.PeriodStart = periodStart
+}
+
+// counterDelta computes difference between kernel counters, counter less than the previous one means it was reset.
+func counterDelta(last, current int64) int64 {
+	if current < last {
+		return current
+	}
+
+	return current - last
+}
+
 func (p *Peer) PopulateDynamicFields(wgpeer *wgtypes.Peer) *Peer {
 	p.Endpoint = wgpeer.Endpoint
 	p.LastHandshakeTime = wgpeer.LastHandshakeTime
@@ -94,11 +289,21 @@ func (p *Peer) ToPeerConfig(dev *Device) (*wgtypes.PeerConfig, error) {
 		return nil, fmt.Errorf("peer: %w", err)
 	}
 
+	// subnets routed behind the peer (site-to-site) are allowed as a whole
+	for _, route := range p.ExtraRoutes {
+		_, ipnet, err := net.ParseCIDR(route)
+		if err != nil {
+			return nil, fmt.Errorf("peer: %w", err)
+		}
+		allowedIPs = append(allowedIPs, *ipnet)
+	}
+
 	conf := &wgtypes.PeerConfig{
-		PublicKey:    p.PublicKey,
-		PresharedKey: &p.PresharedKey,
-		Endpoint:     p.Endpoint,
-		AllowedIPs:   allowedIPs,
+		PublicKey:         p.PublicKey,
+		PresharedKey:      &p.PresharedKey,
+		Endpoint:          p.Endpoint,
+		ReplaceAllowedIPs: true,
+		AllowedIPs:        allowedIPs,
 	}
 
 	if dev.PersistentKeepAlive != 0 {
@@ -116,11 +321,42 @@ func parseAllowedIPs(allowedIPs []string) ([]net.IPNet, error) {
 		if err != nil {
 			return nil, err
 		}
+
+		// server side allows exactly one host address per peer address
+		bits := net.IPv6len * 8
+		if ip4 := ip.To4(); ip4 != nil {
+			ip = ip4
+			bits = net.IPv4len * 8
+		}
+
 		res = append(res, net.IPNet{
 			IP:   ip,
-			Mask: []byte{255, 255, 255, 255},
+			Mask: net.CIDRMask(bits, bits),
 		})
 	}
 
 	return res, nil
 }
+
+// parseNetworks parses CIDR addresses into networks skipping invalid ones.
+func parseNetworks(cidrs []string) []*net.IPNet {
+	res := make([]*net.IPNet, 0, len(cidrs))
+
+	for _, cidr := range cidrs {
+		if _, ipnet, err := net.ParseCIDR(cidr); err == nil {
+			res = append(res, ipnet)
+		}
+	}
+
+	return res
+}
+
+func overlapsAny(ipnet *net.IPNet, networks []*net.IPNet) bool {
+	for _, network := range networks {
+		if network.Contains(ipnet.IP) || ipnet.Contains(network.IP) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,88 @@
+package entity
+
+import (
+	"time"
+)
+
+type PeerEventType int
+
+const (
+	// PeerEventSnapshot carries peer state at the moment watching started.
+	PeerEventSnapshot PeerEventType = iota
+	PeerEventAdded
+	PeerEventRemoved
+	PeerEventEnabled
+	PeerEventDisabled
+	PeerEventHandshake
+	PeerEventEndpointChanged
+	PeerEventTraffic
+)
+
+func (t PeerEventType) String() string {
+	switch t {
+	case PeerEventSnapshot:
+		return "snapshot"
+	case PeerEventAdded:
+		return "added"
+	case PeerEventRemoved:
+		return "removed"
+	case PeerEventEnabled:
+		return "enabled"
+	case PeerEventDisabled:
+		return "disabled"
+	case PeerEventHandshake:
+		return "handshake"
+	case PeerEventEndpointChanged:
+		return "endpoint_changed"
+	case PeerEventTraffic:
+		return "traffic"
+	default:
+		return "unknown"
+	}
+}
+
+// PeerEvent is a change of peer state observed between two snapshots.
+type PeerEvent struct {
+	Type               PeerEventType
+	Time               time.Time
+	Peer               *Peer
+	ReceiveBytesDelta  int64
+	TransmitBytesDelta int64
+}
+
+// DiffPeer returns events describing how peer state changed since the previous snapshot.
+func DiffPeer(prev, cur *Peer, now time.Time) []PeerEvent {
+	events := make([]PeerEvent, 0)
+
+	newEvent := func(t PeerEventType) PeerEvent {
+		return PeerEvent{Type: t, Time: now, Peer: cur}
+	}
+
+	if prev.IsEnabled != cur.IsEnabled {
+		if cur.IsEnabled {
+			events = append(events, newEvent(PeerEventEnabled))
+		} else {
+			events = append(events, newEvent(PeerEventDisabled))
+		}
+	}
+
+	if cur.LastHandshakeTime.After(prev.LastHandshakeTime) {
+		events = append(events, newEvent(PeerEventHandshake))
+	}
+
+	if cur.Endpoint.String() != prev.Endpoint.String() && cur.Endpoint != nil {
+		events = append(events, newEvent(PeerEventEndpointChanged))
+	}
+
+	rx := counterDelta(prev.ReceiveBytes, cur.ReceiveBytes)
+	tx := counterDelta(prev.TransmitBytes, cur.TransmitBytes)
+
+	if rx != 0 || tx != 0 {
+		event := newEvent(PeerEventTraffic)
+		event.ReceiveBytesDelta = rx
+		event.TransmitBytesDelta = tx
+		events = append(events, event)
+	}
+
+	return events
+}
@@ -0,0 +1,62 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"golang.zx2c4.com/wireguard/wgctrl/wgtypes"
+)
+
+type DriftKind int
+
+const (
+	// DriftInterfaceMissing device has no wireguard interface in the kernel.
+	DriftInterfaceMissing DriftKind = iota
+	// DriftPrivateKey interface private key differs from the device one.
+	DriftPrivateKey
+	// DriftPeerMissing enabled peer is not configured on the interface.
+	DriftPeerMissing
+	// DriftPeerUnknown interface peer is neither known nor enabled peer of the device.
+	DriftPeerUnknown
+	// DriftPeerAllowedIPs interface peer allowed IPs differ from addresses and routes of the peer.
+	DriftPeerAllowedIPs
+)
+
+func (k DriftKind) String() string {
+	switch k {
+	case DriftInterfaceMissing:
+		return "interface_missing"
+	case DriftPrivateKey:
+		return "private_key"
+	case DriftPeerMissing:
+		return "peer_missing"
+	case DriftPeerUnknown:
+		return "peer_unknown"
+	case DriftPeerAllowedIPs:
+		return "peer_allowed_ips"
+	default:
+		return "unknown"
+	}
+}
+
+// Drift is a difference between database and kernel state found by reconciliation.
+type Drift struct {
+	Kind     DriftKind
+	DeviceID uuid.UUID
+	Device   string
+	// PeerID is nil for unknown peers and interface level drift.
+	PeerID    uuid.UUID
+	PublicKey wgtypes.Key
+	// Fixed is set when kernel state was brought in line with the database.
+	Fixed bool
+}
+
+type ReconcileReport struct {
+	StartedAt  time.Time
+	FinishedAt time.Time
+	DriftOnly  bool
+	Drifts     []Drift
+	// UnmanagedInterfaces are wireguard interfaces with no device in the database, they are never touched.
+	UnmanagedInterfaces []string
+	Errors              []string
+}
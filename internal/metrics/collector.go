@@ -0,0 +1,129 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/AZhur771/wg-grpc-api/internal/app"
+	"github.com/AZhur771/wg-grpc-api/internal/entity"
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+	"golang.zx2c4.com/wireguard/wgctrl/wgtypes"
+)
+
+const collectTimeout = 10 * time.Second
+
+var peerLabels = []string{"peer_id", "peer_name", "device"}
+
+// PeerCollector exposes state of every peer known to the database
+// combined with counters of the wireguard interface it belongs to.
+type PeerCollector struct {
+	logger        *zap.Logger
+	deviceService app.DeviceService
+	deviceRepo    app.DeviceRepo
+	peerRepo      app.PeerRepo
+
+	receiveBytes  *prometheus.Desc
+	transmitBytes *prometheus.Desc
+	handshakeAge  *prometheus.Desc
+	enabled       *prometheus.Desc
+	active        *prometheus.Desc
+}
+
+func NewPeerCollector(
+	logger *zap.Logger, deviceService app.DeviceService, deviceRepo app.DeviceRepo, peerRepo app.PeerRepo,
+) *PeerCollector {
+	return &PeerCollector{
+		logger:        logger,
+		deviceService: deviceService,
+		deviceRepo:    deviceRepo,
+		peerRepo:      peerRepo,
+		receiveBytes: prometheus.NewDesc("wg_peer_receive_bytes_total",
+			"Number of bytes received from the peer.", peerLabels, nil),
+		transmitBytes: prometheus.NewDesc("wg_peer_transmit_bytes_total",
+			"Number of bytes transmitted to the peer.", peerLabels, nil),
+		handshakeAge: prometheus.NewDesc("wg_peer_last_handshake_age_seconds",
+			"Seconds passed since the last handshake with the peer.", peerLabels, nil),
+		enabled: prometheus.NewDesc("wg_peer_enabled",
+			"Whether the peer is enabled.", peerLabels, nil),
+		active: prometheus.NewDesc("wg_peer_active",
+			"Whether the peer had a recent handshake.", peerLabels, nil),
+	}
+}
+
+func (c *PeerCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.receiveBytes
+	ch <- c.transmitBytes
+	ch <- c.handshakeAge
+	ch <- c.enabled
+	ch <- c.active
+}
+
+func (c *PeerCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), collectTimeout)
+	defer cancel()
+
+	devices, err := c.deviceRepo.GetAll(ctx, nil, 0, 0, "")
+	if err != nil {
+		c.logger.Error("metrics: failed to get devices", zap.Error(err))
+		return
+	}
+
+	names := make(map[uuid.UUID]string, len(devices))
+	configured := make(map[wgtypes.Key]wgtypes.Peer)
+
+	for _, device := range devices {
+		names[device.ID] = device.Name
+
+		wgpeers, err := c.deviceService.GetConfiguredPeers(device.Name)
+		if err != nil {
+			c.logger.Error("metrics: failed to get configured peers",
+				zap.String("device", device.Name), zap.Error(err))
+			continue
+		}
+
+		for _, wgpeer := range wgpeers {
+			configured[wgpeer.PublicKey] = wgpeer
+		}
+	}
+
+	peers, err := c.peerRepo.GetAll(ctx, nil, 0, 0, "", uuid.Nil)
+	if err != nil {
+		c.logger.Error("metrics: failed to get peers", zap.Error(err))
+		return
+	}
+
+	now := time.Now()
+
+	for _, peer := range peers {
+		if wgpeer, ok := configured[peer.PublicKey]; ok && peer.IsEnabled {
+			peer = peer.PopulateDynamicFields(&wgpeer)
+		}
+
+		c.collectPeer(ch, peer, names[peer.DeviceID], now)
+	}
+}
+
+func (c *PeerCollector) collectPeer(ch chan<- prometheus.Metric, peer *entity.Peer, device string, now time.Time) {
+	labels := []string{peer.ID.String(), peer.Name, device}
+
+	ch <- prometheus.MustNewConstMetric(c.receiveBytes, prometheus.CounterValue, float64(peer.ReceiveBytes), labels...)
+	ch <- prometheus.MustNewConstMetric(c.transmitBytes, prometheus.CounterValue, float64(peer.TransmitBytes), labels...)
+	ch <- prometheus.MustNewConstMetric(c.enabled, prometheus.GaugeValue, boolToFloat(peer.IsEnabled), labels...)
+	ch <- prometheus.MustNewConstMetric(c.active, prometheus.GaugeValue, boolToFloat(peer.IsActive), labels...)
+
+	// peer which never completed a handshake has no age to report
+	if !peer.LastHandshakeTime.IsZero() {
+		ch <- prometheus.MustNewConstMetric(c.handshakeAge, prometheus.GaugeValue,
+			now.Sub(peer.LastHandshakeTime).Seconds(), labels...)
+	}
+}
+
+func boolToFloat(b bool) float64 {
+	if b {
+		return 1
+	}
+
+	return 0
+}
@@ -0,0 +1,215 @@
+package apikeyrepo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/AZhur771/wg-grpc-api/internal/entity"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+type APIKeyRepo struct {
+	db *sqlx.DB
+}
+
+func New(db *sqlx.DB) *APIKeyRepo {
+	return &APIKeyRepo{
+		db: db,
+	}
+}
+
+func (a *APIKeyRepo) Add(ctx context.Context, tx *sqlx.Tx, key *entity.APIKey) (*entity.APIKey, error) {
+	model := NewModel().FromEntity(key)
+
+	query := `
+		INSERT INTO api_key (
+				"name",
+				"role",
+				device_ids,
+				salt,
+				hash,
+				created_at
+			)
+		VALUES (
+				:name,
+				:role,
+				:device_ids,
+				:salt,
+				:hash,
+				:created_at
+			)
+		RETURNING *;
+	`
+
+	var rows *sqlx.Rows
+	var err error
+
+	if tx == nil {
+		rows, err = a.db.NamedQueryContext(ctx, query, model)
+	} else {
+		rows, err = tx.NamedQuery(query, model)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("api key repo: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		if err := rows.StructScan(model); err != nil {
+			return nil, fmt.Errorf("api key repo: %w", err)
+		}
+	}
+
+	return model.ToEntity()
+}
+
+func (a *APIKeyRepo) Get(ctx context.Context, tx *sqlx.Tx, id uuid.UUID) (*entity.APIKey, error) {
+	model := NewModel()
+
+	query := "SELECT * FROM api_key WHERE id = $1;"
+
+	var err error
+
+	if tx == nil {
+		err = a.db.GetContext(ctx, model, query, id.String())
+	} else {
+		err = tx.Get(model, query, id.String())
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("api key repo: %w", err)
+	}
+
+	return model.ToEntity()
+}
+
+func (a *APIKeyRepo) GetAll(ctx context.Context, tx *sqlx.Tx, skip, limit int) ([]*entity.APIKey, error) {
+	query := "SELECT * FROM api_key ORDER BY created_at ###offset### ###limit###;"
+
+	if skip != 0 {
+		query = strings.Replace(query, "###offset###", "OFFSET :skip", 1)
+	} else {
+		query = strings.Replace(query, "###offset###", "", 1)
+	}
+
+	if limit != 0 {
+		query = strings.Replace(query, "###limit###", "LIMIT :limit", 1)
+	} else {
+		query = strings.Replace(query, "###limit###", "", 1)
+	}
+
+	args := struct {
+		Skip  int
+		Limit int
+	}{
+		Skip:  skip,
+		Limit: limit,
+	}
+
+	var rows *sqlx.Rows
+	var err error
+
+	if tx == nil {
+		rows, err = a.db.NamedQueryContext(ctx, query, args)
+	} else {
+		rows, err = tx.NamedQuery(query, args)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("api key repo: %w", err)
+	}
+	defer rows.Close()
+
+	keys := make([]*entity.APIKey, 0)
+
+	for rows.Next() {
+		model := NewModel()
+
+		if err := rows.StructScan(model); err != nil {
+			return nil, fmt.Errorf("api key repo: %w", err)
+		}
+
+		key, err := model.ToEntity()
+		if err != nil {
+			return nil, fmt.Errorf("api key repo: %w", err)
+		}
+
+		keys = append(keys, key)
+	}
+
+	return keys, nil
+}
+
+func (a *APIKeyRepo) Count(ctx context.Context, tx *sqlx.Tx) (int, error) {
+	var count int
+	var err error
+
+	query := "SELECT count(1) FROM api_key;"
+
+	if tx == nil {
+		err = a.db.GetContext(ctx, &count, query)
+	} else {
+		err = tx.Get(&count, query)
+	}
+
+	if err != nil {
+		return 0, fmt.Errorf("api key repo: %w", err)
+	}
+
+	return count, nil
+}
+
+// Revoke marks key as revoked, already revoked key keeps its original revocation time.
+// sql.ErrNoRows is returned if there is no such key.
+func (a *APIKeyRepo) Revoke(ctx context.Context, tx *sqlx.Tx, id uuid.UUID, at time.Time) error {
+	query := "UPDATE api_key SET revoked_at = coalesce(revoked_at, $2) WHERE id = $1;"
+
+	var (
+		res sql.Result
+		err error
+	)
+
+	if tx == nil {
+		res, err = a.db.ExecContext(ctx, query, id.String(), at)
+	} else {
+		res, err = tx.Exec(query, id.String(), at)
+	}
+
+	if err != nil {
+		return fmt.Errorf("api key repo: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("api key repo: %w", err)
+	}
+
+	if affected == 0 {
+		return fmt.Errorf("api key repo: %w", sql.ErrNoRows)
+	}
+
+	return nil
+}
+
+func (a *APIKeyRepo) UpdateLastUsed(ctx context.Context, tx *sqlx.Tx, id uuid.UUID, at time.Time) error {
+	query := "UPDATE api_key SET last_used_at = $2 WHERE id = $1;"
+
+	var err error
+
+	if tx == nil {
+		_, err = a.db.ExecContext(ctx, query, id.String(), at)
+	} else {
+		_, err = tx.Exec(query, id.String(), at)
+	}
+
+	if err != nil {
+		return fmt.Errorf("api key repo: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,82 @@
+package apikeyrepo
+
+import (
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/AZhur771/wg-grpc-api/internal/auth"
+	"github.com/AZhur771/wg-grpc-api/internal/entity"
+	"github.com/google/uuid"
+)
+
+type APIKeyModel struct {
+	ID         uuid.UUID `db:"id" sql:",type:uuid"`
+	Name       string
+	Role       string
+	DeviceIDs  string       `db:"device_ids"`
+	Salt       []byte       `db:"salt"`
+	Hash       []byte       `db:"hash"`
+	CreatedAt  time.Time    `db:"created_at"`
+	LastUsedAt sql.NullTime `db:"last_used_at"`
+	RevokedAt  sql.NullTime `db:"revoked_at"`
+}
+
+func NewModel() *APIKeyModel {
+	return &APIKeyModel{}
+}
+
+func (k *APIKeyModel) FromEntity(key *entity.APIKey) *APIKeyModel {
+	k.ID = key.ID
+	k.Name = key.Name
+	k.Role = key.Role.String()
+	k.Salt = key.Salt
+	k.Hash = key.Hash
+	k.CreatedAt = key.CreatedAt
+	k.LastUsedAt = sql.NullTime{Time: key.LastUsedAt, Valid: !key.LastUsedAt.IsZero()}
+	k.RevokedAt = sql.NullTime{Time: key.RevokedAt, Valid: !key.RevokedAt.IsZero()}
+
+	deviceIDs := make([]string, 0, len(key.DeviceIDs))
+	for _, id := range key.DeviceIDs {
+		deviceIDs = append(deviceIDs, id.String())
+	}
+	k.DeviceIDs = strings.Join(deviceIDs, ",")
+
+	return k
+}
+
+func (k *APIKeyModel) ToEntity() (*entity.APIKey, error) {
+	role, err := auth.ParseRole(k.Role)
+	if err != nil {
+		return nil, err
+	}
+
+	key := &entity.APIKey{
+		ID:        k.ID,
+		Name:      k.Name,
+		Role:      role,
+		Salt:      k.Salt,
+		Hash:      k.Hash,
+		CreatedAt: k.CreatedAt,
+	}
+
+	if k.LastUsedAt.Valid {
+		key.LastUsedAt = k.LastUsedAt.Time
+	}
+
+	if k.RevokedAt.Valid {
+		key.RevokedAt = k.RevokedAt.Time
+	}
+
+	if k.DeviceIDs != "" {
+		for _, id := range strings.Split(k.DeviceIDs, ",") {
+			deviceID, err := uuid.Parse(id)
+			if err != nil {
+				return nil, err
+			}
+			key.DeviceIDs = append(key.DeviceIDs, deviceID)
+		}
+	}
+
+	return key, nil
+}
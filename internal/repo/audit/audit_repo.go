@@ -0,0 +1,202 @@
+package auditrepo
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/AZhur771/wg-grpc-api/internal/entity"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+type AuditRepo struct {
+	db *sqlx.DB
+}
+
+func New(db *sqlx.DB) *AuditRepo {
+	return &AuditRepo{
+		db: db,
+	}
+}
+
+type filterArgs struct {
+	Actor      string
+	EntityType string `db:"entity_type"`
+	EntityID   string `db:"entity_id"`
+	From       time.Time
+	To         time.Time
+	Skip       int
+	Limit      int
+}
+
+// whereClause returns condition matching the filter along with its named arguments.
+func whereClause(filter entity.AuditFilter) (string, filterArgs) {
+	conditions := make([]string, 0)
+	args := filterArgs{
+		Actor:      filter.Actor,
+		EntityType: filter.EntityType,
+		EntityID:   filter.EntityID.String(),
+		From:       filter.From,
+		To:         filter.To,
+	}
+
+	if filter.Actor != "" {
+		conditions = append(conditions, "actor = :actor")
+	}
+
+	if filter.EntityType != "" {
+		conditions = append(conditions, "entity_type = :entity_type")
+	}
+
+	if filter.EntityID != uuid.Nil {
+		conditions = append(conditions, "entity_id = :entity_id")
+	}
+
+	if !filter.From.IsZero() {
+		conditions = append(conditions, "created_at >= :from")
+	}
+
+	if !filter.To.IsZero() {
+		conditions = append(conditions, "created_at < :to")
+	}
+
+	if len(conditions) == 0 {
+		return "", args
+	}
+
+	return "WHERE " + strings.Join(conditions, " AND "), args
+}
+
+func (a *AuditRepo) Add(ctx context.Context, tx *sqlx.Tx, record *entity.AuditRecord) (*entity.AuditRecord, error) {
+	model, err := NewModel().FromEntity(record)
+	if err != nil {
+		return nil, fmt.Errorf("audit repo: %w", err)
+	}
+
+	query := `
+		INSERT INTO audit_record (
+				actor,
+				action,
+				entity_type,
+				entity_id,
+				changes,
+				created_at
+			)
+		VALUES (
+				:actor,
+				:action,
+				:entity_type,
+				:entity_id,
+				:changes,
+				:created_at
+			)
+		RETURNING *;
+	`
+
+	var rows *sqlx.Rows
+
+	if tx == nil {
+		rows, err = a.db.NamedQueryContext(ctx, query, model)
+	} else {
+		rows, err = tx.NamedQuery(query, model)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("audit repo: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		if err := rows.StructScan(model); err != nil {
+			return nil, fmt.Errorf("audit repo: %w", err)
+		}
+	}
+
+	return model.ToEntity()
+}
+
+func (a *AuditRepo) GetAll(
+	ctx context.Context, tx *sqlx.Tx, skip, limit int, filter entity.AuditFilter,
+) ([]*entity.AuditRecord, error) {
+	query := "SELECT * FROM audit_record ###where### ORDER BY created_at DESC ###offset### ###limit###;"
+
+	where, args := whereClause(filter)
+	query = strings.Replace(query, "###where###", where, 1)
+
+	if skip != 0 {
+		query = strings.Replace(query, "###offset###", "OFFSET :skip", 1)
+	} else {
+		query = strings.Replace(query, "###offset###", "", 1)
+	}
+
+	if limit != 0 {
+		query = strings.Replace(query, "###limit###", "LIMIT :limit", 1)
+	} else {
+		query = strings.Replace(query, "###limit###", "", 1)
+	}
+
+	args.Skip = skip
+	args.Limit = limit
+
+	var rows *sqlx.Rows
+	var err error
+
+	if tx == nil {
+		rows, err = a.db.NamedQueryContext(ctx, query, args)
+	} else {
+		rows, err = tx.NamedQuery(query, args)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("audit repo: %w", err)
+	}
+	defer rows.Close()
+
+	records := make([]*entity.AuditRecord, 0)
+
+	for rows.Next() {
+		model := NewModel()
+
+		if err := rows.StructScan(model); err != nil {
+			return nil, fmt.Errorf("audit repo: %w", err)
+		}
+
+		record, err := model.ToEntity()
+		if err != nil {
+			return nil, fmt.Errorf("audit repo: %w", err)
+		}
+
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
+func (a *AuditRepo) Count(ctx context.Context, tx *sqlx.Tx, filter entity.AuditFilter) (int, error) {
+	where, args := whereClause(filter)
+	query := fmt.Sprintf("SELECT count(1) FROM audit_record %s;", where)
+
+	var count int
+
+	stmt, err := a.prepareNamed(ctx, tx, query)
+	if err != nil {
+		return 0, fmt.Errorf("audit repo: %w", err)
+	}
+	defer stmt.Close()
+
+	if err := stmt.GetContext(ctx, &count, args); err != nil {
+		return 0, fmt.Errorf("audit repo: %w", err)
+	}
+
+	return count, nil
+}
+
+func (a *AuditRepo) prepareNamed(ctx context.Context, tx *sqlx.Tx, query string) (*sqlx.NamedStmt, error) {
+	if tx == nil {
+		return a.db.PrepareNamedContext(ctx, query)
+	}
+
+	return tx.PrepareNamedContext(ctx, query)
+}
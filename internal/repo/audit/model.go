@@ -0,0 +1,57 @@
+package auditrepo
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/AZhur771/wg-grpc-api/internal/entity"
+	"github.com/google/uuid"
+)
+
+type AuditRecordModel struct {
+	ID         uuid.UUID `db:"id" sql:",type:uuid"`
+	Actor      string
+	Action     string
+	EntityType string    `db:"entity_type"`
+	EntityID   uuid.UUID `db:"entity_id" sql:",type:uuid"`
+	Changes    []byte    `db:"changes"`
+	CreatedAt  time.Time `db:"created_at"`
+}
+
+func NewModel() *AuditRecordModel {
+	return &AuditRecordModel{}
+}
+
+func (r *AuditRecordModel) FromEntity(record *entity.AuditRecord) (*AuditRecordModel, error) {
+	changes, err := json.Marshal(record.Changes)
+	if err != nil {
+		return nil, err
+	}
+
+	r.ID = record.ID
+	r.Actor = record.Actor
+	r.Action = string(record.Action)
+	r.EntityType = record.EntityType
+	r.EntityID = record.EntityID
+	r.Changes = changes
+	r.CreatedAt = record.CreatedAt
+
+	return r, nil
+}
+
+func (r *AuditRecordModel) ToEntity() (*entity.AuditRecord, error) {
+	record := &entity.AuditRecord{
+		ID:         r.ID,
+		Actor:      r.Actor,
+		Action:     entity.AuditAction(r.Action),
+		EntityType: r.EntityType,
+		EntityID:   r.EntityID,
+		CreatedAt:  r.CreatedAt,
+	}
+
+	if err := json.Unmarshal(r.Changes, &record.Changes); err != nil {
+		return nil, err
+	}
+
+	return record, nil
+}
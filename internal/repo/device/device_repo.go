@@ -6,61 +6,81 @@
 	"errors"
 	"fmt"
 	"net"
+	"strconv"
+	"strings"
 
 	"github.com/AZhur771/wg-grpc-api/internal/entity"
+	"github.com/AZhur771/wg-grpc-api/internal/repo"
+	"github.com/AZhur771/wg-grpc-api/internal/secrets"
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
 )
 
+// nameConstraint keeps device names unique.
+const nameConstraint = "device_name_key"
+
 type DeviceRepo struct {
-	db *sqlx.DB
+	db        *sqlx.DB
+	keyCipher *secrets.Cipher
 }
 
-func New(db *sqlx.DB) *DeviceRepo {
+// New creates repo storing keys encrypted with keyCipher, nil cipher keeps them unencrypted.
+func New(db *sqlx.DB, keyCipher *secrets.Cipher) *DeviceRepo {
 	return &DeviceRepo{
-		db: db,
+		db:        db,
+		keyCipher: keyCipher,
 	}
 }
 
 func (d *DeviceRepo) Add(ctx context.Context, tx *sqlx.Tx, dev *entity.Device) (*entity.Device, error) {
-	model := d.toModel(dev)
+	model, err := d.toModel(dev)
+	if err != nil {
+		return nil, fmt.Errorf("device repo: %w", err)
+	}
 
 	query := `
 		INSERT INTO device (
+				"name",
 				private_key,
 				description,
 				endpoint,
 				fw_mark,
 				address,
+				address6,
 				mtu,
 				dns,
 				persistent_keep_alive,
 				tble,
+				netns,
 				pre_up,
 				post_up,
 				pre_down,
-				post_down
+				post_down,
+				client_allowed_ips
 			)
 		VALUES (
+				Coalesce(NULLIF(:name, ''), 'wg' || Nextval('device_num') - 1),
 				:private_key,
 				:description,
 				:endpoint,
 				:fw_mark,
 				:address,
+				:address6,
 				:mtu,
 				:dns,
 				:persistent_keep_alive,
 				:tble,
+				:netns,
 				:pre_up,
 				:post_up,
 				:pre_down,
-				:post_down
+				:post_down,
+				:client_allowed_ips
 			)
 		RETURNING *;
 	`
 
 	var rows *sqlx.Rows
-	var err error
 
 	if tx == nil {
 		rows, err = d.db.NamedQueryContext(ctx, query, model)
@@ -69,7 +89,7 @@ func (d *DeviceRepo) Add(ctx context.Context, tx *sqlx.Tx, dev *entity.Device) (
 	}
 
 	if err != nil {
-		return nil, fmt.Errorf("device repo: %w", err)
+		return nil, fmt.Errorf("device repo: %w", mapNameTaken(err))
 	}
 	defer rows.Close()
 
@@ -79,11 +99,60 @@ func (d *DeviceRepo) Add(ctx context.Context, tx *sqlx.Tx, dev *entity.Device) (
 		}
 	}
 
-	return model.ToEntity()
+	// violation of the unique name is reported once rows are read
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("device repo: %w", mapNameTaken(err))
+	}
+
+	rows.Close()
+
+	if dev.Name != "" {
+		if err := d.skipDeviceNum(ctx, tx, dev.Name); err != nil {
+			return nil, err
+		}
+	}
+
+	return model.ToEntity(d.keyCipher)
+}
+
+// mapNameTaken reports violation of the unique device name as repo.ErrDeviceExists,
+// so devices added concurrently are rejected the same way.
+func mapNameTaken(err error) error {
+	if repo.IsUniqueViolation(err, nameConstraint) {
+		return repo.ErrDeviceExists
+	}
+
+	return err
+}
+
+// skipDeviceNum moves device name sequence past the number of explicitly named device,
+// so generated names never collide with it.
+func (d *DeviceRepo) skipDeviceNum(ctx context.Context, tx *sqlx.Tx, name string) error {
+	num, err := strconv.Atoi(strings.TrimPrefix(name, "wg"))
+	if err != nil {
+		return fmt.Errorf("device repo: %w", err)
+	}
+
+	query := "SELECT Setval('device_num', Greatest($1::BIGINT + 1, (SELECT last_value FROM device_num)));"
+
+	if tx == nil {
+		_, err = d.db.ExecContext(ctx, query, num)
+	} else {
+		_, err = tx.Exec(query, num)
+	}
+
+	if err != nil {
+		return fmt.Errorf("device repo: %w", err)
+	}
+
+	return nil
 }
 
 func (d *DeviceRepo) Update(ctx context.Context, tx *sqlx.Tx, dev *entity.Device) (*entity.Device, error) {
-	model := d.toModel(dev)
+	model, err := d.toModel(dev)
+	if err != nil {
+		return nil, fmt.Errorf("device repo: %w", err)
+	}
 
 	query := `
 		UPDATE device
@@ -91,19 +160,22 @@ func (d *DeviceRepo) Update(ctx context.Context, tx *sqlx.Tx, dev *entity.Device
 			endpoint = :endpoint,
 			fw_mark = :fw_mark,
 			address = :address,
+			address6 = :address6,
 			mtu = :mtu,
 			dns = :dns,
 			tble = :tble,
+			netns = :netns,
 			persistent_keep_alive = :persistent_keep_alive,
 			pre_up = :pre_up,
 			post_up = :post_up,
 			pre_down = :pre_down,
-			post_down = :post_down
+			post_down = :post_down,
+			client_allowed_ips = :client_allowed_ips
+		WHERE id = :id
 		RETURNING *;
 	`
 
 	var rows *sqlx.Rows
-	var err error
 
 	if tx == nil {
 		rows, err = d.db.NamedQueryContext(ctx, query, model)
@@ -122,7 +194,35 @@ func (d *DeviceRepo) Update(ctx context.Context, tx *sqlx.Tx, dev *entity.Device
 		}
 	}
 
-	return model.ToEntity()
+	return model.ToEntity(d.keyCipher)
+}
+
+// UpdateKey persists only current and pending private keys of the device along with cutover time.
+func (d *DeviceRepo) UpdateKey(ctx context.Context, tx *sqlx.Tx, dev *entity.Device) error {
+	model, err := d.toModel(dev)
+	if err != nil {
+		return fmt.Errorf("device repo: %w", err)
+	}
+
+	query := `
+		UPDATE device
+		SET private_key = :private_key,
+			pending_private_key = :pending_private_key,
+			key_cutover_at = :key_cutover_at
+		WHERE id = :id;
+	`
+
+	if tx == nil {
+		_, err = d.db.NamedExecContext(ctx, query, model)
+	} else {
+		_, err = tx.NamedExec(query, model)
+	}
+
+	if err != nil {
+		return fmt.Errorf("device repo: %w", err)
+	}
+
+	return nil
 }
 
 func (d *DeviceRepo) Remove(ctx context.Context, tx *sqlx.Tx, id uuid.UUID) error {
@@ -154,14 +254,19 @@ func (d *DeviceRepo) Get(ctx context.Context, tx *sqlx.Tx, id uuid.UUID) (*entit
 			endpoint,
 			fw_mark,
 			address,
+			address6,
 			mtu,
 			dns,
 			persistent_keep_alive,
 			tble,
+			netns,
 			pre_up,
 			post_up,
 			pre_down,
-			post_down
+			post_down,
+			client_allowed_ips,
+			pending_private_key,
+			key_cutover_at
 		FROM device
 		WHERE id = $1;
 	`
@@ -178,7 +283,7 @@ func (d *DeviceRepo) Get(ctx context.Context, tx *sqlx.Tx, id uuid.UUID) (*entit
 		return nil, fmt.Errorf("device repo: %w", err)
 	}
 
-	return model.ToEntity()
+	return model.ToEntity(d.keyCipher)
 }
 
 func (d *DeviceRepo) GetAll(ctx context.Context, tx *sqlx.Tx, skip, limit int, search string) ([]*entity.Device, error) {
@@ -192,14 +297,19 @@ func (d *DeviceRepo) GetAll(ctx context.Context, tx *sqlx.Tx, skip, limit int, s
 			endpoint,
 			fw_mark,
 			address,
+			address6,
 			mtu,
 			dns,
 			persistent_keep_alive,
 			tble,
+			netns,
 			pre_up,
 			post_up,
 			pre_down,
-			post_down
+			post_down,
+			client_allowed_ips,
+			pending_private_key,
+			key_cutover_at
 		FROM device
 	`
 
@@ -248,7 +358,7 @@ func (d *DeviceRepo) GetAll(ctx context.Context, tx *sqlx.Tx, skip, limit int, s
 			return nil, fmt.Errorf("device repo: %w", err)
 		}
 
-		dev, err := model.ToEntity()
+		dev, err := model.ToEntity(d.keyCipher)
 		if err != nil {
 			return nil, fmt.Errorf("device repo: %w", err)
 		}
@@ -278,39 +388,44 @@ func (d *DeviceRepo) Count(ctx context.Context, tx *sqlx.Tx) (int, error) {
 	return count, nil
 }
 
-func (d *DeviceRepo) GenerateAddress(ctx context.Context, tx *sqlx.Tx, dev *entity.Device) (string, error) {
+// GenerateAddress returns the lowest free host address from cidr network of the device.
+// Candidates are the first host address and addresses following the taken ones,
+// so the query does not depend on network size and works for huge IPv6 networks.
+func (d *DeviceRepo) GenerateAddress(ctx context.Context, tx *sqlx.Tx, dev *entity.Device, cidr string) (string, error) {
 	query := `
-		SELECT sub.ip
-		FROM (
-				SELECT Set_masklen(
-						(
-							(
-								Generate_series(
-									1,
-									(2 ^ (32 - Masklen($1::cidr)))::INTEGER - 2
-								) + $1::cidr
-							)::inet
-						),
-						32
-					) AS ip
-			) AS sub
-		WHERE sub.ip NOT IN (
-				SELECT Set_masklen(address, 32)
+		WITH taken AS (
+				SELECT Host(address)::inet AS ip
 				FROM peer_address
 				WHERE device_id = $2
-			)
-			AND sub.ip != (
-				SELECT Set_masklen(address, 32)
+				UNION
+				SELECT Host(address)::inet
+				FROM device
+				WHERE id = $2
+				UNION
+				SELECT Host(address6)::inet
 				FROM device
 				WHERE id = $2
+					AND address6 IS NOT NULL
 			)
-			AND sub.ip > Set_masklen($1, 32);
+		SELECT Host(sub.ip)
+		FROM (
+				SELECT Host(Network($1::cidr))::inet + 1 AS ip
+				UNION
+				SELECT ip + 1
+				FROM taken
+				WHERE ip << $1::cidr
+					AND ip < Host(Broadcast($1::cidr))::inet
+			) AS sub
+		WHERE sub.ip NOT IN (SELECT ip FROM taken)
+			AND sub.ip < Host(Broadcast($1::cidr))::inet
+		ORDER BY sub.ip
+		LIMIT 1;
 	`
 
 	var addr string
 	var err error
 
-	_, ipnet, err := net.ParseCIDR(dev.Address)
+	_, ipnet, err := net.ParseCIDR(cidr)
 	if err != nil {
 		return addr, fmt.Errorf("device repo: %w", err)
 	}
@@ -336,6 +451,22 @@ func (d *DeviceRepo) BeginTxx(ctx context.Context, options *sql.TxOptions) (*sql
 	return d.db.BeginTxx(ctx, options)
 }
 
-func (d *DeviceRepo) toModel(dev *entity.Device) *DeviceModel {
-	return NewModel().FromEntity(dev)
+// ReencryptKeys encrypts current and pending private keys of all devices with newCipher,
+// tx is required to rotate keys atomically.
+func (d *DeviceRepo) ReencryptKeys(ctx context.Context, tx *sqlx.Tx, newCipher *secrets.Cipher) (int, error) {
+	total := 0
+
+	for _, column := range []string{"private_key", "pending_private_key"} {
+		count, err := secrets.ReencryptColumn(ctx, tx.Tx, "device", column, d.keyCipher, newCipher)
+		if err != nil {
+			return 0, fmt.Errorf("device repo: %w", err)
+		}
+		total += count
+	}
+
+	return total, nil
+}
+
+func (d *DeviceRepo) toModel(dev *entity.Device) (*DeviceModel, error) {
+	return NewModel().FromEntity(dev, d.keyCipher)
 }
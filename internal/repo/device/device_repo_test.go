@@ -0,0 +1,36 @@
+package devicerepo_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/AZhur771/wg-grpc-api/internal/entity"
+	"github.com/AZhur771/wg-grpc-api/internal/repo"
+	devicerepo "github.com/AZhur771/wg-grpc-api/internal/repo/device"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jackc/pgconn"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeviceRepo_AddExists(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, mock.ExpectationsWereMet())
+		db.Close()
+	})
+
+	deviceRepo := devicerepo.New(sqlx.NewDb(db, "sqlmock"), nil)
+	nameTaken := &pgconn.PgError{Code: "23505", ConstraintName: "device_name_key"}
+
+	// violation is reported either by the query or once its rows are read
+	mock.ExpectQuery("INSERT INTO device").WillReturnError(nameTaken)
+	mock.ExpectQuery("INSERT INTO device").
+		WillReturnRows(sqlmock.NewRows([]string{"name"}).AddRow("wg0").RowError(0, nameTaken))
+
+	for i := 0; i < 2; i++ {
+		_, err = deviceRepo.Add(context.Background(), nil, &entity.Device{Name: "wg0"})
+		require.ErrorIs(t, err, repo.ErrDeviceExists)
+	}
+}
@@ -1,9 +1,12 @@
 package devicerepo
 
 import (
+	"database/sql"
+	"strings"
 	"time"
 
 	"github.com/AZhur771/wg-grpc-api/internal/entity"
+	"github.com/AZhur771/wg-grpc-api/internal/secrets"
 	"github.com/google/uuid"
 	"golang.zx2c4.com/wireguard/wgctrl/wgtypes"
 )
@@ -16,44 +19,78 @@ type DeviceModel struct {
 	Endpoint            string
 	FwMark              int `db:"fw_mark"`
 	Address             string
+	Address6            sql.NullString
 	Mtu                 int
 	DNS                 string `db:"dns"`
 	PersistentKeepAlive int    `db:"persistent_keep_alive"`
 	Tble                string
-	PreUp               string `db:"pre_up"`
-	PostUp              string `db:"post_up"`
-	PreDown             string `db:"pre_down"`
-	PostDown            string `db:"post_down"`
+	Netns               string
+	PreUp               string       `db:"pre_up"`
+	PostUp              string       `db:"post_up"`
+	PreDown             string       `db:"pre_down"`
+	PostDown            string       `db:"post_down"`
+	ClientAllowedIPs    string       `db:"client_allowed_ips"`
+	PendingPrivateKey   string       `db:"pending_private_key"`
+	KeyCutoverAt        sql.NullTime `db:"key_cutover_at"`
 }
 
 func NewModel() *DeviceModel {
 	return &DeviceModel{}
 }
 
-func (d *DeviceModel) FromEntity(dev *entity.Device) *DeviceModel {
+// FromEntity fills the model with device data, private keys are encrypted with keyCipher.
+func (d *DeviceModel) FromEntity(dev *entity.Device, keyCipher *secrets.Cipher) (*DeviceModel, error) {
+	privateKey, err := keyCipher.Encrypt(dev.PrivateKey.String())
+	if err != nil {
+		return nil, err
+	}
+
 	d.ID = dev.ID
 	d.Name = dev.Name
-	d.PrivateKey = dev.PrivateKey.String()
+	d.PrivateKey = privateKey
 	d.Description = dev.Description
 	d.Endpoint = dev.Endpoint
 	d.FwMark = dev.FirewallMark
 	d.Address = dev.Address
+	d.Address6 = sql.NullString{
+		String: dev.Address6,
+		Valid:  dev.Address6 != "",
+	}
 	d.Mtu = dev.MTU
 	d.DNS = dev.DNS
 	d.PersistentKeepAlive = int(dev.PersistentKeepAlive) / (1000 * 1000 * 1000)
 	d.Tble = dev.Table
+	d.Netns = dev.Netns
 	d.PreUp = dev.PreUp
 	d.PostUp = dev.PostUp
 	d.PreDown = dev.PreDown
 	d.PostDown = dev.PostDown
+	d.ClientAllowedIPs = strings.Join(dev.ClientAllowedIPs, ",")
+	d.PendingPrivateKey = ""
+	d.KeyCutoverAt = sql.NullTime{
+		Time:  dev.KeyCutoverAt,
+		Valid: dev.HasPendingKey(),
+	}
 
-	return d
+	if dev.HasPendingKey() {
+		if d.PendingPrivateKey, err = keyCipher.Encrypt(dev.PendingPrivateKey.String()); err != nil {
+			return nil, err
+		}
+	}
+
+	return d, nil
 }
 
-func (d *DeviceModel) ToEntity() (*entity.Device, error) {
+// ToEntity maps the model to device, private keys are decrypted with keyCipher.
+func (d *DeviceModel) ToEntity(keyCipher *secrets.Cipher) (*entity.Device, error) {
 	dev := &entity.Device{}
 
-	privateKey, err := wgtypes.ParseKey(d.PrivateKey)
+	decrypted, err := keyCipher.Decrypt(d.PrivateKey)
+	if err != nil {
+		return dev, err
+	}
+
+	privateKey, err := wgtypes.ParseKey(decrypted)
 	if err != nil {
 		return dev, err
 	}
@@ -65,14 +102,34 @@ func (d *DeviceModel) ToEntity() (*entity.Device, error) {
 	dev.Endpoint = d.Endpoint
 	dev.FirewallMark = d.FwMark
 	dev.Address = d.Address
+	dev.Address6 = d.Address6.String
 	dev.MTU = d.Mtu
 	dev.DNS = d.DNS
 	dev.PersistentKeepAlive = time.Duration(d.PersistentKeepAlive) * time.Second
 	dev.Table = d.Tble
+	dev.Netns = d.Netns
 	dev.PreUp = d.PreUp
 	dev.PostUp = d.PostUp
 	dev.PreDown = d.PreDown
 	dev.PostDown = d.PostDown
 
+	if d.ClientAllowedIPs != "" {
+		dev.ClientAllowedIPs = strings.Split(d.ClientAllowedIPs, ",")
+	}
+
+	if d.KeyCutoverAt.Valid && d.PendingPrivateKey != "" {
+		decrypted, err := keyCipher.Decrypt(d.PendingPrivateKey)
+		if err != nil {
+			return dev, err
+		}
+
+		pendingPrivateKey, err := wgtypes.ParseKey(decrypted)
+		if err != nil {
+			return dev, err
+		}
+
+		dev.SetPendingKey(pendingPrivateKey, d.KeyCutoverAt.Time)
+	}
+
 	return dev, nil
 }
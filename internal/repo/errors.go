@@ -0,0 +1,22 @@
+package repo
+
+import (
+	"errors"
+
+	"github.com/jackc/pgconn"
+)
+
+// uniqueViolation is the SQLSTATE of unique constraint violations.
+const uniqueViolation = "23505"
+
+var (
+	ErrAddressTaken = errors.New("address is already taken")
+	ErrDeviceExists = errors.New("device already exists")
+)
+
+// IsUniqueViolation reports whether err is a violation of the named unique constraint.
+func IsUniqueViolation(err error, constraint string) bool {
+	var pgErr *pgconn.PgError
+
+	return errors.As(err, &pgErr) && pgErr.Code == uniqueViolation && pgErr.ConstraintName == constraint
+}
@@ -1,9 +1,12 @@
 package peerrepo
 
 import (
+	"database/sql"
+	"strings"
 	"time"
 
 	"github.com/AZhur771/wg-grpc-api/internal/entity"
+	"github.com/AZhur771/wg-grpc-api/internal/secrets"
 	"github.com/google/uuid"
 	"golang.zx2c4.com/wireguard/wgctrl/wgtypes"
 )
@@ -12,14 +15,25 @@ type PeerModel struct {
 	ID                  uuid.UUID `db:"id" sql:",type:uuid"`
 	DeviceID            uuid.UUID `db:"device_id" sql:",type:uuid"`
 	PrivateKey          string    `db:"private_key"`
+	PublicKey           string    `db:"public_key"`
 	PresharedKey        string    `db:"preshared_key"`
 	Name                string
 	Description         string
 	Email               string
 	DNS                 string `db:"dns"`
 	Mtu                 int
-	PersistentKeepAlive int  `db:"persistent_keep_alive"`
-	IsEnabled           bool `db:"is_enabled"`
+	PersistentKeepAlive int          `db:"persistent_keep_alive"`
+	IsEnabled           bool         `db:"is_enabled"`
+	ExpiresAt           sql.NullTime `db:"expires_at"`
+	QuotaBytes          int64        `db:"quota_bytes"`
+	QuotaPeriod         string       `db:"quota_period"`
+	UsageReceiveBytes   int64        `db:"usage_receive_bytes"`
+	UsageTransmitBytes  int64        `db:"usage_transmit_bytes"`
+	LastReceiveBytes    int64        `db:"last_receive_bytes"`
+	LastTransmitBytes   int64        `db:"last_transmit_bytes"`
+	UsagePeriodStart    time.Time    `db:"usage_period_start"`
+	ClientAllowedIPs    string       `db:"client_allowed_ips"`
+	ExtraRoutes         string       `db:"extra_routes"`
 	AllowedIPs          []string
 }
 
@@ -27,10 +41,11 @@ func NewModel() *PeerModel {
 	return &PeerModel{}
 }
 
-func (p *PeerModel) FromEntity(peer *entity.Peer) *PeerModel {
+// FromEntity fills the model with peer data, private and preshared keys are encrypted with keyCipher.
+func (p *PeerModel) FromEntity(peer *entity.Peer, keyCipher *secrets.Cipher) (*PeerModel, error) {
 	p.ID = peer.ID
 	p.DeviceID = peer.DeviceID
-	p.PrivateKey = peer.PrivateKey.String()
+	p.PublicKey = peer.PublicKey.String()
 	p.Name = peer.Name
 	p.Description = peer.Description
 	p.Email = peer.Email
@@ -39,25 +54,63 @@ func (p *PeerModel) FromEntity(peer *entity.Peer) *PeerModel {
 	p.PersistentKeepAlive = int(peer.PersistentKeepaliveInterval) / (1000 * 1000 * 1000)
 	p.IsEnabled = peer.IsEnabled
 	p.AllowedIPs = peer.AllowedIPs
+	p.ExpiresAt = sql.NullTime{
+		Time:  peer.ExpiresAt,
+		Valid: !peer.ExpiresAt.IsZero(),
+	}
+	p.QuotaBytes = peer.QuotaBytes
+	p.QuotaPeriod = peer.QuotaPeriod.String()
+	p.UsageReceiveBytes = peer.Usage.ReceiveBytes
+	p.UsageTransmitBytes = peer.Usage.TransmitBytes
+	p.LastReceiveBytes = peer.Usage.LastReceiveBytes
+	p.LastTransmitBytes = peer.Usage.LastTransmitBytes
+	p.UsagePeriodStart = peer.Usage.PeriodStart
+	p.ClientAllowedIPs = strings.Join(peer.ClientAllowedIPs, ",")
+	p.ExtraRoutes = strings.Join(peer.ExtraRoutes, ",")
+
+	p.PrivateKey = ""
+	p.PresharedKey = ""
+
+	var err error
+
+	if peer.HasPrivateKey {
+		p.PrivateKey, err = keyCipher.Encrypt(peer.PrivateKey.String())
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	if peer.HasPresharedKey {
-		p.PresharedKey = peer.PresharedKey.String()
+		p.PresharedKey, err = keyCipher.Encrypt(peer.PresharedKey.String())
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	return p
+	return p, nil
 }
 
-func (p *PeerModel) ToEntity() (*entity.Peer, error) {
+// ToEntity maps the model to peer, private and preshared keys are decrypted with keyCipher.
+func (p *PeerModel) ToEntity(keyCipher *secrets.Cipher) (*entity.Peer, error) {
 	peer := &entity.Peer{}
 
-	privateKey, err := wgtypes.ParseKey(p.PrivateKey)
+	publicKey, err := wgtypes.ParseKey(p.PublicKey)
 	if err != nil {
 		return peer, err
 	}
-	peer.PrivateKey = privateKey
+	peer.PublicKey = publicKey
+
+	if p.PrivateKey != "" {
+		privateKey, err := parseEncryptedKey(p.PrivateKey, keyCipher)
+		if err != nil {
+			return peer, err
+		}
+		peer.PrivateKey = privateKey
+		peer.HasPrivateKey = true
+	}
 
 	if p.PresharedKey != "" {
-		presharedKey, err := wgtypes.ParseKey(p.PresharedKey)
+		presharedKey, err := parseEncryptedKey(p.PresharedKey, keyCipher)
 		if err != nil {
 			return peer, err
 		}
@@ -65,7 +118,6 @@ func (p *PeerModel) ToEntity() (*entity.Peer, error) {
 		peer.HasPresharedKey = true
 	}
 
-	peer.PublicKey = privateKey.PublicKey()
 	peer.ID = p.ID
 	peer.DeviceID = p.DeviceID
 	peer.Name = p.Name
@@ -77,5 +129,40 @@ func (p *PeerModel) ToEntity() (*entity.Peer, error) {
 	peer.IsEnabled = p.IsEnabled
 	peer.AllowedIPs = p.AllowedIPs
 
+	if p.ExpiresAt.Valid {
+		peer.ExpiresAt = p.ExpiresAt.Time
+	}
+
+	quotaPeriod, err := entity.ParseQuotaPeriod(p.QuotaPeriod)
+	if err != nil {
+		return peer, err
+	}
+	peer.QuotaPeriod = quotaPeriod
+	peer.QuotaBytes = p.QuotaBytes
+	peer.Usage = entity.PeerUsage{
+		ReceiveBytes:      p.UsageReceiveBytes,
+		TransmitBytes:     p.UsageTransmitBytes,
+		LastReceiveBytes:  p.LastReceiveBytes,
+		LastTransmitBytes: p.LastTransmitBytes,
+		PeriodStart:       p.UsagePeriodStart,
+	}
+
+	if p.ClientAllowedIPs != "" {
+		peer.ClientAllowedIPs = strings.Split(p.ClientAllowedIPs, ",")
+	}
+
+	if p.ExtraRoutes != "" {
+		peer.ExtraRoutes = strings.Split(p.ExtraRoutes, ",")
+	}
+
 	return peer, nil
 }
+
+func parseEncryptedKey(value string, keyCipher *secrets.Cipher) (wgtypes.Key, error) {
+	key, err := keyCipher.Decrypt(value)
+	if err != nil {
+		return wgtypes.Key{}, err
+	}
+
+	return wgtypes.ParseKey(key)
+}
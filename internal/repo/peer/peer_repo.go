@@ -4,30 +4,65 @@
 	"context"
 	"database/sql"
 	"fmt"
+	"net/netip"
 	"strings"
+	"time"
 
 	"github.com/AZhur771/wg-grpc-api/internal/entity"
+	"github.com/AZhur771/wg-grpc-api/internal/repo"
+	"github.com/AZhur771/wg-grpc-api/internal/secrets"
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
 )
 
+// addressConstraint keeps addresses unique within a device.
+const addressConstraint = "peer_address_address_device_id_key"
+
 type PeerRepo struct {
-	db *sqlx.DB
+	db        *sqlx.DB
+	keyCipher *secrets.Cipher
 }
 
-func New(db *sqlx.DB) *PeerRepo {
+// New creates repo storing keys encrypted with keyCipher, nil cipher keeps them unencrypted.
+func New(db *sqlx.DB, keyCipher *secrets.Cipher) *PeerRepo {
 	return &PeerRepo{
-		db: db,
+		db:        db,
+		keyCipher: keyCipher,
 	}
 }
 
+// Add stores the peer along with its addresses and routes, nil tx makes it run in its own transaction,
+// so the peer is never stored without them.
 func (p *PeerRepo) Add(ctx context.Context, tx *sqlx.Tx, peer *entity.Peer) (*entity.Peer, error) {
-	model := p.toModel(peer)
+	if tx == nil {
+		tx, err := p.db.BeginTxx(ctx, nil)
+		if err != nil {
+			return nil, fmt.Errorf("peer repo: %w", err)
+		}
+		defer tx.Rollback()
+
+		res, err := p.Add(ctx, tx, peer)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := tx.Commit(); err != nil {
+			return nil, fmt.Errorf("peer repo: %w", err)
+		}
+
+		return res, nil
+	}
+
+	model, err := p.toModel(peer)
+	if err != nil {
+		return nil, fmt.Errorf("peer repo: %w", err)
+	}
 
 	queryPeer := `
 		INSERT INTO peer (
 				device_id,
 				private_key,
+				public_key,
 				preshared_key,
 				"name",
 				description,
@@ -35,11 +70,17 @@ func (p *PeerRepo) Add(ctx context.Context, tx *sqlx.Tx, peer *entity.Peer) (*en
 				persistent_keep_alive,
 				dns,
 				mtu,
-				is_enabled
+				is_enabled,
+				expires_at,
+				quota_bytes,
+				quota_period,
+				usage_period_start,
+				client_allowed_ips
 			)
 		VALUES (
 				:device_id,
 				:private_key,
+				:public_key,
 				:preshared_key,
 				:name,
 				:description,
@@ -47,31 +88,33 @@ func (p *PeerRepo) Add(ctx context.Context, tx *sqlx.Tx, peer *entity.Peer) (*en
 				:persistent_keep_alive,
 				:dns,
 				:mtu,
-				:is_enabled
+				:is_enabled,
+				:expires_at,
+				:quota_bytes,
+				:quota_period,
+				:usage_period_start,
+				:client_allowed_ips
 			)
 		RETURNING *;
 	`
 
-	var rows *sqlx.Rows
-	var err error
-
-	if tx == nil {
-		rows, err = p.db.NamedQueryContext(ctx, queryPeer, model)
-	} else {
-		rows, err = tx.NamedQuery(queryPeer, model)
-	}
-
+	rows, err := tx.NamedQuery(queryPeer, model)
 	if err != nil {
 		return nil, fmt.Errorf("peer repo: %w", err)
 	}
-	defer rows.Close()
 
 	for rows.Next() {
 		if err := rows.StructScan(model); err != nil {
+			rows.Close()
 			return nil, fmt.Errorf("peer repo: %w", err)
 		}
 	}
 
+	// rows hold the connection of the transaction until closed
+	if err := rows.Close(); err != nil {
+		return nil, fmt.Errorf("peer repo: %w", err)
+	}
+
 	type allowedIP struct {
 		PeerID   string `db:"peer_id" sql:",type:uuid"`
 		DeviceID string `db:"device_id" sql:",type:uuid"`
@@ -93,21 +136,48 @@ type allowedIP struct {
 		})
 	}
 
-	if tx == nil {
-		_, err = tx.NamedExecContext(ctx, queryAddr, allowedIPs)
-	} else {
-		_, err = tx.NamedExec(queryAddr, allowedIPs)
+	_, err = tx.NamedExec(queryAddr, allowedIPs)
+	if repo.IsUniqueViolation(err, addressConstraint) {
+		return nil, fmt.Errorf("peer repo: %w", repo.ErrAddressTaken)
 	}
 
 	if err != nil {
 		return nil, fmt.Errorf("peer repo: %w", err)
 	}
 
-	return model.ToEntity()
+	if err := p.replaceRoutes(ctx, tx, model.ID, model.DeviceID, peer.ExtraRoutes); err != nil {
+		return nil, err
+	}
+
+	return model.ToEntity(p.keyCipher)
 }
 
+// Update persists the peer along with its addresses and routes, nil tx makes it run in its own transaction,
+// so they are never left rewritten partially.
 func (p *PeerRepo) Update(ctx context.Context, tx *sqlx.Tx, peer *entity.Peer) (*entity.Peer, error) {
-	model := p.toModel(peer)
+	if tx == nil {
+		tx, err := p.db.BeginTxx(ctx, nil)
+		if err != nil {
+			return nil, fmt.Errorf("peer repo: %w", err)
+		}
+		defer tx.Rollback()
+
+		res, err := p.Update(ctx, tx, peer)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := tx.Commit(); err != nil {
+			return nil, fmt.Errorf("peer repo: %w", err)
+		}
+
+		return res, nil
+	}
+
+	model, err := p.toModel(peer)
+	if err != nil {
+		return nil, fmt.Errorf("peer repo: %w", err)
+	}
 
 	query := `
 		UPDATE peer
@@ -118,31 +188,147 @@ func (p *PeerRepo) Update(ctx context.Context, tx *sqlx.Tx, peer *entity.Peer) (
 			dns = :dns,
 			mtu = :mtu,
 			persistent_keep_alive = :persistent_keep_alive,
-			is_enabled = :is_enabled
+			is_enabled = :is_enabled,
+			expires_at = :expires_at,
+			quota_bytes = :quota_bytes,
+			quota_period = :quota_period,
+			client_allowed_ips = :client_allowed_ips
+		WHERE id = :id
 		RETURNING *;
 	`
 
-	var rows *sqlx.Rows
+	rows, err := tx.NamedQuery(query, model)
+	if err != nil {
+		return nil, fmt.Errorf("peer repo: %w", err)
+	}
+
+	for rows.Next() {
+		if err := rows.StructScan(model); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("peer repo: %w", err)
+		}
+	}
+
+	// rows hold the connection of the transaction until closed
+	if err := rows.Close(); err != nil {
+		return nil, fmt.Errorf("peer repo: %w", err)
+	}
+
+	if err := p.replaceAddresses(ctx, tx, model.ID, model.DeviceID, peer.AllowedIPs); err != nil {
+		return nil, err
+	}
+
+	if err := p.replaceRoutes(ctx, tx, model.ID, model.DeviceID, peer.ExtraRoutes); err != nil {
+		return nil, err
+	}
+
+	return model.ToEntity(p.keyCipher)
+}
+
+// IsAddressTaken reports whether the address is assigned to a peer of the device other than the given one.
+func (p *PeerRepo) IsAddressTaken(ctx context.Context, tx *sqlx.Tx, deviceID, excludePeerID uuid.UUID, addr string) (bool, error) {
+	query := `
+		SELECT EXISTS (
+			SELECT 1 FROM peer_address
+			WHERE device_id = $1
+			AND peer_id <> $2
+			AND host(address) = host($3::inet)
+		);
+	`
+
+	var taken bool
 	var err error
 
 	if tx == nil {
-		rows, err = p.db.NamedQueryContext(ctx, query, model)
+		err = p.db.GetContext(ctx, &taken, query, deviceID.String(), excludePeerID.String(), addr)
 	} else {
-		rows, err = tx.NamedQuery(query, model)
+		err = tx.Get(&taken, query, deviceID.String(), excludePeerID.String(), addr)
+	}
+
+	if err != nil {
+		return false, fmt.Errorf("peer repo: %w", err)
+	}
+
+	return taken, nil
+}
+
+// GetRoutes returns subnets routed behind peers of the device except the given peer.
+func (p *PeerRepo) GetRoutes(ctx context.Context, tx *sqlx.Tx, deviceID, excludePeerID uuid.UUID) ([]string, error) {
+	query := "SELECT route::text FROM peer_route WHERE device_id = $1 AND peer_id <> $2;"
+
+	routes := make([]string, 0)
+
+	var err error
+
+	if tx == nil {
+		err = p.db.SelectContext(ctx, &routes, query, deviceID.String(), excludePeerID.String())
+	} else {
+		err = tx.Select(&routes, query, deviceID.String(), excludePeerID.String())
 	}
 
 	if err != nil {
 		return nil, fmt.Errorf("peer repo: %w", err)
 	}
-	defer rows.Close()
 
-	for rows.Next() {
-		if err := rows.StructScan(model); err != nil {
-			return nil, fmt.Errorf("peer repo: %w", err)
-		}
+	return routes, nil
+}
+
+// UpdateUsage persists only traffic accounting fields of the peer.
+func (p *PeerRepo) UpdateUsage(ctx context.Context, tx *sqlx.Tx, peer *entity.Peer) error {
+	model, err := p.toModel(peer)
+	if err != nil {
+		return fmt.Errorf("peer repo: %w", err)
+	}
+
+	query := `
+		UPDATE peer
+		SET usage_receive_bytes = :usage_receive_bytes,
+			usage_transmit_bytes = :usage_transmit_bytes,
+			last_receive_bytes = :last_receive_bytes,
+			last_transmit_bytes = :last_transmit_bytes,
+			usage_period_start = :usage_period_start
+		WHERE id = :id;
+	`
+
+	if tx == nil {
+		_, err = p.db.NamedExecContext(ctx, query, model)
+	} else {
+		_, err = tx.NamedExec(query, model)
+	}
+
+	if err != nil {
+		return fmt.Errorf("peer repo: %w", err)
 	}
 
-	return model.ToEntity()
+	return nil
+}
+
+// UpdateKeys persists only private, public and preshared keys of the peer.
+func (p *PeerRepo) UpdateKeys(ctx context.Context, tx *sqlx.Tx, peer *entity.Peer) error {
+	model, err := p.toModel(peer)
+	if err != nil {
+		return fmt.Errorf("peer repo: %w", err)
+	}
+
+	query := `
+		UPDATE peer
+		SET private_key = :private_key,
+			public_key = :public_key,
+			preshared_key = :preshared_key
+		WHERE id = :id;
+	`
+
+	if tx == nil {
+		_, err = p.db.NamedExecContext(ctx, query, model)
+	} else {
+		_, err = tx.NamedExec(query, model)
+	}
+
+	if err != nil {
+		return fmt.Errorf("peer repo: %w", err)
+	}
+
+	return nil
 }
 
 func (p *PeerRepo) Remove(ctx context.Context, tx *sqlx.Tx, id uuid.UUID) error {
@@ -170,6 +356,7 @@ func (p *PeerRepo) Get(ctx context.Context, tx *sqlx.Tx, id uuid.UUID) (*entity.
 			SELECT p.id as id,
 					p.device_id as device_id,
 					p.private_key as private_key,
+					p.public_key as public_key,
 					p.preshared_key as preshared_key,
 					p."name" as "name",
 					p.description as description,
@@ -178,6 +365,20 @@ func (p *PeerRepo) Get(ctx context.Context, tx *sqlx.Tx, id uuid.UUID) (*entity.
 					p.is_enabled as is_enabled,
 					p.mtu as mtu,
 					p.dns as dns,
+					p.expires_at as expires_at,
+					p.quota_bytes as quota_bytes,
+					p.quota_period as quota_period,
+					p.usage_receive_bytes as usage_receive_bytes,
+					p.usage_transmit_bytes as usage_transmit_bytes,
+					p.last_receive_bytes as last_receive_bytes,
+					p.last_transmit_bytes as last_transmit_bytes,
+					p.usage_period_start as usage_period_start,
+					p.client_allowed_ips as client_allowed_ips,
+					(
+						SELECT coalesce(string_agg(pr.route::text, ',' ORDER BY pr.route), '')
+						FROM peer_route pr
+						WHERE pr.peer_id = p.id
+					) as extra_routes,
 					pa.address as address
 			FROM peer p
 				JOIN peer_address pa
@@ -198,6 +399,7 @@ func (p *PeerRepo) Get(ctx context.Context, tx *sqlx.Tx, id uuid.UUID) (*entity.
 			&model.ID,
 			&model.DeviceID,
 			&model.PrivateKey,
+			&model.PublicKey,
 			&model.PresharedKey,
 			&model.Name,
 			&model.Description,
@@ -206,6 +408,16 @@ func (p *PeerRepo) Get(ctx context.Context, tx *sqlx.Tx, id uuid.UUID) (*entity.
 			&model.IsEnabled,
 			&model.Mtu,
 			&model.DNS,
+			&model.ExpiresAt,
+			&model.QuotaBytes,
+			&model.QuotaPeriod,
+			&model.UsageReceiveBytes,
+			&model.UsageTransmitBytes,
+			&model.LastReceiveBytes,
+			&model.LastTransmitBytes,
+			&model.UsagePeriodStart,
+			&model.ClientAllowedIPs,
+			&model.ExtraRoutes,
 			&allowedIP,
 		); err != nil {
 			return nil, fmt.Errorf("storage: %w", err)
@@ -214,16 +426,15 @@ func (p *PeerRepo) Get(ctx context.Context, tx *sqlx.Tx, id uuid.UUID) (*entity.
 		model.AllowedIPs = append(model.AllowedIPs, allowedIP)
 	}
 
-	return model.ToEntity()
+	return model.ToEntity(p.keyCipher)
 }
 
 func (p *PeerRepo) GetAll(ctx context.Context, tx *sqlx.Tx, skip, limit int, search string, deviceID uuid.UUID) ([]*entity.Peer, error) {
-	mapper := make(map[uuid.UUID]*PeerModel)
-
 	query := `
 		SELECT t.id as id,
 				t.device_id as device_id,
 				t.private_key as private_key,
+				t.public_key as public_key,
 				t.preshared_key as preshared_key,
 				t."name" as "name",
 				t.description as description,
@@ -232,6 +443,20 @@ func (p *PeerRepo) GetAll(ctx context.Context, tx *sqlx.Tx, skip, limit int, sea
 				t.is_enabled as is_enabled,
 				t.mtu as mtu,
 				t.dns as dns,
+				t.expires_at as expires_at,
+				t.quota_bytes as quota_bytes,
+				t.quota_period as quota_period,
+				t.usage_receive_bytes as usage_receive_bytes,
+				t.usage_transmit_bytes as usage_transmit_bytes,
+				t.last_receive_bytes as last_receive_bytes,
+				t.last_transmit_bytes as last_transmit_bytes,
+				t.usage_period_start as usage_period_start,
+				t.client_allowed_ips as client_allowed_ips,
+				(
+					SELECT coalesce(string_agg(pr.route::text, ',' ORDER BY pr.route), '')
+					FROM peer_route pr
+					WHERE pr.peer_id = t.id
+				) as extra_routes,
 				pa.address as address
 		FROM (
 				SELECT * FROM peer
@@ -297,6 +522,235 @@ func (p *PeerRepo) GetAll(ctx context.Context, tx *sqlx.Tx, skip, limit int, sea
 	}
 	defer rows.Close()
 
+	return p.scanPeers(rows)
+}
+
+// GetExpired returns enabled peers which expiration deadline is not after now.
+func (p *PeerRepo) GetExpired(ctx context.Context, tx *sqlx.Tx, now time.Time) ([]*entity.Peer, error) {
+	query := `
+		SELECT t.id as id,
+				t.device_id as device_id,
+				t.private_key as private_key,
+				t.public_key as public_key,
+				t.preshared_key as preshared_key,
+				t."name" as "name",
+				t.description as description,
+				t.email as email,
+				t.persistent_keep_alive as persistent_keep_alive,
+				t.is_enabled as is_enabled,
+				t.mtu as mtu,
+				t.dns as dns,
+				t.expires_at as expires_at,
+				t.quota_bytes as quota_bytes,
+				t.quota_period as quota_period,
+				t.usage_receive_bytes as usage_receive_bytes,
+				t.usage_transmit_bytes as usage_transmit_bytes,
+				t.last_receive_bytes as last_receive_bytes,
+				t.last_transmit_bytes as last_transmit_bytes,
+				t.usage_period_start as usage_period_start,
+				t.client_allowed_ips as client_allowed_ips,
+				(
+					SELECT coalesce(string_agg(pr.route::text, ',' ORDER BY pr.route), '')
+					FROM peer_route pr
+					WHERE pr.peer_id = t.id
+				) as extra_routes,
+				pa.address as address
+		FROM (
+				SELECT * FROM peer
+				WHERE is_enabled
+				AND expires_at <= $1
+			) t
+		JOIN peer_address pa
+		ON 	 t.id = pa.peer_id;
+	`
+
+	var rows *sqlx.Rows
+	var err error
+
+	if tx == nil {
+		rows, err = p.db.QueryxContext(ctx, query, now)
+	} else {
+		rows, err = tx.Queryx(query, now)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("storage: %w", err)
+	}
+	defer rows.Close()
+
+	return p.scanPeers(rows)
+}
+
+func (p *PeerRepo) Count(ctx context.Context, tx *sqlx.Tx, deviceID uuid.UUID) (int, error) {
+	query := "SELECT count(1) FROM peer ###device_id###;"
+
+	if deviceID != uuid.Nil {
+		query = strings.Replace(query, "###device_id###", "WHERE device_id = :device_id", 1)
+	} else {
+		query = strings.Replace(query, "###device_id###", "", 1)
+	}
+
+	var count int
+	var rows *sqlx.Rows
+	var err error
+
+	args := struct {
+		DeviceID string `db:"device_id" sql:",type:uuid"`
+	}{
+		DeviceID: deviceID.String(),
+	}
+
+	if tx == nil {
+		rows, err = p.db.NamedQueryContext(ctx, query, args)
+	} else {
+		rows, err = tx.NamedQuery(query, args)
+	}
+
+	if err != nil {
+		return count, fmt.Errorf("peer repo: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		if err := rows.Scan(&count); err != nil {
+			return count, fmt.Errorf("peer repo: %w", err)
+		}
+	}
+
+	return count, nil
+}
+
+func (p *PeerRepo) BeginTxx(ctx context.Context, options *sql.TxOptions) (*sqlx.Tx, error) {
+	return p.db.BeginTxx(ctx, options)
+}
+
+// replaceAddresses overwrites addresses assigned to the peer, unchanged addresses are left alone.
+// Address assigned to another peer of the device in the meantime is reported as repo.ErrAddressTaken.
+func (p *PeerRepo) replaceAddresses(ctx context.Context, tx *sqlx.Tx, peerID, deviceID uuid.UUID, addrs []string) error {
+	querySelect := "SELECT address::text FROM peer_address WHERE peer_id = $1;"
+	queryDelete := "DELETE FROM peer_address WHERE peer_id = $1;"
+	queryInsert := "INSERT INTO peer_address (peer_id, device_id, address) VALUES ($1, $2, $3);"
+
+	current := make([]string, 0)
+
+	var err error
+
+	if tx == nil {
+		err = p.db.SelectContext(ctx, &current, querySelect, peerID.String())
+	} else {
+		err = tx.Select(&current, querySelect, peerID.String())
+	}
+
+	if err != nil {
+		return fmt.Errorf("peer repo: %w", err)
+	}
+
+	if samePrefixes(current, addrs) {
+		return nil
+	}
+
+	if tx == nil {
+		_, err = p.db.ExecContext(ctx, queryDelete, peerID.String())
+	} else {
+		_, err = tx.Exec(queryDelete, peerID.String())
+	}
+
+	if err != nil {
+		return fmt.Errorf("peer repo: %w", err)
+	}
+
+	for _, addr := range addrs {
+		if tx == nil {
+			_, err = p.db.ExecContext(ctx, queryInsert, peerID.String(), deviceID.String(), addr)
+		} else {
+			_, err = tx.Exec(queryInsert, peerID.String(), deviceID.String(), addr)
+		}
+
+		if repo.IsUniqueViolation(err, addressConstraint) {
+			return fmt.Errorf("peer repo: %s: %w", addr, repo.ErrAddressTaken)
+		}
+
+		if err != nil {
+			return fmt.Errorf("peer repo: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// replaceRoutes overwrites subnets routed behind the peer, unchanged routes are left alone.
+func (p *PeerRepo) replaceRoutes(ctx context.Context, tx *sqlx.Tx, peerID, deviceID uuid.UUID, routes []string) error {
+	querySelect := "SELECT route::text FROM peer_route WHERE peer_id = $1;"
+	queryDelete := "DELETE FROM peer_route WHERE peer_id = $1;"
+	queryInsert := "INSERT INTO peer_route (peer_id, device_id, route) VALUES ($1, $2, $3);"
+
+	current := make([]string, 0)
+
+	var err error
+
+	if tx == nil {
+		err = p.db.SelectContext(ctx, &current, querySelect, peerID.String())
+	} else {
+		err = tx.Select(&current, querySelect, peerID.String())
+	}
+
+	if err != nil {
+		return fmt.Errorf("peer repo: %w", err)
+	}
+
+	if samePrefixes(current, routes) {
+		return nil
+	}
+
+	if tx == nil {
+		_, err = p.db.ExecContext(ctx, queryDelete, peerID.String())
+	} else {
+		_, err = tx.Exec(queryDelete, peerID.String())
+	}
+
+	if err != nil {
+		return fmt.Errorf("peer repo: %w", err)
+	}
+
+	for _, route := range routes {
+		if tx == nil {
+			_, err = p.db.ExecContext(ctx, queryInsert, peerID.String(), deviceID.String(), route)
+		} else {
+			_, err = tx.Exec(queryInsert, peerID.String(), deviceID.String(), route)
+		}
+
+		if err != nil {
+			return fmt.Errorf("peer repo: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// ReencryptKeys encrypts private and preshared keys of all peers with newCipher,
+// tx is required to rotate keys atomically.
+func (p *PeerRepo) ReencryptKeys(ctx context.Context, tx *sqlx.Tx, newCipher *secrets.Cipher) (int, error) {
+	total := 0
+
+	for _, column := range []string{"private_key", "preshared_key"} {
+		count, err := secrets.ReencryptColumn(ctx, tx.Tx, "peer", column, p.keyCipher, newCipher)
+		if err != nil {
+			return 0, fmt.Errorf("peer repo: %w", err)
+		}
+		total += count
+	}
+
+	return total, nil
+}
+
+func (p *PeerRepo) toModel(peer *entity.Peer) (*PeerModel, error) {
+	return NewModel().FromEntity(peer, p.keyCipher)
+}
+
+// scanPeers groups joined peer/peer_address rows into peers.
+func (p *PeerRepo) scanPeers(rows *sqlx.Rows) ([]*entity.Peer, error) {
+	mapper := make(map[uuid.UUID]*PeerModel)
+
 	for rows.Next() {
 		model := NewModel()
 
@@ -306,6 +760,7 @@ func (p *PeerRepo) GetAll(ctx context.Context, tx *sqlx.Tx, skip, limit int, sea
 			&model.ID,
 			&model.DeviceID,
 			&model.PrivateKey,
+			&model.PublicKey,
 			&model.PresharedKey,
 			&model.Name,
 			&model.Description,
@@ -314,6 +769,16 @@ func (p *PeerRepo) GetAll(ctx context.Context, tx *sqlx.Tx, skip, limit int, sea
 			&model.IsEnabled,
 			&model.Mtu,
 			&model.DNS,
+			&model.ExpiresAt,
+			&model.QuotaBytes,
+			&model.QuotaPeriod,
+			&model.UsageReceiveBytes,
+			&model.UsageTransmitBytes,
+			&model.LastReceiveBytes,
+			&model.LastTransmitBytes,
+			&model.UsagePeriodStart,
+			&model.ClientAllowedIPs,
+			&model.ExtraRoutes,
 			&allowedIP,
 		); err != nil {
 			return nil, fmt.Errorf("storage: %w", err)
@@ -331,9 +796,9 @@ func (p *PeerRepo) GetAll(ctx context.Context, tx *sqlx.Tx, skip, limit int, sea
 	peers := make([]*entity.Peer, 0, len(mapper))
 
 	for _, model := range mapper {
-		peer, err := model.ToEntity()
+		peer, err := model.ToEntity(p.keyCipher)
 		if err != nil {
-			return nil, fmt.Errorf("device repo: %w", err)
+			return nil, fmt.Errorf("peer repo: %w", err)
 		}
 
 		peers = append(peers, peer)
@@ -342,49 +807,32 @@ func (p *PeerRepo) GetAll(ctx context.Context, tx *sqlx.Tx, skip, limit int, sea
 	return peers, nil
 }
 
-func (p *PeerRepo) Count(ctx context.Context, tx *sqlx.Tx, deviceID uuid.UUID) (int, error) {
-	query := "SELECT count(1) FROM peer ###device_id###;"
-
-	if deviceID != uuid.Nil {
-		query = strings.Replace(query, "###device_id###", "WHERE device_id = :device_id", 1)
-	} else {
-		query = strings.Replace(query, "###device_id###", "", 1)
+// samePrefixes reports whether both lists hold the same CIDR prefixes regardless of order and notation.
+func samePrefixes(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
 	}
 
-	var count int
-	var rows *sqlx.Rows
-	var err error
-
-	args := struct {
-		DeviceID string `db:"device_id" sql:",type:uuid"`
-	}{
-		DeviceID: deviceID.String(),
+	prefixes := make(map[string]int, len(a))
+	for _, prefix := range a {
+		prefixes[normalizePrefix(prefix)]++
 	}
 
-	if tx == nil {
-		rows, err = p.db.NamedQueryContext(ctx, query, args)
-	} else {
-		rows, err = tx.NamedQuery(query, args)
-	}
-
-	if err != nil {
-		return count, fmt.Errorf("peer repo: %w", err)
-	}
-	defer rows.Close()
-
-	for rows.Next() {
-		if err := rows.Scan(&count); err != nil {
-			return count, fmt.Errorf("peer repo: %w", err)
+	for _, prefix := range b {
+		key := normalizePrefix(prefix)
+		if prefixes[key] == 0 {
+			return false
 		}
+		prefixes[key]--
 	}
 
-	return count, nil
+	return true
 }
 
-func (p *PeerRepo) BeginTxx(ctx context.Context, options *sql.TxOptions) (*sqlx.Tx, error) {
-	return p.db.BeginTxx(ctx, options)
-}
+func normalizePrefix(prefix string) string {
+	if parsed, err := netip.ParsePrefix(prefix); err == nil {
+		return parsed.String()
+	}
 
-func (p *PeerRepo) toModel(peer *entity.Peer) *PeerModel {
-	return NewModel().FromEntity(peer)
+	return prefix
 }
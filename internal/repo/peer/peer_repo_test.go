@@ -0,0 +1,139 @@
+package peerrepo_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/AZhur771/wg-grpc-api/internal/entity"
+	"github.com/AZhur771/wg-grpc-api/internal/repo"
+	peerrepo "github.com/AZhur771/wg-grpc-api/internal/repo/peer"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jackc/pgconn"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/require"
+	"golang.zx2c4.com/wireguard/wgctrl/wgtypes"
+)
+
+func newPeerRepoTest(t *testing.T) (*peerrepo.PeerRepo, sqlmock.Sqlmock) {
+	t.Helper()
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, mock.ExpectationsWereMet())
+		db.Close()
+	})
+
+	return peerrepo.New(sqlx.NewDb(db, "sqlmock"), nil), mock
+}
+
+func generateTestPeer(t *testing.T) *entity.Peer {
+	t.Helper()
+
+	privateKey, err := wgtypes.GeneratePrivateKey()
+	require.NoError(t, err)
+
+	return &entity.Peer{
+		ID:          uuid.New(),
+		DeviceID:    uuid.New(),
+		Name:        "alice",
+		PublicKey:   privateKey.PublicKey(),
+		AllowedIPs:  []string{"10.6.0.2/24"},
+		ExtraRoutes: []string{"192.168.10.0/24"},
+		IsEnabled:   true,
+	}
+}
+
+func peerRows(peer *entity.Peer) *sqlmock.Rows {
+	return sqlmock.NewRows([]string{"id", "device_id", "public_key", "name", "is_enabled"}).
+		AddRow(peer.ID.String(), peer.DeviceID.String(), peer.PublicKey.String(), peer.Name, peer.IsEnabled)
+}
+
+func TestPeerRepo_UpdateKeepsUnchanged(t *testing.T) {
+	peerRepo, mock := newPeerRepoTest(t)
+	peer := generateTestPeer(t)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("UPDATE peer").WillReturnRows(peerRows(peer))
+	mock.ExpectQuery("SELECT address::text FROM peer_address").
+		WillReturnRows(sqlmock.NewRows([]string{"address"}).AddRow("10.6.0.2/24"))
+	mock.ExpectQuery("SELECT route::text FROM peer_route").
+		WillReturnRows(sqlmock.NewRows([]string{"route"}).AddRow("192.168.10.0/24"))
+	mock.ExpectCommit()
+
+	res, err := peerRepo.Update(context.Background(), nil, peer)
+	require.NoError(t, err)
+	require.Equal(t, peer.ID, res.ID)
+}
+
+func TestPeerRepo_UpdateRollsBack(t *testing.T) {
+	peerRepo, mock := newPeerRepoTest(t)
+	peer := generateTestPeer(t)
+	errInsert := errors.New("insert failed")
+
+	// peer is never left without addresses
+	mock.ExpectBegin()
+	mock.ExpectQuery("UPDATE peer").WillReturnRows(peerRows(peer))
+	mock.ExpectQuery("SELECT address::text FROM peer_address").WillReturnRows(sqlmock.NewRows([]string{"address"}))
+	mock.ExpectExec("DELETE FROM peer_address").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO peer_address").WillReturnError(errInsert)
+	mock.ExpectRollback()
+
+	_, err := peerRepo.Update(context.Background(), nil, peer)
+	require.ErrorIs(t, err, errInsert)
+}
+
+func TestPeerRepo_UpdateAddressTaken(t *testing.T) {
+	peerRepo, mock := newPeerRepoTest(t)
+	peer := generateTestPeer(t)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("UPDATE peer").WillReturnRows(peerRows(peer))
+	mock.ExpectQuery("SELECT address::text FROM peer_address").
+		WillReturnRows(sqlmock.NewRows([]string{"address"}).AddRow("10.6.0.3/24"))
+	mock.ExpectExec("DELETE FROM peer_address").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO peer_address").WillReturnError(&pgconn.PgError{
+		Code:           "23505",
+		ConstraintName: "peer_address_address_device_id_key",
+	})
+	mock.ExpectRollback()
+
+	_, err := peerRepo.Update(context.Background(), nil, peer)
+	require.ErrorIs(t, err, repo.ErrAddressTaken)
+}
+
+func TestPeerRepo_AddOwnTransaction(t *testing.T) {
+	peerRepo, mock := newPeerRepoTest(t)
+	peer := generateTestPeer(t)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT INTO peer").WillReturnRows(peerRows(peer))
+	mock.ExpectExec("INSERT INTO peer_address").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery("SELECT route::text FROM peer_route").WillReturnRows(sqlmock.NewRows([]string{"route"}))
+	mock.ExpectExec("DELETE FROM peer_route").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("INSERT INTO peer_route").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	res, err := peerRepo.Add(context.Background(), nil, peer)
+	require.NoError(t, err)
+	require.Equal(t, peer.ID, res.ID)
+}
+
+func TestPeerRepo_AddAddressTaken(t *testing.T) {
+	peerRepo, mock := newPeerRepoTest(t)
+	peer := generateTestPeer(t)
+
+	// peer is not stored without its addresses
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT INTO peer").WillReturnRows(peerRows(peer))
+	mock.ExpectExec("INSERT INTO peer_address").WillReturnError(&pgconn.PgError{
+		Code:           "23505",
+		ConstraintName: "peer_address_address_device_id_key",
+	})
+	mock.ExpectRollback()
+
+	_, err := peerRepo.Add(context.Background(), nil, peer)
+	require.ErrorIs(t, err, repo.ErrAddressTaken)
+}
@@ -0,0 +1,47 @@
+// Package role defines roles of API clients, it is shared by entities and auth without importing either.
+package role
+
+import "fmt"
+
+// Role grants access to API methods, every next role includes permissions of the previous ones.
+type Role int
+
+const (
+	// ReadOnly may only read devices and peers.
+	ReadOnly Role = iota
+	// PeerOperator may manage peers, enable/disable them and download their configs.
+	PeerOperator
+	// Admin has full access.
+	Admin
+)
+
+func (r Role) String() string {
+	switch r {
+	case ReadOnly:
+		return "read-only"
+	case PeerOperator:
+		return "peer-operator"
+	case Admin:
+		return "admin"
+	default:
+		return "unknown"
+	}
+}
+
+func Parse(s string) (Role, error) {
+	switch s {
+	case "read-only":
+		return ReadOnly, nil
+	case "peer-operator":
+		return PeerOperator, nil
+	case "admin":
+		return Admin, nil
+	default:
+		return ReadOnly, fmt.Errorf("unknown role: %s", s)
+	}
+}
+
+// Allows reports whether the role grants access to methods which require the given one.
+func (r Role) Allows(required Role) bool {
+	return r >= required
+}
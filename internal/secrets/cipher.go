@@ -0,0 +1,190 @@
+package secrets
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+const (
+	// MasterKeySize is the size of AES-256 master key.
+	MasterKeySize = 32
+
+	dataKeySize   = 32
+	encodedPrefix = "enc:v1:"
+)
+
+var (
+	ErrInvalidMasterKey = errors.New("master key must be 32 bytes encoded in base64")
+	ErrUnknownMasterKey = errors.New("value is encrypted with another master key")
+	ErrMalformedValue   = errors.New("malformed encrypted value")
+)
+
+// Cipher implements envelope encryption: every value is encrypted with its own random data key
+// which is in turn encrypted with the master key and stored next to the value.
+// Nil cipher stores values as is, so encryption stays optional.
+type Cipher struct {
+	keyID  string
+	master cipher.AEAD
+}
+
+func NewCipher(masterKey []byte) (*Cipher, error) {
+	if len(masterKey) != MasterKeySize {
+		return nil, ErrInvalidMasterKey
+	}
+
+	master, err := newAEAD(masterKey)
+	if err != nil {
+		return nil, err
+	}
+
+	sum := sha256.Sum256(masterKey)
+
+	return &Cipher{
+		keyID:  hex.EncodeToString(sum[:4]),
+		master: master,
+	}, nil
+}
+
+// LoadCipher creates cipher from base64 encoded master key given either directly or in a file,
+// nil cipher is returned when neither is given.
+func LoadCipher(masterKey, masterKeyFile string) (*Cipher, error) {
+	if masterKeyFile != "" {
+		data, err := os.ReadFile(masterKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("master key: %w", err)
+		}
+		masterKey = string(data)
+	}
+
+	if masterKey == "" {
+		return nil, nil
+	}
+
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(masterKey))
+	if err != nil {
+		return nil, ErrInvalidMasterKey
+	}
+
+	return NewCipher(key)
+}
+
+// IsEncrypted reports whether value was produced by Encrypt.
+func IsEncrypted(value string) bool {
+	return strings.HasPrefix(value, encodedPrefix)
+}
+
+// Encrypt returns "enc:v1:<master key id>:<encrypted data key>:<encrypted value>".
+func (c *Cipher) Encrypt(plaintext string) (string, error) {
+	if c == nil || plaintext == "" {
+		return plaintext, nil
+	}
+
+	dataKey := make([]byte, dataKeySize)
+	if _, err := rand.Read(dataKey); err != nil {
+		return "", err
+	}
+
+	data, err := newAEAD(dataKey)
+	if err != nil {
+		return "", err
+	}
+
+	wrappedKey, err := seal(c.master, dataKey)
+	if err != nil {
+		return "", err
+	}
+
+	ciphertext, err := seal(data, []byte(plaintext))
+	if err != nil {
+		return "", err
+	}
+
+	return encodedPrefix + strings.Join([]string{
+		c.keyID,
+		base64.RawStdEncoding.EncodeToString(wrappedKey),
+		base64.RawStdEncoding.EncodeToString(ciphertext),
+	}, ":"), nil
+}
+
+// Decrypt returns plaintext value, values which are not encrypted are returned as is.
+func (c *Cipher) Decrypt(value string) (string, error) {
+	if !IsEncrypted(value) {
+		return value, nil
+	}
+
+	parts := strings.Split(strings.TrimPrefix(value, encodedPrefix), ":")
+	if len(parts) != 3 {
+		return "", ErrMalformedValue
+	}
+
+	if c == nil || parts[0] != c.keyID {
+		return "", ErrUnknownMasterKey
+	}
+
+	wrappedKey, err := base64.RawStdEncoding.DecodeString(parts[1])
+	if err != nil {
+		return "", ErrMalformedValue
+	}
+
+	ciphertext, err := base64.RawStdEncoding.DecodeString(parts[2])
+	if err != nil {
+		return "", ErrMalformedValue
+	}
+
+	dataKey, err := open(c.master, wrappedKey)
+	if err != nil {
+		return "", err
+	}
+
+	data, err := newAEAD(dataKey)
+	if err != nil {
+		return "", err
+	}
+
+	plaintext, err := open(data, ciphertext)
+	if err != nil {
+		return "", err
+	}
+
+	return string(plaintext), nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// seal returns nonce followed by the ciphertext.
+func seal(aead cipher.AEAD, plaintext []byte) ([]byte, error) {
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	return aead.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+func open(aead cipher.AEAD, sealed []byte) ([]byte, error) {
+	if len(sealed) < aead.NonceSize() {
+		return nil, ErrMalformedValue
+	}
+
+	plaintext, err := aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrMalformedValue, err)
+	}
+
+	return plaintext, nil
+}
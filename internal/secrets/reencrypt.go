@@ -0,0 +1,59 @@
+package secrets
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// ReencryptColumn decrypts every non-empty value of the column with from cipher and encrypts it with to cipher,
+// nil from cipher expects unencrypted values and nil to cipher leaves values unencrypted.
+// Table and column are never user input. Number of updated rows is returned.
+func ReencryptColumn(ctx context.Context, tx *sql.Tx, table, column string, from, to *Cipher) (int, error) {
+	//nolint:gosec
+	rows, err := tx.QueryContext(ctx, fmt.Sprintf(
+		"SELECT id, %[2]s FROM %[1]s WHERE %[2]s IS NOT NULL AND %[2]s <> '' FOR UPDATE;", table, column,
+	))
+	if err != nil {
+		return 0, err
+	}
+
+	values := make(map[string]string)
+
+	for rows.Next() {
+		var id, value string
+
+		if err := rows.Scan(&id, &value); err != nil {
+			rows.Close()
+			return 0, err
+		}
+
+		values[id] = value
+	}
+	rows.Close()
+
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+
+	//nolint:gosec
+	query := fmt.Sprintf("UPDATE %s SET %s = $2 WHERE id = $1;", table, column)
+
+	for id, value := range values {
+		plaintext, err := from.Decrypt(value)
+		if err != nil {
+			return 0, fmt.Errorf("%s.%s of %s: %w", table, column, id, err)
+		}
+
+		encrypted, err := to.Encrypt(plaintext)
+		if err != nil {
+			return 0, err
+		}
+
+		if _, err := tx.ExecContext(ctx, query, id, encrypted); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(values), nil
+}
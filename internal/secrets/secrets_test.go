@@ -0,0 +1,71 @@
+package secrets_test
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"testing"
+
+	"github.com/AZhur771/wg-grpc-api/internal/secrets"
+	"github.com/stretchr/testify/require"
+)
+
+var privateKeyMock = "WDhbZ4+4sE8LmIu4tSA1AXINX1ly+d+ZUwzazdiRMFU="
+
+func TestCipher(t *testing.T) {
+	keyCipher, err := secrets.NewCipher(bytes.Repeat([]byte{1}, secrets.MasterKeySize))
+	require.NoError(t, err)
+
+	encrypted, err := keyCipher.Encrypt(privateKeyMock)
+	require.NoError(t, err)
+	require.True(t, secrets.IsEncrypted(encrypted))
+	require.NotContains(t, encrypted, privateKeyMock)
+
+	// every value gets its own data key
+	another, err := keyCipher.Encrypt(privateKeyMock)
+	require.NoError(t, err)
+	require.NotEqual(t, encrypted, another)
+
+	decrypted, err := keyCipher.Decrypt(encrypted)
+	require.NoError(t, err)
+	require.Equal(t, privateKeyMock, decrypted)
+
+	// values stored before encryption was enabled are read as is
+	decrypted, err = keyCipher.Decrypt(privateKeyMock)
+	require.NoError(t, err)
+	require.Equal(t, privateKeyMock, decrypted)
+
+	otherCipher, err := secrets.LoadCipher(base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{2}, 32)), "")
+	require.NoError(t, err)
+
+	_, err = otherCipher.Decrypt(encrypted)
+	require.True(t, errors.Is(err, secrets.ErrUnknownMasterKey))
+
+	var noCipher *secrets.Cipher
+
+	plaintext, err := noCipher.Encrypt(privateKeyMock)
+	require.NoError(t, err)
+	require.Equal(t, privateKeyMock, plaintext)
+
+	_, err = noCipher.Decrypt(encrypted)
+	require.True(t, errors.Is(err, secrets.ErrUnknownMasterKey))
+
+	tampered := encrypted[:len(encrypted)-2] + "AA"
+	if tampered == encrypted {
+		tampered = encrypted[:len(encrypted)-2] + "BB"
+	}
+	_, err = keyCipher.Decrypt(tampered)
+	require.True(t, errors.Is(err, secrets.ErrMalformedValue))
+}
+
+func TestLoadCipher(t *testing.T) {
+	keyCipher, err := secrets.LoadCipher("", "")
+	require.NoError(t, err)
+	require.Nil(t, keyCipher)
+
+	_, err = secrets.LoadCipher(base64.StdEncoding.EncodeToString([]byte("too short")), "")
+	require.True(t, errors.Is(err, secrets.ErrInvalidMasterKey))
+
+	_, err = secrets.LoadCipher("not base64!", "")
+	require.True(t, errors.Is(err, secrets.ErrInvalidMasterKey))
+}
@@ -0,0 +1,120 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	wgpb "github.com/AZhur771/wg-grpc-api/gen"
+	"github.com/AZhur771/wg-grpc-api/internal/app"
+	"github.com/AZhur771/wg-grpc-api/internal/auth"
+	"github.com/AZhur771/wg-grpc-api/internal/dto"
+	"github.com/AZhur771/wg-grpc-api/internal/service/common"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type APIKeysImpl struct {
+	Ctx     context.Context
+	Logger  *zap.Logger
+	Service app.APIKeyService
+
+	wgpb.UnimplementedApiKeyServiceServer
+}
+
+func NewAPIKeysImpl(ctx context.Context, logger *zap.Logger, service app.APIKeyService) *APIKeysImpl {
+	return &APIKeysImpl{
+		Ctx:     ctx,
+		Logger:  logger,
+		Service: service,
+	}
+}
+
+func (a *APIKeysImpl) Create(ctx context.Context, req *wgpb.CreateApiKeyRequest) (*wgpb.CreateApiKeyResponse, error) {
+	deviceIDs := make([]uuid.UUID, 0, len(req.GetDeviceIds()))
+
+	for _, id := range req.GetDeviceIds() {
+		deviceID, err := uuid.Parse(id)
+		if err != nil {
+			return nil, err
+		}
+		deviceIDs = append(deviceIDs, deviceID)
+	}
+
+	key, rawKey, err := a.Service.Create(ctx, dto.CreateAPIKeyDTO{
+		Name:      req.GetName(),
+		Role:      auth.Role(req.GetRole()),
+		DeviceIDs: deviceIDs,
+	})
+
+	errInvalidData := &common.ErrInvalidData{}
+
+	if errors.As(err, errInvalidData) {
+		st := status.New(codes.InvalidArgument, err.Error())
+		st, err = st.WithDetails(errInvalidData.Details())
+		if err != nil {
+			return nil, err
+		}
+		return nil, st.Err()
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &wgpb.CreateApiKeyResponse{
+		ApiKey: mapEntityAPIKeyToPbAPIKey(key),
+		Key:    rawKey,
+	}, nil
+}
+
+func (a *APIKeysImpl) GetAll(ctx context.Context, req *wgpb.GetApiKeysRequest) (*wgpb.GetApiKeysResponse, error) {
+	resp, err := a.Service.GetAll(ctx, dto.GetAPIKeysRequestDTO{
+		Skip:  int(req.GetSkip()),
+		Limit: int(req.GetLimit()),
+	})
+
+	errInvalidData := &common.ErrInvalidData{}
+
+	if errors.As(err, errInvalidData) {
+		st := status.New(codes.InvalidArgument, err.Error())
+		st, err = st.WithDetails(errInvalidData.Details())
+		if err != nil {
+			return nil, err
+		}
+		return nil, st.Err()
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	keyspb := make([]*wgpb.ApiKey, 0, len(resp.APIKeys))
+	for _, key := range resp.APIKeys {
+		keyspb = append(keyspb, mapEntityAPIKeyToPbAPIKey(key))
+	}
+
+	return &wgpb.GetApiKeysResponse{
+		ApiKeys: keyspb,
+		Total:   int32(resp.Total),
+		HasNext: resp.HasNext,
+	}, nil
+}
+
+func (a *APIKeysImpl) Revoke(ctx context.Context, req *wgpb.EntityIdRequest) (*empty.Empty, error) {
+	id, err := uuid.Parse(req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := a.Service.Revoke(ctx, id); errors.Is(err, sql.ErrNoRows) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	} else if err != nil {
+		return nil, err
+	}
+
+	return &empty.Empty{}, nil
+}
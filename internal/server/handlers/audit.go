@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+
+	wgpb "github.com/AZhur771/wg-grpc-api/gen"
+	"github.com/AZhur771/wg-grpc-api/internal/app"
+	"github.com/AZhur771/wg-grpc-api/internal/dto"
+	"github.com/AZhur771/wg-grpc-api/internal/entity"
+	"github.com/AZhur771/wg-grpc-api/internal/service/common"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type AuditImpl struct {
+	Ctx     context.Context
+	Logger  *zap.Logger
+	Service app.AuditService
+
+	wgpb.UnimplementedAuditServiceServer
+}
+
+func NewAuditImpl(ctx context.Context, logger *zap.Logger, service app.AuditService) *AuditImpl {
+	return &AuditImpl{
+		Ctx:     ctx,
+		Logger:  logger,
+		Service: service,
+	}
+}
+
+func (a *AuditImpl) List(ctx context.Context, req *wgpb.ListAuditRecordsRequest) (*wgpb.ListAuditRecordsResponse, error) {
+	var entityID uuid.UUID
+
+	if req.GetEntityId() != "" {
+		id, err := uuid.Parse(req.GetEntityId())
+		if err != nil {
+			return nil, err
+		}
+		entityID = id
+	}
+
+	resp, err := a.Service.List(ctx, dto.GetAuditRecordsRequestDTO{
+		Skip:  int(req.GetSkip()),
+		Limit: int(req.GetLimit()),
+		Filter: entity.AuditFilter{
+			Actor:      req.GetActor(),
+			EntityType: req.GetEntityType(),
+			EntityID:   entityID,
+			From:       mapPbTimestampToTime(req.GetFrom()),
+			To:         mapPbTimestampToTime(req.GetTo()),
+		},
+	})
+
+	errInvalidData := &common.ErrInvalidData{}
+
+	if errors.As(err, errInvalidData) {
+		st := status.New(codes.InvalidArgument, err.Error())
+		st, err = st.WithDetails(errInvalidData.Details())
+		if err != nil {
+			return nil, err
+		}
+		return nil, st.Err()
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	recordspb := make([]*wgpb.AuditRecord, 0, len(resp.Records))
+	for _, record := range resp.Records {
+		recordspb = append(recordspb, mapEntityAuditRecordToPbAuditRecord(record))
+	}
+
+	return &wgpb.ListAuditRecordsResponse{
+		Records: recordspb,
+		Total:   int32(resp.Total),
+		HasNext: resp.HasNext,
+	}, nil
+}
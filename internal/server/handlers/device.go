@@ -10,6 +10,7 @@
 	"github.com/AZhur771/wg-grpc-api/internal/app"
 	"github.com/AZhur771/wg-grpc-api/internal/dto"
 	"github.com/AZhur771/wg-grpc-api/internal/service/common"
+	deviceservice "github.com/AZhur771/wg-grpc-api/internal/service/device"
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/google/uuid"
 	fieldmask_utils "github.com/mennanov/fieldmask-utils"
@@ -42,7 +43,10 @@ func (d *DeviceImpl) Add(ctx context.Context, req *wgpb.AddDeviceRequest) (*wgpb
 			Endpoint:            req.GetEndpoint(),
 			FirewallMark:        int(req.GetFirewallMark()),
 			Address:             req.GetAddress(),
+			Address6:            req.GetAddress6(),
+			ClientAllowedIPs:    req.GetClientAllowedIps(),
 			Table:               req.GetTable(),
+			Netns:               req.GetNetns(),
 			MTU:                 int(req.GetMtu()),
 			DNS:                 req.GetDns(),
 			PersistentKeepAlive: time.Duration(req.GetPersistentKeepAlive()) * time.Second,
@@ -97,7 +101,10 @@ func (d *DeviceImpl) Update(ctx context.Context, req *wgpb.UpdateDeviceRequest)
 			Endpoint:            device.GetEndpoint(),
 			FirewallMark:        int(device.GetFirewallMark()),
 			Address:             device.GetAddress(),
+			Address6:            device.GetAddress6(),
+			ClientAllowedIPs:    device.GetClientAllowedIps(),
 			Table:               device.GetTable(),
+			Netns:               device.GetNetns(),
 			MTU:                 int(device.GetMtu()),
 			DNS:                 device.GetDns(),
 			PersistentKeepAlive: time.Duration(device.GetPersistentKeepAlive()) * time.Second,
@@ -164,6 +171,98 @@ func (d *DeviceImpl) Get(ctx context.Context, req *wgpb.EntityIdRequest) (*wgpb.
 	return mapEntityDeviceToPbDeivce(device), nil
 }
 
+func (d *DeviceImpl) ImportDevice(ctx context.Context, req *wgpb.ImportDeviceRequest) (*wgpb.ImportDeviceResponse, error) {
+	resp, err := d.Service.Import(ctx, dto.ImportDeviceDTO{
+		Name:        req.GetName(),
+		Config:      req.GetConfig(),
+		Endpoint:    req.GetEndpoint(),
+		Description: req.GetDescription(),
+	})
+
+	errInvalidData := &common.ErrInvalidData{}
+
+	if errors.As(err, errInvalidData) {
+		st := status.New(codes.InvalidArgument, err.Error())
+		st, err = st.WithDetails(errInvalidData.Details())
+		if err != nil {
+			return nil, err
+		}
+		return nil, st.Err()
+	}
+
+	if errors.Is(err, deviceservice.ErrDeviceExists) {
+		return nil, status.Error(codes.AlreadyExists, err.Error())
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	peerIDs := make([]string, 0, len(resp.Peers))
+	for _, peer := range resp.Peers {
+		peerIDs = append(peerIDs, peer.ID.String())
+	}
+
+	return &wgpb.ImportDeviceResponse{
+		Device:  mapEntityDeviceToPbDeivce(resp.Device),
+		PeerIds: peerIDs,
+	}, nil
+}
+
+func (d *DeviceImpl) GetReconcileReport(ctx context.Context, req *emptypb.Empty) (*wgpb.ReconcileReport, error) {
+	report, err := d.Service.LastReconcileReport()
+	if errors.Is(err, deviceservice.ErrNoReconcileReport) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return mapEntityReconcileReportToPb(report), nil
+}
+
+func (d *DeviceImpl) RotateKey(ctx context.Context, req *wgpb.RotateDeviceKeyRequest) (*wgpb.RotateDeviceKeyResponse, error) {
+	id, err := uuid.Parse(req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := d.Service.RotateKey(ctx, dto.RotateDeviceKeyDTO{
+		ID:        id,
+		CutoverAt: mapPbTimestampToTime(req.GetCutoverAt()),
+	})
+
+	errInvalidData := &common.ErrInvalidData{}
+
+	if errors.As(err, errInvalidData) {
+		st := status.New(codes.InvalidArgument, err.Error())
+		st, err = st.WithDetails(errInvalidData.Details())
+		if err != nil {
+			return nil, err
+		}
+		return nil, st.Err()
+	}
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	peerIDs := make([]string, 0, len(resp.Peers))
+	for _, peer := range resp.Peers {
+		peerIDs = append(peerIDs, peer.ID.String())
+	}
+
+	return &wgpb.RotateDeviceKeyResponse{
+		Device:  mapEntityDeviceToPbDeivce(resp.Device),
+		PeerIds: peerIDs,
+	}, nil
+}
+
 func (d *DeviceImpl) GetAll(ctx context.Context, req *wgpb.GetDevicesRequest) (*wgpb.GetDevicesResponse, error) {
 	resp, err := d.Service.GetAll(ctx, dto.GetDevicesRequestDTO{
 		Skip:   int(req.GetSkip()),
@@ -4,12 +4,16 @@
 	"context"
 	"database/sql"
 	"errors"
+	"io"
+	"strings"
 	"time"
 
 	wgpb "github.com/AZhur771/wg-grpc-api/gen"
 	"github.com/AZhur771/wg-grpc-api/internal/app"
 	"github.com/AZhur771/wg-grpc-api/internal/dto"
+	"github.com/AZhur771/wg-grpc-api/internal/entity"
 	"github.com/AZhur771/wg-grpc-api/internal/service/common"
+	peerservice "github.com/AZhur771/wg-grpc-api/internal/service/peer"
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/google/uuid"
 	fieldmask_utils "github.com/mennanov/fieldmask-utils"
@@ -18,6 +22,8 @@
 	"google.golang.org/grpc/status"
 )
 
+const defaultWatchInterval = 2 * time.Second
+
 type PeersImpl struct {
 	Ctx     context.Context
 	Logger  *zap.Logger
@@ -45,11 +51,18 @@ func (p *PeersImpl) Add(ctx context.Context, req *wgpb.AddPeerRequest) (*wgpb.En
 			DeviceID:            deviceID,
 			Name:                req.GetName(),
 			Email:               req.GetEmail(),
+			PublicKey:           req.GetPublicKey(),
 			Description:         req.GetDescription(),
 			AddPresharedKey:     req.GetAddPresharedKey(),
 			PersistentKeepAlive: time.Duration(req.GetPersistentKeepAlive()) * time.Second,
 			MTU:                 int(req.GetMtu()),
 			DNS:                 req.GetDns(),
+			ExpiresAt:           mapPbTimestampToTime(req.GetExpiresAt()),
+			QuotaBytes:          req.GetQuotaBytes(),
+			QuotaPeriod:         entity.QuotaPeriod(req.GetQuotaPeriod()),
+			ClientAllowedIPs:    req.GetClientAllowedIps(),
+			ExtraRoutes:         req.GetExtraRoutes(),
+			Addresses:           req.GetAddresses(),
 		},
 	)
 
@@ -68,6 +81,10 @@ func (p *PeersImpl) Add(ctx context.Context, req *wgpb.AddPeerRequest) (*wgpb.En
 		return nil, status.Error(codes.NotFound, err.Error())
 	}
 
+	if errors.Is(err, peerservice.ErrAddressTaken) {
+		return nil, status.Error(codes.AlreadyExists, err.Error())
+	}
+
 	if err != nil {
 		return nil, err
 	}
@@ -100,6 +117,12 @@ func (p *PeersImpl) Update(ctx context.Context, req *wgpb.UpdatePeerRequest) (*e
 			DNS:                 peer.GetDns(),
 			MTU:                 int(peer.GetMtu()),
 			PersistentKeepAlive: time.Duration(peer.GetPersistentKeepAlive()) * time.Second,
+			ExpiresAt:           mapPbTimestampToTime(peer.GetExpiresAt()),
+			QuotaBytes:          peer.GetQuotaBytes(),
+			QuotaPeriod:         entity.QuotaPeriod(peer.GetQuotaPeriod()),
+			ClientAllowedIPs:    peer.GetClientAllowedIps(),
+			ExtraRoutes:         peer.GetExtraRoutes(),
+			Addresses:           peer.GetAddresses(),
 		},
 		fmask,
 	)
@@ -119,6 +142,10 @@ func (p *PeersImpl) Update(ctx context.Context, req *wgpb.UpdatePeerRequest) (*e
 		return nil, status.Error(codes.NotFound, err.Error())
 	}
 
+	if errors.Is(err, peerservice.ErrAddressTaken) {
+		return nil, status.Error(codes.AlreadyExists, err.Error())
+	}
+
 	if err != nil {
 		return nil, err
 	}
@@ -218,10 +245,15 @@ func (p *PeersImpl) Enable(ctx context.Context, req *wgpb.EntityIdRequest) (*emp
 		return nil, err
 	}
 
-	if err := p.Service.Enable(ctx, id); errors.Is(err, sql.ErrNoRows) {
+	err = p.Service.Enable(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
 		return nil, status.Error(codes.NotFound, err.Error())
 	}
 
+	if errors.Is(err, peerservice.ErrPeerExpired) || errors.Is(err, peerservice.ErrQuotaExceeded) {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+
 	if err != nil {
 		return nil, err
 	}
@@ -279,6 +311,10 @@ func (p *PeersImpl) DownloadQRCode(ctx context.Context, req *wgpb.EntityIdReques
 		return nil, status.Error(codes.NotFound, err.Error())
 	}
 
+	if errors.Is(err, peerservice.ErrPrivateKeyUnknown) {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+
 	if err != nil {
 		return nil, err
 	}
@@ -289,3 +325,217 @@ func (p *PeersImpl) DownloadQRCode(ctx context.Context, req *wgpb.EntityIdReques
 		Data: qr.Data,
 	}, nil
 }
+
+func (p *PeersImpl) ResetQuota(ctx context.Context, req *wgpb.EntityIdRequest) (*empty.Empty, error) {
+	id, err := uuid.Parse(req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	err = p.Service.ResetQuota(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &empty.Empty{}, nil
+}
+
+func (p *PeersImpl) RotateKeys(ctx context.Context, req *wgpb.RotatePeerKeysRequest) (*wgpb.Peer, error) {
+	id, err := uuid.Parse(req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	peer, err := p.Service.RotateKeys(ctx, dto.RotatePeerKeysDTO{
+		ID:                 id,
+		PublicKey:          req.GetPublicKey(),
+		RotatePresharedKey: req.GetRotatePresharedKey(),
+	})
+
+	errInvalidData := &common.ErrInvalidData{}
+
+	if errors.As(err, errInvalidData) {
+		st := status.New(codes.InvalidArgument, err.Error())
+		st, err = st.WithDetails(errInvalidData.Details())
+		if err != nil {
+			return nil, err
+		}
+		return nil, st.Err()
+	}
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return mapEntityPeerToPbPeer(peer), nil
+}
+
+func (p *PeersImpl) BulkAdd(ctx context.Context, req *wgpb.BulkAddPeersRequest) (*wgpb.BulkAddPeersResponse, error) {
+	entries, err := bulkPeerEntries(req)
+	if err != nil {
+		return nil, mapBulkAddError(err)
+	}
+
+	return p.bulkAdd(ctx, req.GetDeviceId(), req.GetAddPresharedKey(), entries)
+}
+
+func (p *PeersImpl) BulkAddStream(stream wgpb.PeerService_BulkAddStreamServer) error {
+	var first *wgpb.BulkAddPeersRequest
+
+	entries := make([]dto.BulkPeerEntryDTO, 0)
+
+	for {
+		req, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if first == nil {
+			first = req
+		} else if req.GetDeviceId() != first.GetDeviceId() {
+			return status.Error(codes.InvalidArgument, "all messages should refer to the same device")
+		}
+
+		reqEntries, err := bulkPeerEntries(req)
+		if err != nil {
+			return mapBulkAddError(err)
+		}
+
+		// stop reading early instead of buffering an oversized import
+		if entries = append(entries, reqEntries...); len(entries) > dto.MaxBulkPeers {
+			return status.Errorf(codes.InvalidArgument, "peers should be %d entries max", dto.MaxBulkPeers)
+		}
+	}
+
+	if first == nil {
+		return status.Error(codes.InvalidArgument, "at least one message is expected")
+	}
+
+	resp, err := p.bulkAdd(stream.Context(), first.GetDeviceId(), first.GetAddPresharedKey(), entries)
+	if err != nil {
+		return err
+	}
+
+	return stream.SendAndClose(resp)
+}
+
+func (p *PeersImpl) bulkAdd(
+	ctx context.Context, deviceID string, addPresharedKey bool, entries []dto.BulkPeerEntryDTO,
+) (*wgpb.BulkAddPeersResponse, error) {
+	id, err := uuid.Parse(deviceID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := p.Service.BulkAdd(ctx, dto.BulkAddPeersDTO{
+		DeviceID:        id,
+		AddPresharedKey: addPresharedKey,
+		Peers:           entries,
+	})
+	if err != nil {
+		return nil, mapBulkAddError(err)
+	}
+
+	results := make([]*wgpb.BulkAddPeerResult, 0, len(resp.Results))
+	for _, result := range resp.Results {
+		results = append(results, mapBulkAddPeerResultToPb(result))
+	}
+
+	return &wgpb.BulkAddPeersResponse{
+		Results: results,
+		Added:   int32(resp.Added),
+	}, nil
+}
+
+// bulkPeerEntries merges listed entries with entries parsed from csv.
+func bulkPeerEntries(req *wgpb.BulkAddPeersRequest) ([]dto.BulkPeerEntryDTO, error) {
+	entries := make([]dto.BulkPeerEntryDTO, 0, len(req.GetPeers()))
+
+	for _, entry := range req.GetPeers() {
+		entries = append(entries, dto.BulkPeerEntryDTO{
+			Name:        entry.GetName(),
+			Email:       entry.GetEmail(),
+			Description: entry.GetDescription(),
+		})
+	}
+
+	if req.GetCsv() == "" {
+		return entries, nil
+	}
+
+	csvEntries, violations := dto.ParseBulkPeerEntriesCSV(strings.NewReader(req.GetCsv()))
+	if len(violations) > 0 {
+		return nil, common.NewErrInvalidData(errors.New("invalid csv"), violations)
+	}
+
+	return append(entries, csvEntries...), nil
+}
+
+func mapBulkAddError(err error) error {
+	errInvalidData := &common.ErrInvalidData{}
+
+	if errors.As(err, errInvalidData) {
+		st := status.New(codes.InvalidArgument, err.Error())
+		st, err = st.WithDetails(errInvalidData.Details())
+		if err != nil {
+			return err
+		}
+		return st.Err()
+	}
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return status.Error(codes.NotFound, err.Error())
+	}
+
+	return err
+}
+
+func (p *PeersImpl) Watch(req *wgpb.WatchPeerRequest, stream wgpb.PeerService_WatchServer) error {
+	id, err := uuid.Parse(req.GetId())
+	if err != nil {
+		return err
+	}
+
+	return p.watch(stream.Context(), uuid.Nil, id, req.GetInterval(), stream.Send)
+}
+
+func (p *PeersImpl) WatchDevice(req *wgpb.WatchDevicePeersRequest, stream wgpb.PeerService_WatchDeviceServer) error {
+	deviceID, err := uuid.Parse(req.GetDeviceId())
+	if err != nil {
+		return err
+	}
+
+	return p.watch(stream.Context(), deviceID, uuid.Nil, req.GetInterval(), stream.Send)
+}
+
+func (p *PeersImpl) watch(ctx context.Context, deviceID, peerID uuid.UUID, interval int32, send func(*wgpb.PeerEvent) error) error {
+	if interval < 0 {
+		return status.Error(codes.InvalidArgument, "interval should not be less than zero")
+	}
+
+	pollInterval := defaultWatchInterval
+	if interval > 0 {
+		pollInterval = time.Duration(interval) * time.Second
+	}
+
+	err := p.Service.Watch(ctx, deviceID, peerID, pollInterval, func(event entity.PeerEvent) error {
+		return send(mapEntityPeerEventToPbPeerEvent(event))
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return status.Error(codes.NotFound, err.Error())
+	}
+
+	return err
+}
@@ -1,12 +1,22 @@
 package handlers
 
 import (
+	"time"
+
 	wgpb "github.com/AZhur771/wg-grpc-api/gen"
+	"github.com/AZhur771/wg-grpc-api/internal/dto"
 	"github.com/AZhur771/wg-grpc-api/internal/entity"
+	"github.com/google/uuid"
+	"golang.zx2c4.com/wireguard/wgctrl/wgtypes"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 func mapEntityDeviceToPbDeivce(dev *entity.Device) *wgpb.Device {
+	pendingPublicKey := ""
+	if dev.HasPendingKey() {
+		pendingPublicKey = dev.PendingPublicKey.String()
+	}
+
 	return &wgpb.Device{
 		Id:                  dev.ID.String(),
 		Name:                dev.Name,
@@ -18,14 +28,19 @@ func mapEntityDeviceToPbDeivce(dev *entity.Device) *wgpb.Device {
 		CurrentPeersCount:   int32(dev.CurrentPeersCount),
 		Endpoint:            dev.Endpoint,
 		Address:             dev.Address,
+		Address6:            dev.Address6,
+		ClientAllowedIps:    dev.ClientAllowedIPs,
 		Mtu:                 int32(dev.MTU),
 		Dns:                 dev.DNS,
 		Table:               dev.Table,
+		Netns:               dev.Netns,
 		PersistentKeepAlive: int32(dev.PersistentKeepAlive),
 		PreUp:               dev.PreUp,
 		PreDown:             dev.PreDown,
 		PostUp:              dev.PostDown,
 		PostDown:            dev.PostDown,
+		PendingPublicKey:    pendingPublicKey,
+		KeyCutoverAt:        mapTimeToPbTimestamp(dev.KeyCutoverAt),
 	}
 }
 
@@ -43,12 +58,21 @@ func mapEntityPeerToPbPeer(peer *entity.Peer) *wgpb.Peer {
 		ReceiveBytes:        peer.ReceiveBytes,
 		TransmitBytes:       peer.TransmitBytes,
 		LastHandshake:       timestamppb.New(peer.LastHandshakeTime),
+		HasPrivateKey:       peer.HasPrivateKey,
 		HasPresharedKey:     peer.HasPresharedKey,
 		IsEnabled:           peer.IsEnabled,
 		IsActive:            peer.IsActive,
 		Description:         peer.Description,
 		Dns:                 peer.DNS,
 		Mtu:                 int32(peer.MTU),
+		ExpiresAt:           mapTimeToPbTimestamp(peer.ExpiresAt),
+		QuotaBytes:          peer.QuotaBytes,
+		QuotaPeriod:         wgpb.QuotaPeriod(peer.QuotaPeriod),
+		UsageReceiveBytes:   peer.Usage.ReceiveBytes,
+		UsageTransmitBytes:  peer.Usage.TransmitBytes,
+		UsagePeriodStart:    mapTimeToPbTimestamp(peer.Usage.PeriodStart),
+		ClientAllowedIps:    peer.ClientAllowedIPs,
+		ExtraRoutes:         peer.ExtraRoutes,
 	}
 }
 
@@ -61,12 +85,137 @@ func mapEntityPeerToPbPeerAbridged(peer *entity.Peer) *wgpb.PeerAbridged {
 		PublicKey:           peer.PublicKey.String(),
 		PersistentKeepAlive: int32(peer.PersistentKeepaliveInterval.Seconds()),
 		AllowedIps:          peer.AllowedIPs,
+		HasPrivateKey:       peer.HasPrivateKey,
 		HasPresharedKey:     peer.HasPresharedKey,
 		IsEnabled:           peer.IsEnabled,
 		Description:         peer.Description,
 		Dns:                 peer.DNS,
 		Mtu:                 int32(peer.MTU),
+		ExpiresAt:           mapTimeToPbTimestamp(peer.ExpiresAt),
+		QuotaBytes:          peer.QuotaBytes,
+		QuotaPeriod:         wgpb.QuotaPeriod(peer.QuotaPeriod),
+		UsageBytes:          peer.UsedBytes(),
+		ClientAllowedIps:    peer.ClientAllowedIPs,
+		ExtraRoutes:         peer.ExtraRoutes,
+	}
+}
+
+func mapBulkAddPeerResultToPb(result dto.BulkAddPeerResultDTO) *wgpb.BulkAddPeerResult {
+	res := &wgpb.BulkAddPeerResult{
+		Index:  int32(result.Index),
+		Errors: make([]*wgpb.FieldViolation, 0, len(result.Errors)),
+	}
+
+	if result.Peer != nil {
+		res.Peer = mapEntityPeerToPbPeer(result.Peer)
+	}
+
+	for _, violation := range result.Errors {
+		res.Errors = append(res.Errors, &wgpb.FieldViolation{
+			Field:       violation.GetField(),
+			Description: violation.GetDescription(),
+		})
 	}
+
+	return res
+}
+
+func mapEntityPeerEventToPbPeerEvent(event entity.PeerEvent) *wgpb.PeerEvent {
+	return &wgpb.PeerEvent{
+		Type:               wgpb.PeerEventType(event.Type),
+		Time:               mapTimeToPbTimestamp(event.Time),
+		Peer:               mapEntityPeerToPbPeer(event.Peer),
+		ReceiveBytesDelta:  event.ReceiveBytesDelta,
+		TransmitBytesDelta: event.TransmitBytesDelta,
+	}
+}
+
+func mapEntityAPIKeyToPbAPIKey(key *entity.APIKey) *wgpb.ApiKey {
+	deviceIDs := make([]string, 0, len(key.DeviceIDs))
+	for _, id := range key.DeviceIDs {
+		deviceIDs = append(deviceIDs, id.String())
+	}
+
+	return &wgpb.ApiKey{
+		Id:         key.ID.String(),
+		Name:       key.Name,
+		Role:       wgpb.Role(key.Role),
+		DeviceIds:  deviceIDs,
+		CreatedAt:  mapTimeToPbTimestamp(key.CreatedAt),
+		LastUsedAt: mapTimeToPbTimestamp(key.LastUsedAt),
+		RevokedAt:  mapTimeToPbTimestamp(key.RevokedAt),
+	}
+}
+
+func mapEntityAuditRecordToPbAuditRecord(record *entity.AuditRecord) *wgpb.AuditRecord {
+	changes := make(map[string]*wgpb.AuditChange, len(record.Changes))
+	for field, change := range record.Changes {
+		changes[field] = &wgpb.AuditChange{
+			Old: change.Old,
+			New: change.New,
+		}
+	}
+
+	return &wgpb.AuditRecord{
+		Id:         record.ID.String(),
+		Actor:      record.Actor,
+		Action:     string(record.Action),
+		EntityType: record.EntityType,
+		EntityId:   record.EntityID.String(),
+		Changes:    changes,
+		CreatedAt:  mapTimeToPbTimestamp(record.CreatedAt),
+	}
+}
+
+func mapEntityReconcileReportToPb(report *entity.ReconcileReport) *wgpb.ReconcileReport {
+	drifts := make([]*wgpb.Drift, 0, len(report.Drifts))
+	for _, drift := range report.Drifts {
+		peerID := ""
+		if drift.PeerID != uuid.Nil {
+			peerID = drift.PeerID.String()
+		}
+
+		publicKey := ""
+		if drift.PublicKey != (wgtypes.Key{}) {
+			publicKey = drift.PublicKey.String()
+		}
+
+		drifts = append(drifts, &wgpb.Drift{
+			Kind:      wgpb.DriftKind(drift.Kind),
+			DeviceId:  drift.DeviceID.String(),
+			Device:    drift.Device,
+			PeerId:    peerID,
+			PublicKey: publicKey,
+			Fixed:     drift.Fixed,
+		})
+	}
+
+	return &wgpb.ReconcileReport{
+		StartedAt:           mapTimeToPbTimestamp(report.StartedAt),
+		FinishedAt:          mapTimeToPbTimestamp(report.FinishedAt),
+		DriftOnly:           report.DriftOnly,
+		Drifts:              drifts,
+		UnmanagedInterfaces: report.UnmanagedInterfaces,
+		Errors:              report.Errors,
+	}
+}
+
+// mapTimeToPbTimestamp maps zero time to nil timestamp.
+func mapTimeToPbTimestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+
+	return timestamppb.New(t)
+}
+
+// mapPbTimestampToTime maps nil timestamp to zero time.
+func mapPbTimestampToTime(ts *timestamppb.Timestamp) time.Time {
+	if ts == nil {
+		return time.Time{}
+	}
+
+	return ts.AsTime()
 }
 
 func mapNames(s string) string {
@@ -83,8 +232,12 @@ func mapNames(s string) string {
 		return "Endpoint"
 	case "address":
 		return "Address"
+	case "address6":
+		return "Address6"
 	case "table":
 		return "Table"
+	case "netns":
+		return "Netns"
 	case "firewall_mark":
 		return "FirewallMark"
 	case "dns":
@@ -105,6 +258,18 @@ func mapNames(s string) string {
 		return "PostUp"
 	case "post_down":
 		return "PostDown"
+	case "expires_at":
+		return "ExpiresAt"
+	case "quota_bytes":
+		return "QuotaBytes"
+	case "quota_period":
+		return "QuotaPeriod"
+	case "client_allowed_ips":
+		return "ClientAllowedIPs"
+	case "extra_routes":
+		return "ExtraRoutes"
+	case "addresses":
+		return "Addresses"
 	default:
 		return ""
 	}
@@ -2,19 +2,30 @@
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 
+	wgpb "github.com/AZhur771/wg-grpc-api/gen"
+	"github.com/AZhur771/wg-grpc-api/internal/auth"
+	"github.com/google/uuid"
 	"github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/logging"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 )
 
-func withUnaryServerInterceptor(tokens []string) grpc.UnaryServerInterceptor {
+func withUnaryServerInterceptor(authz *authorizer) grpc.UnaryServerInterceptor {
 	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
-		if err := authorize(ctx, tokens); err != nil {
+		identity, err := authz.authorize(ctx, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+
+		ctx = auth.NewContext(ctx, identity)
+
+		if err := authz.checkScope(ctx, identity, info.FullMethod, req); err != nil {
 			return nil, err
 		}
 
@@ -22,6 +33,42 @@ func withUnaryServerInterceptor(tokens []string) grpc.UnaryServerInterceptor {
 	}
 }
 
+func withStreamServerInterceptor(authz *authorizer) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		identity, err := authz.authorize(ss.Context(), info.FullMethod)
+		if err != nil {
+			return err
+		}
+
+		return handler(srv, &authorizedStream{
+			ServerStream: ss,
+			ctx:          auth.NewContext(ss.Context(), identity),
+			checkScope: func(ctx context.Context, req interface{}) error {
+				return authz.checkScope(ctx, identity, info.FullMethod, req)
+			},
+		})
+	}
+}
+
+// authorizedStream carries identity of the client and checks device scope of every received message.
+type authorizedStream struct {
+	grpc.ServerStream
+	ctx        context.Context
+	checkScope func(ctx context.Context, req interface{}) error
+}
+
+func (s *authorizedStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *authorizedStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+
+	return s.checkScope(s.ctx, m)
+}
+
 func interceptorLogger(l *zap.Logger) logging.Logger {
 	return logging.LoggerFunc(func(ctx context.Context, lvl logging.Level, msg string, fields ...any) {
 		f := make([]zap.Field, 0, len(fields)/2)
@@ -59,30 +106,91 @@ func interceptorLogger(l *zap.Logger) logging.Logger {
 	})
 }
 
-func authorize(ctx context.Context, tokens []string) error {
-	md, ok := metadata.FromIncomingContext(ctx)
-	if !ok {
-		return status.Error(codes.Unauthenticated, "metadata is not provided")
+type authorizer struct {
+	logger        *zap.Logger
+	authenticator auth.Authenticator
+	// peerDevice resolves device of the peer to check scope of peer requests.
+	peerDevice func(ctx context.Context, peerID uuid.UUID) (uuid.UUID, error)
+}
+
+// authorize authenticates the client and checks its role allows calling the method.
+func (a *authorizer) authorize(ctx context.Context, fullMethod string) (*auth.Identity, error) {
+	identity, err := a.authenticator.Authenticate(ctx)
+	if errors.Is(err, auth.ErrNoCredentials) {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
 	}
 
-	values := md["x-api-key"]
-	if len(values) == 0 {
-		return status.Error(codes.Unauthenticated, "x-api-key is not provided")
+	if errors.Is(err, auth.ErrInvalidCredentials) {
+		return nil, status.Error(codes.PermissionDenied, "permission denied")
 	}
 
-	if contains(tokens, values[0]) {
+	if err != nil {
+		a.logger.Error("failed to authenticate", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to authenticate")
+	}
+
+	if !identity.Role.Allows(auth.RequiredRole(fullMethod)) {
+		return nil, status.Errorf(codes.PermissionDenied, "role %s is not allowed to call %s", identity.Role, fullMethod)
+	}
+
+	return identity, nil
+}
+
+// checkScope makes sure identity limited to particular devices acts on one of them.
+func (a *authorizer) checkScope(ctx context.Context, identity *auth.Identity, fullMethod string, req interface{}) error {
+	if !identity.IsScoped() {
 		return nil
 	}
 
-	return status.Error(codes.PermissionDenied, "permission denied")
+	deviceID, err := a.targetDevice(ctx, fullMethod, req)
+	if err != nil {
+		a.logger.Debug("failed to resolve target device", zap.String("method", fullMethod), zap.Error(err))
+		return status.Error(codes.PermissionDenied, "permission denied")
+	}
+
+	if deviceID == uuid.Nil {
+		return status.Error(codes.PermissionDenied, "device id is required for device scoped credentials")
+	}
+
+	if !identity.CanAccessDevice(deviceID) {
+		return status.Error(codes.PermissionDenied, "permission denied")
+	}
+
+	return nil
 }
 
-func contains(tokens []string, token string) bool {
-	for _, t := range tokens {
-		if token == t {
-			return true
+// targetDevice returns id of the device request acts on, nil id if request is not bound to a device.
+func (a *authorizer) targetDevice(ctx context.Context, fullMethod string, req interface{}) (uuid.UUID, error) {
+	switch r := req.(type) {
+	case interface{ GetDeviceId() string }:
+		return parseOptionalID(r.GetDeviceId())
+	case *wgpb.UpdateDeviceRequest:
+		return parseOptionalID(r.GetDevice().GetId())
+	case *wgpb.UpdatePeerRequest:
+		return a.resolvePeerDevice(ctx, r.GetPeer().GetId())
+	case interface{ GetId() string }:
+		if strings.HasPrefix(fullMethod, "/PeerService/") {
+			return a.resolvePeerDevice(ctx, r.GetId())
 		}
+		return parseOptionalID(r.GetId())
+	default:
+		return uuid.Nil, nil
+	}
+}
+
+func (a *authorizer) resolvePeerDevice(ctx context.Context, id string) (uuid.UUID, error) {
+	peerID, err := uuid.Parse(id)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	return a.peerDevice(ctx, peerID)
+}
+
+func parseOptionalID(id string) (uuid.UUID, error) {
+	if id == "" {
+		return uuid.Nil, nil
 	}
 
-	return false
+	return uuid.Parse(id)
 }
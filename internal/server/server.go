@@ -2,12 +2,14 @@
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
 	"io"
 	"io/fs"
 	"log"
 	"mime"
 	"net/http"
+	"net/textproto"
 	"os"
 	"strings"
 	"sync"
@@ -15,11 +17,16 @@
 
 	wgpb "github.com/AZhur771/wg-grpc-api/gen"
 	"github.com/AZhur771/wg-grpc-api/internal/app"
+	"github.com/AZhur771/wg-grpc-api/internal/auth"
 	"github.com/AZhur771/wg-grpc-api/internal/certs"
+	"github.com/AZhur771/wg-grpc-api/internal/metrics"
 	"github.com/AZhur771/wg-grpc-api/internal/server/handlers"
+	apikeyservice "github.com/AZhur771/wg-grpc-api/internal/service/apikey"
+	auditservice "github.com/AZhur771/wg-grpc-api/internal/service/audit"
 	deviceservice "github.com/AZhur771/wg-grpc-api/internal/service/device"
 	peerservice "github.com/AZhur771/wg-grpc-api/internal/service/peer"
 	"github.com/AZhur771/wg-grpc-api/third_party"
+	"github.com/google/uuid"
 	grpc_recovery "github.com/grpc-ecosystem/go-grpc-middleware/recovery"
 	"github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/logging"
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
@@ -28,6 +35,7 @@
 	"golang.org/x/net/http2/h2c"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/reflection"
 	"google.golang.org/grpc/status"
@@ -37,17 +45,19 @@
 const (
 	defaultGatewayPrefix string = "/api/"
 	defaultSwaggerPrefix string = "/swagger-ui/"
+	defaultMetricsPath   string = "/metrics"
 )
 
 type Server struct {
-	logger  *zap.Logger
-	grpcSrv *grpc.Server
-	mux     *http.ServeMux
-	addr    string
-	cert    string
-	key     string
-	swagger bool
-	tls     bool
+	logger    *zap.Logger
+	grpcSrv   *grpc.Server
+	mux       *http.ServeMux
+	addr      string
+	cert      string
+	key       string
+	swagger   bool
+	tls       bool
+	tlsConfig *tls.Config
 }
 
 func handlePanic(p interface{}) (err error) {
@@ -95,11 +105,9 @@ func grpcHandlerFunc(srv *http2.Server, grpcServer *grpc.Server, otherHandler ht
 	}), srv)
 }
 
-func NewServer(ctx context.Context, logger *zap.Logger,
-	peerService *peerservice.PeerService, deviceService *deviceservice.DeviceService, cfg app.Config,
-) (*Server, error) {
-	addr := fmt.Sprintf("%s:%d", cfg.Host, cfg.Port) // gRPC/REST API address
-
+// serverInterceptors returns interceptors shared by unary and streaming RPCs,
+// so every RPC is measured, logged, authorized and protected from panics in the same way.
+func serverInterceptors(logger *zap.Logger, authz *authorizer, appMetrics *metrics.Metrics) []grpc.ServerOption {
 	logOptions := []logging.Option{
 		logging.WithLogOnEvents(logging.StartCall, logging.FinishCall),
 	}
@@ -108,48 +116,145 @@ func NewServer(ctx context.Context, logger *zap.Logger,
 		grpc_recovery.WithRecoveryHandler(handlePanic),
 	}
 
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(
+			appMetrics.UnaryServerInterceptor(),
+			logging.UnaryServerInterceptor(interceptorLogger(logger), logOptions...),
+			withUnaryServerInterceptor(authz),
+			grpc_recovery.UnaryServerInterceptor(recOptions...),
+		),
+		grpc.ChainStreamInterceptor(
+			appMetrics.StreamServerInterceptor(),
+			logging.StreamServerInterceptor(interceptorLogger(logger), logOptions...),
+			withStreamServerInterceptor(authz),
+			grpc_recovery.StreamServerInterceptor(recOptions...),
+		),
+	}
+}
+
+// gatewayHeaderMatcher forwards x-api-key header to gRPC metadata under the same key gRPC clients use,
+// Authorization header is always forwarded as authorization by the gateway itself.
+// Metadata set by the gateway only (e.g. forwarded client certificate) can not be passed by clients.
+func gatewayHeaderMatcher(key string) (string, bool) {
+	if textproto.CanonicalMIMEHeaderKey(key) == "X-Api-Key" {
+		return "x-api-key", true
+	}
+
+	mdKey, ok := runtime.DefaultHeaderMatcher(key)
+	if !ok || auth.IsGatewayMetadata(mdKey) {
+		return "", false
+	}
+
+	return mdKey, true
+}
+
+func newGatewayMux(opts ...runtime.ServeMuxOption) *runtime.ServeMux {
+	opts = append([]runtime.ServeMuxOption{
+		runtime.WithMarshalerOption(runtime.MIMEWildcard, &runtime.JSONPb{
+			MarshalOptions: protojson.MarshalOptions{
+				EmitUnpopulated: true,
+			},
+			UnmarshalOptions: protojson.UnmarshalOptions{
+				DiscardUnknown: true,
+			},
+		}),
+		runtime.WithIncomingHeaderMatcher(gatewayHeaderMatcher),
+	}, opts...)
+
+	return runtime.NewServeMux(opts...)
+}
+
+func NewServer(ctx context.Context, logger *zap.Logger,
+	peerService *peerservice.PeerService, deviceService *deviceservice.DeviceService,
+	apiKeyService *apikeyservice.APIKeyService, auditService *auditservice.AuditService, peerRepo app.PeerRepo,
+	appMetrics *metrics.Metrics, cfg app.Config,
+) (*Server, error) {
+	addr := fmt.Sprintf("%s:%d", cfg.Host, cfg.Port) // gRPC/REST API address
+
+	tokenAuthenticator, err := auth.NewTokenAuthenticator(cfg.Tokens)
+	if err != nil {
+		return nil, fmt.Errorf("grpc server: %w", err)
+	}
+
+	// env tokens are kept as bootstrap keys to create the first API keys
+	authenticator := auth.ChainAuthenticator{
+		tokenAuthenticator,
+		auth.NewKeyAuthenticator(apiKeyService),
+	}
+
+	if cfg.JWTSecret != "" || cfg.JWTJWKSFile != "" {
+		jwtAuthenticator, err := auth.NewJWTAuthenticator(auth.JWTConfig{
+			Secret:       cfg.JWTSecret,
+			JWKSFile:     cfg.JWTJWKSFile,
+			Audience:     cfg.JWTAudience,
+			RoleClaim:    cfg.JWTRoleClaim,
+			DevicesClaim: cfg.JWTDevicesClaim,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("grpc server: %w", err)
+		}
+
+		authenticator = append(authenticator, jwtAuthenticator)
+	}
+
+	var gatewayOpts []runtime.ServeMuxOption
+
+	// credentials passed explicitly take precedence over client certificate
+	if cfg.ClientCaCert != "" {
+		certAuthenticator, err := auth.NewCertAuthenticator()
+		if err != nil {
+			return nil, fmt.Errorf("grpc server: %w", err)
+		}
+
+		authenticator = append(authenticator, certAuthenticator)
+		// REST clients are authenticated by their certificates as well
+		gatewayOpts = append(gatewayOpts, runtime.WithMetadata(certAuthenticator.GatewayMetadata))
+	}
+
+	authz := &authorizer{
+		logger:        logger,
+		authenticator: authenticator,
+		// scope is checked against the database only, so it never depends on the state of interfaces
+		peerDevice: func(ctx context.Context, peerID uuid.UUID) (uuid.UUID, error) {
+			peer, err := peerRepo.Get(ctx, nil, peerID)
+			if err != nil {
+				return uuid.Nil, err
+			}
+
+			return peer.DeviceID, nil
+		},
+	}
+
 	grpcOptions := make([]grpc.ServerOption, 0)
 
+	var tlsConfig *tls.Config
+
 	if cfg.Cert != "" || cfg.Key != "" {
-		tlsCredentials, err := certs.LoadTLSCredentials(cfg.Cert, cfg.Key)
+		tlsConfig, err = certs.LoadServerTLSConfig(cfg.Cert, cfg.Key, cfg.ClientCaCert)
 		if err != nil {
 			return nil, fmt.Errorf("grpc server: %w", err)
 		}
 
-		grpcOptions = append(grpcOptions, grpc.Creds(tlsCredentials))
+		grpcOptions = append(grpcOptions, grpc.Creds(credentials.NewTLS(tlsConfig)))
 	}
 
-	grpcOptions = append(
-		grpcOptions,
-		grpc.ChainUnaryInterceptor(
-			logging.UnaryServerInterceptor(interceptorLogger(logger), logOptions...),
-			withUnaryServerInterceptor(cfg.Tokens),
-			grpc_recovery.UnaryServerInterceptor(recOptions...),
-		),
-	)
+	grpcOptions = append(grpcOptions, serverInterceptors(logger, authz, appMetrics)...)
 
 	grpcSrv := grpc.NewServer(grpcOptions...)
 
 	device := handlers.NewDeviceImpl(ctx, logger, deviceService)
 	peers := handlers.NewPeersImpl(ctx, logger, peerService)
+	apiKeys := handlers.NewAPIKeysImpl(ctx, logger, apiKeyService)
+	audit := handlers.NewAuditImpl(ctx, logger, auditService)
 
 	wgpb.RegisterDeviceServiceServer(grpcSrv, device)
 	wgpb.RegisterPeerServiceServer(grpcSrv, peers)
+	wgpb.RegisterApiKeyServiceServer(grpcSrv, apiKeys)
+	wgpb.RegisterAuditServiceServer(grpcSrv, audit)
 	reflection.Register(grpcSrv)
 
-	gatewayOptions := []runtime.ServeMuxOption{
-		runtime.WithMarshalerOption(runtime.MIMEWildcard, &runtime.JSONPb{
-			MarshalOptions: protojson.MarshalOptions{
-				EmitUnpopulated: true,
-			},
-			UnmarshalOptions: protojson.UnmarshalOptions{
-				DiscardUnknown: true,
-			},
-		}),
-	}
-
 	mux := http.NewServeMux()
-	gwmux := runtime.NewServeMux(gatewayOptions...)
+	gwmux := newGatewayMux(gatewayOpts...)
 
 	if cfg.ServeSwagger {
 		if err := serveSwagger(mux); err != nil {
@@ -158,12 +263,24 @@ func NewServer(ctx context.Context, logger *zap.Logger,
 		}
 	}
 
+	if cfg.ServeMetrics {
+		mux.Handle(defaultMetricsPath, appMetrics.Handler())
+	}
+
 	mux.Handle(defaultGatewayPrefix, gwmux)
 
 	grpcDialOpts := make([]grpc.DialOption, 0, 1)
 
 	if cfg.CaCert != "" {
-		tlsCredentials, err := certs.LoadCATLSCredentials(cfg.CaCert)
+		var clientCert, clientKey string
+
+		// gateway has to present a certificate to the listener requiring one,
+		// the server certificate is used for that so it must be trusted by the client CA
+		if cfg.ClientCaCert != "" {
+			clientCert, clientKey = cfg.Cert, cfg.Key
+		}
+
+		tlsCredentials, err := certs.LoadCATLSCredentials(cfg.CaCert, clientCert, clientKey)
 		if err != nil {
 			return nil, fmt.Errorf("rest gateway server: %w", err)
 		}
@@ -182,15 +299,26 @@ func NewServer(ctx context.Context, logger *zap.Logger,
 		return nil, err
 	}
 
+	if err := wgpb.RegisterApiKeyServiceHandlerFromEndpoint(ctx, gwmux, addr, grpcDialOpts); err != nil {
+		logger.Error("failed to register api key gateway handler", zap.Error(err))
+		return nil, err
+	}
+
+	if err := wgpb.RegisterAuditServiceHandlerFromEndpoint(ctx, gwmux, addr, grpcDialOpts); err != nil {
+		logger.Error("failed to register audit gateway handler", zap.Error(err))
+		return nil, err
+	}
+
 	return &Server{
-		swagger: cfg.ServeSwagger,
-		tls:     cfg.Cert != "" && cfg.Key != "",
-		addr:    addr,
-		cert:    cfg.Cert,
-		key:     cfg.Key,
-		logger:  logger,
-		grpcSrv: grpcSrv,
-		mux:     mux,
+		swagger:   cfg.ServeSwagger,
+		tls:       cfg.Cert != "" && cfg.Key != "",
+		tlsConfig: tlsConfig,
+		addr:      addr,
+		cert:      cfg.Cert,
+		key:       cfg.Key,
+		logger:    logger,
+		grpcSrv:   grpcSrv,
+		mux:       mux,
 	}, nil
 }
 
@@ -203,6 +331,7 @@ func (s *Server) Run(ctx context.Context, stop context.CancelFunc) {
 		Addr:              s.addr,
 		Handler:           grpcHandlerFunc(srvh2, s.grpcSrv, s.mux),
 		ReadHeaderTimeout: time.Second * 10,
+		TLSConfig:         s.tlsConfig,
 	}
 
 	if err := http2.ConfigureServer(srvh, srvh2); err != nil {
@@ -0,0 +1,321 @@
+package server
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"io"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	wgpb "github.com/AZhur771/wg-grpc-api/gen"
+	"github.com/AZhur771/wg-grpc-api/internal/auth"
+	"github.com/AZhur771/wg-grpc-api/internal/metrics"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+var (
+	deviceIDMock      = uuid.MustParse("acda9b63-45ae-4352-995c-82202086cac4")
+	peerIDMock        = uuid.MustParse("6b3a0b0e-6c1a-4b6b-9d1e-2f4c1c9e8a10")
+	foreignPeerIDMock = uuid.MustParse("0f0e4c71-8c55-4d7c-9a53-7b0c8f1a2d33")
+	jwtSecretMock     = "jwt-secret"
+)
+
+// peerServiceMock echoes name of the authenticated client back.
+type peerServiceMock struct {
+	wgpb.UnimplementedPeerServiceServer
+}
+
+func (peerServiceMock) Get(ctx context.Context, req *wgpb.EntityIdRequest) (*wgpb.Peer, error) {
+	identity, _ := auth.FromContext(ctx)
+
+	return &wgpb.Peer{Id: req.GetId(), Name: identity.Name}, nil
+}
+
+func (peerServiceMock) Watch(req *wgpb.WatchPeerRequest, stream wgpb.PeerService_WatchServer) error {
+	identity, _ := auth.FromContext(stream.Context())
+
+	return stream.Send(&wgpb.PeerEvent{Peer: &wgpb.Peer{Id: req.GetId(), Name: identity.Name}})
+}
+
+func (peerServiceMock) BulkAddStream(stream wgpb.PeerService_BulkAddStreamServer) error {
+	var added int32
+
+	for {
+		req, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return stream.SendAndClose(&wgpb.BulkAddPeersResponse{Added: added})
+		}
+
+		if err != nil {
+			return err
+		}
+
+		added += int32(len(req.GetPeers()))
+	}
+}
+
+func (peerServiceMock) WatchDevice(req *wgpb.WatchDevicePeersRequest, stream wgpb.PeerService_WatchDeviceServer) error {
+	panic("watch device")
+}
+
+func newTestConn(t *testing.T, authenticators ...auth.Authenticator) *grpc.ClientConn {
+	t.Helper()
+
+	tokenAuthenticator, err := auth.NewTokenAuthenticator([]string{
+		"admin-secret",
+		"scoped-secret:read-only:" + deviceIDMock.String(),
+		"scoped-operator-secret:peer-operator:" + deviceIDMock.String(),
+	})
+	require.NoError(t, err)
+
+	jwtAuthenticator, err := auth.NewJWTAuthenticator(auth.JWTConfig{
+		Secret:       jwtSecretMock,
+		RoleClaim:    "role",
+		DevicesClaim: "devices",
+	})
+	require.NoError(t, err)
+
+	authz := &authorizer{
+		logger:        zap.NewNop(),
+		authenticator: append(auth.ChainAuthenticator{tokenAuthenticator, jwtAuthenticator}, authenticators...),
+		peerDevice: func(ctx context.Context, id uuid.UUID) (uuid.UUID, error) {
+			if id == peerIDMock {
+				return deviceIDMock, nil
+			}
+			return uuid.New(), nil
+		},
+	}
+
+	appMetrics := metrics.New(metrics.NewPeerCollector(zap.NewNop(), nil, nil, nil))
+
+	grpcSrv := grpc.NewServer(serverInterceptors(zap.NewNop(), authz, appMetrics)...)
+	wgpb.RegisterPeerServiceServer(grpcSrv, peerServiceMock{})
+
+	lis := bufconn.Listen(1 << 20)
+	go grpcSrv.Serve(lis) //nolint:errcheck
+	t.Cleanup(grpcSrv.Stop)
+
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	return conn
+}
+
+func newTestJWT(t *testing.T) string {
+	t.Helper()
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"sub":  "alice",
+		"exp":  jwt.NewNumericDate(time.Now().Add(time.Hour)),
+		"role": "admin",
+	}).SignedString([]byte(jwtSecretMock))
+	require.NoError(t, err)
+
+	return token
+}
+
+func TestServer_GRPCAuth(t *testing.T) {
+	client := wgpb.NewPeerServiceClient(newTestConn(t))
+	ctx := context.Background()
+	withKey := func(key string) context.Context {
+		return metadata.AppendToOutgoingContext(ctx, "x-api-key", key)
+	}
+
+	_, err := client.Get(ctx, &wgpb.EntityIdRequest{Id: peerIDMock.String()})
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	_, err = client.Get(withKey("wrong-secret"), &wgpb.EntityIdRequest{Id: peerIDMock.String()})
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	peer, err := client.Get(withKey("admin-secret"), &wgpb.EntityIdRequest{Id: peerIDMock.String()})
+	require.NoError(t, err)
+	require.Equal(t, "token-0", peer.GetName())
+
+	peer, err = client.Get(
+		metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+newTestJWT(t)),
+		&wgpb.EntityIdRequest{Id: peerIDMock.String()},
+	)
+	require.NoError(t, err)
+	require.Equal(t, "jwt-alice", peer.GetName())
+
+	recvWatch := func(ctx context.Context, id uuid.UUID) (*wgpb.PeerEvent, error) {
+		stream, err := client.Watch(ctx, &wgpb.WatchPeerRequest{Id: id.String()})
+		require.NoError(t, err)
+
+		return stream.Recv()
+	}
+
+	_, err = recvWatch(ctx, peerIDMock)
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	event, err := recvWatch(withKey("admin-secret"), peerIDMock)
+	require.NoError(t, err)
+	require.Equal(t, "token-0", event.GetPeer().GetName())
+
+	event, err = recvWatch(withKey("scoped-secret"), peerIDMock)
+	require.NoError(t, err)
+	require.Equal(t, "token-1", event.GetPeer().GetName())
+
+	_, err = recvWatch(withKey("scoped-secret"), foreignPeerIDMock)
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	// panic in stream handler is recovered
+	stream, err := client.WatchDevice(withKey("admin-secret"), &wgpb.WatchDevicePeersRequest{DeviceId: deviceIDMock.String()})
+	require.NoError(t, err)
+
+	_, err = stream.Recv()
+	require.Equal(t, codes.Unknown, status.Code(err))
+}
+
+func TestServer_ClientStreamScope(t *testing.T) {
+	client := wgpb.NewPeerServiceClient(newTestConn(t))
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "x-api-key", "scoped-operator-secret")
+
+	bulkAdd := func(deviceIDs ...uuid.UUID) (*wgpb.BulkAddPeersResponse, error) {
+		stream, err := client.BulkAddStream(ctx)
+		require.NoError(t, err)
+
+		for _, id := range deviceIDs {
+			// server may close the stream early, error is reported by CloseAndRecv
+			if err := stream.Send(&wgpb.BulkAddPeersRequest{
+				DeviceId: id.String(),
+				Peers:    []*wgpb.BulkPeerEntry{{Name: "peer"}},
+			}); err != nil {
+				break
+			}
+		}
+
+		return stream.CloseAndRecv()
+	}
+
+	resp, err := bulkAdd(deviceIDMock, deviceIDMock)
+	require.NoError(t, err)
+	require.Equal(t, int32(2), resp.GetAdded())
+
+	// every received message is checked against the device scope
+	_, err = bulkAdd(deviceIDMock, uuid.New())
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+}
+
+func TestServer_GatewayAuth(t *testing.T) {
+	conn := newTestConn(t)
+
+	gwmux := newGatewayMux()
+	require.NoError(t, wgpb.RegisterPeerServiceHandler(context.Background(), gwmux, conn))
+
+	srv := httptest.NewServer(gwmux)
+	t.Cleanup(srv.Close)
+
+	get := func(path string, header http.Header) (int, string) {
+		req, err := http.NewRequest(http.MethodGet, srv.URL+path, nil)
+		require.NoError(t, err)
+		req.Header = header
+
+		resp, err := srv.Client().Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+
+		return resp.StatusCode, string(body)
+	}
+
+	peerPath := "/api/peers/" + peerIDMock.String()
+	watchPath := peerPath + "/watch"
+
+	code, _ := get(peerPath, http.Header{})
+	require.Equal(t, http.StatusUnauthorized, code)
+
+	code, body := get(peerPath, http.Header{"X-Api-Key": {"admin-secret"}})
+	require.Equal(t, http.StatusOK, code)
+	require.True(t, strings.Contains(body, `"token-0"`), body)
+
+	code, body = get(peerPath, http.Header{"Authorization": {"Bearer " + newTestJWT(t)}})
+	require.Equal(t, http.StatusOK, code)
+	require.True(t, strings.Contains(body, `"jwt-alice"`), body)
+
+	// errors of streaming RPCs are reported in the body once the response has started
+	_, body = get(watchPath, http.Header{})
+	require.True(t, strings.Contains(body, `"code":16`), body)
+
+	_, body = get(watchPath, http.Header{"X-Api-Key": {"scoped-secret"}})
+	require.True(t, strings.Contains(body, `"token-1"`), body)
+
+	_, body = get("/api/peers/"+foreignPeerIDMock.String()+"/watch", http.Header{"X-Api-Key": {"scoped-secret"}})
+	require.True(t, strings.Contains(body, `"code":7`), body)
+}
+
+func TestServer_GatewayClientCert(t *testing.T) {
+	certAuthenticator, err := auth.NewCertAuthenticator()
+	require.NoError(t, err)
+
+	conn := newTestConn(t, certAuthenticator)
+
+	gwmux := newGatewayMux(runtime.WithMetadata(certAuthenticator.GatewayMetadata))
+	require.NoError(t, wgpb.RegisterPeerServiceHandler(context.Background(), gwmux, conn))
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "automation", OrganizationalUnit: []string{"read-only"}},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	raw, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(raw)
+	require.NoError(t, err)
+
+	get := func(state *tls.ConnectionState) (int, string) {
+		req := httptest.NewRequest(http.MethodGet, "/api/peers/"+peerIDMock.String(), nil)
+		req.TLS = state
+
+		rec := httptest.NewRecorder()
+		gwmux.ServeHTTP(rec, req)
+
+		return rec.Code, rec.Body.String()
+	}
+
+	code, body := get(&tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{cert}}})
+	require.Equal(t, http.StatusOK, code)
+	require.True(t, strings.Contains(body, `"cert-automation"`), body)
+
+	code, _ = get(nil)
+	require.Equal(t, http.StatusUnauthorized, code)
+
+	// clients can not pass metadata reserved for the gateway
+	for _, header := range []string{"Grpc-Metadata-X-Forwarded-Client-Cert", "Grpc-Metadata-X-Gateway-Secret"} {
+		_, ok := gatewayHeaderMatcher(header)
+		require.False(t, ok, header)
+	}
+}
@@ -0,0 +1,199 @@
+package apikeyservice
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/AZhur771/wg-grpc-api/internal/app"
+	"github.com/AZhur771/wg-grpc-api/internal/auth"
+	dt "github.com/AZhur771/wg-grpc-api/internal/dto"
+	"github.com/AZhur771/wg-grpc-api/internal/entity"
+	"github.com/AZhur771/wg-grpc-api/internal/service/common"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultLimit = 20
+
+	secretSize = 32
+	saltSize   = 16
+
+	// lastUsedResolution limits how often last usage time is written to the database.
+	lastUsedResolution = time.Minute
+	// lastUsedTimeout bounds last usage time writes which are not tied to any request.
+	lastUsedTimeout = 5 * time.Second
+)
+
+type APIKeyService struct {
+	logger     *zap.Logger
+	apiKeyRepo app.APIKeyRepo
+
+	// lastUsed holds last usage times already written, so that keys used concurrently are written once
+	lastUsedMu sync.Mutex
+	lastUsed   map[uuid.UUID]time.Time
+}
+
+func NewAPIKeyService(logger *zap.Logger, apiKeyRepo app.APIKeyRepo) *APIKeyService {
+	return &APIKeyService{
+		logger:     logger,
+		apiKeyRepo: apiKeyRepo,
+		lastUsed:   make(map[uuid.UUID]time.Time),
+	}
+}
+
+// Create stores a new key and returns it along with the key string which is never shown again.
+func (as *APIKeyService) Create(ctx context.Context, dto dt.CreateAPIKeyDTO) (*entity.APIKey, string, error) {
+	key := &entity.APIKey{
+		Name:      dto.Name,
+		Role:      dto.Role,
+		DeviceIDs: dto.DeviceIDs,
+		CreatedAt: time.Now(),
+	}
+
+	if errors := key.IsValid(); len(errors) > 0 {
+		return nil, "", common.NewErrInvalidData(fmt.Errorf("api key service: %w", ErrInvalidAPIKeyData), errors)
+	}
+
+	secret, err := randomBytes(secretSize)
+	if err != nil {
+		return nil, "", fmt.Errorf("api key service: %w", err)
+	}
+
+	salt, err := randomBytes(saltSize)
+	if err != nil {
+		return nil, "", fmt.Errorf("api key service: %w", err)
+	}
+
+	encodedSecret := base64.RawURLEncoding.EncodeToString(secret)
+	key.SetSecret(salt, encodedSecret)
+
+	key, err = as.apiKeyRepo.Add(ctx, nil, key)
+	if err != nil {
+		return nil, "", fmt.Errorf("api key service: %w", err)
+	}
+
+	// key id is a part of the key string to look the key up without scanning the table
+	return key, fmt.Sprintf("%s.%s", key.ID, encodedSecret), nil
+}
+
+func (as *APIKeyService) GetAll(ctx context.Context, dto dt.GetAPIKeysRequestDTO) (dt.GetAPIKeysResponseDTO, error) {
+	resp := dt.GetAPIKeysResponseDTO{}
+
+	if errors := dto.IsValid(); len(errors) > 0 {
+		return resp, common.NewErrInvalidData(fmt.Errorf("api key service: %w", ErrInvalidPaginationParams), errors)
+	}
+
+	total, err := as.apiKeyRepo.Count(ctx, nil)
+	if err != nil {
+		return resp, fmt.Errorf("api key service: %w", err)
+	}
+
+	if dto.Limit == 0 {
+		dto.Limit = defaultLimit
+	}
+
+	keys, err := as.apiKeyRepo.GetAll(ctx, nil, dto.Skip, dto.Limit)
+	if err != nil {
+		return resp, fmt.Errorf("api key service: %w", err)
+	}
+
+	resp.Total = total
+	resp.APIKeys = keys
+	resp.HasNext = (dto.Skip + dto.Limit) < total
+
+	return resp, nil
+}
+
+func (as *APIKeyService) Revoke(ctx context.Context, id uuid.UUID) error {
+	if err := as.apiKeyRepo.Revoke(ctx, nil, id, time.Now()); err != nil {
+		return fmt.Errorf("api key service: %w", err)
+	}
+
+	as.lastUsedMu.Lock()
+	delete(as.lastUsed, id)
+	as.lastUsedMu.Unlock()
+
+	return nil
+}
+
+// VerifyKey returns identity of the key owner, it is used by auth.KeyAuthenticator.
+func (as *APIKeyService) VerifyKey(ctx context.Context, rawKey string) (*auth.Identity, error) {
+	id, secret, ok := strings.Cut(rawKey, ".")
+	if !ok {
+		return nil, auth.ErrInvalidCredentials
+	}
+
+	keyID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, auth.ErrInvalidCredentials
+	}
+
+	key, err := as.apiKeyRepo.Get(ctx, nil, keyID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, auth.ErrInvalidCredentials
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("api key service: %w", err)
+	}
+
+	if key.IsRevoked() || !key.Verify(secret) {
+		return nil, auth.ErrInvalidCredentials
+	}
+
+	if now := time.Now(); as.touch(key, now) {
+		// usage time is informational, so requests do not wait for it to be written
+		go func() {
+			ctx, cancel := context.WithTimeout(context.Background(), lastUsedTimeout)
+			defer cancel()
+
+			if err := as.apiKeyRepo.UpdateLastUsed(ctx, nil, key.ID, now); err != nil {
+				as.logger.Error("failed to update api key last usage time", zap.Error(err))
+			}
+		}()
+	}
+
+	return &auth.Identity{
+		Name:      "api-key-" + key.ID.String(),
+		Role:      key.Role,
+		DeviceIDs: key.DeviceIDs,
+	}, nil
+}
+
+// touch reports whether last usage time of the key should be written,
+// it is written at most once per lastUsedResolution no matter how many requests use the key.
+func (as *APIKeyService) touch(key *entity.APIKey, now time.Time) bool {
+	as.lastUsedMu.Lock()
+	defer as.lastUsedMu.Unlock()
+
+	last := key.LastUsedAt
+	if written, ok := as.lastUsed[key.ID]; ok && written.After(last) {
+		last = written
+	}
+
+	if now.Sub(last) <= lastUsedResolution {
+		return false
+	}
+
+	as.lastUsed[key.ID] = now
+
+	return true
+}
+
+func randomBytes(n int) ([]byte, error) {
+	b := make([]byte, n)
+
+	if _, err := rand.Read(b); err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
@@ -0,0 +1,79 @@
+package apikeyservice_test
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	app_mocks "github.com/AZhur771/wg-grpc-api/internal/app/mocks"
+	"github.com/AZhur771/wg-grpc-api/internal/auth"
+	"github.com/AZhur771/wg-grpc-api/internal/entity"
+	apikeyservice "github.com/AZhur771/wg-grpc-api/internal/service/apikey"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestAPIKeyService_VerifyKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	apiKeyRepo := app_mocks.NewMockAPIKeyRepo(ctrl)
+	service := apikeyservice.NewAPIKeyService(zap.NewNop(), apiKeyRepo)
+
+	key := &entity.APIKey{
+		ID:        uuid.New(),
+		Name:      "helpdesk",
+		Role:      auth.RolePeerOperator,
+		CreatedAt: time.Now(),
+	}
+	key.SetSecret([]byte("salt"), "secret")
+
+	apiKeyRepo.EXPECT().Get(gomock.Any(), gomock.Any(), key.ID).Return(key, nil).AnyTimes()
+
+	// concurrent requests write last usage time once and do not wait for it
+	written := make(chan struct{})
+	apiKeyRepo.EXPECT().UpdateLastUsed(gomock.Any(), gomock.Any(), key.ID, gomock.Any()).
+		DoAndReturn(func(context.Context, any, uuid.UUID, time.Time) error {
+			close(written)
+			return nil
+		})
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			identity, err := service.VerifyKey(context.Background(), fmt.Sprintf("%s.secret", key.ID))
+			require.NoError(t, err)
+			require.Equal(t, auth.RolePeerOperator, identity.Role)
+		}()
+	}
+
+	wg.Wait()
+
+	select {
+	case <-written:
+	case <-time.After(time.Second):
+		t.Fatal("last usage time is not written")
+	}
+
+	_, err := service.VerifyKey(context.Background(), fmt.Sprintf("%s.another", key.ID))
+	require.ErrorIs(t, err, auth.ErrInvalidCredentials)
+}
+
+func TestAPIKeyService_RevokeUnknown(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	apiKeyRepo := app_mocks.NewMockAPIKeyRepo(ctrl)
+	service := apikeyservice.NewAPIKeyService(zap.NewNop(), apiKeyRepo)
+
+	id := uuid.New()
+	apiKeyRepo.EXPECT().Revoke(gomock.Any(), gomock.Any(), id, gomock.Any()).Return(sql.ErrNoRows)
+
+	require.ErrorIs(t, service.Revoke(context.Background(), id), sql.ErrNoRows)
+}
@@ -0,0 +1,10 @@
+package apikeyservice
+
+import (
+	"errors"
+)
+
+var (
+	ErrInvalidPaginationParams = errors.New("invalid pagination params")
+	ErrInvalidAPIKeyData       = errors.New("invalid api key data")
+)
@@ -0,0 +1,87 @@
+package auditservice
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/AZhur771/wg-grpc-api/internal/app"
+	"github.com/AZhur771/wg-grpc-api/internal/auth"
+	dt "github.com/AZhur771/wg-grpc-api/internal/dto"
+	"github.com/AZhur771/wg-grpc-api/internal/entity"
+	"github.com/AZhur771/wg-grpc-api/internal/service/common"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const defaultLimit = 20
+
+type AuditService struct {
+	logger    *zap.Logger
+	auditRepo app.AuditRepo
+}
+
+func NewAuditService(logger *zap.Logger, auditRepo app.AuditRepo) *AuditService {
+	return &AuditService{
+		logger:    logger,
+		auditRepo: auditRepo,
+	}
+}
+
+// Record stores operation made by the client authenticated in ctx along with the fields it changed,
+// nil before or after stands for entity which did not exist before or after the operation.
+// Operation has already taken effect by then, so failure to record it is only logged.
+func (as *AuditService) Record(
+	ctx context.Context, action entity.AuditAction, entityType string, entityID uuid.UUID, before, after map[string]string,
+) {
+	actor := entity.AuditActorSystem
+	if identity, ok := auth.FromContext(ctx); ok {
+		actor = identity.Name
+	}
+
+	record := &entity.AuditRecord{
+		Actor:      actor,
+		Action:     action,
+		EntityType: entityType,
+		EntityID:   entityID,
+		Changes:    entity.DiffAuditFields(before, after),
+		CreatedAt:  time.Now(),
+	}
+
+	if _, err := as.auditRepo.Add(ctx, nil, record); err != nil {
+		as.logger.Error("failed to record audit log",
+			zap.String("actor", actor),
+			zap.String("action", string(action)),
+			zap.String(entityType, entityID.String()),
+			zap.Error(err),
+		)
+	}
+}
+
+func (as *AuditService) List(ctx context.Context, dto dt.GetAuditRecordsRequestDTO) (dt.GetAuditRecordsResponseDTO, error) {
+	resp := dt.GetAuditRecordsResponseDTO{}
+
+	if errors := dto.IsValid(); len(errors) > 0 {
+		return resp, common.NewErrInvalidData(fmt.Errorf("audit service: %w", ErrInvalidAuditFilter), errors)
+	}
+
+	total, err := as.auditRepo.Count(ctx, nil, dto.Filter)
+	if err != nil {
+		return resp, fmt.Errorf("audit service: %w", err)
+	}
+
+	if dto.Limit == 0 {
+		dto.Limit = defaultLimit
+	}
+
+	records, err := as.auditRepo.GetAll(ctx, nil, dto.Skip, dto.Limit, dto.Filter)
+	if err != nil {
+		return resp, fmt.Errorf("audit service: %w", err)
+	}
+
+	resp.Total = total
+	resp.Records = records
+	resp.HasNext = (dto.Skip + dto.Limit) < total
+
+	return resp, nil
+}
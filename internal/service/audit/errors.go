@@ -0,0 +1,7 @@
+package auditservice
+
+import (
+	"errors"
+)
+
+var ErrInvalidAuditFilter = errors.New("invalid audit filter")
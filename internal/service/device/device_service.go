@@ -4,19 +4,14 @@
 	"bytes"
 	"context"
 	"fmt"
-	"io/ioutil"
-	"net"
-	"os"
-	"os/exec"
 	"regexp"
-	"strings"
-	"text/template"
+	"sync"
+	"time"
 
 	"github.com/AZhur771/wg-grpc-api/internal/app"
 	dt "github.com/AZhur771/wg-grpc-api/internal/dto"
 	"github.com/AZhur771/wg-grpc-api/internal/entity"
 	"github.com/AZhur771/wg-grpc-api/internal/service/common"
-	tmpl "github.com/AZhur771/wg-grpc-api/internal/template"
 	"github.com/google/uuid"
 	fieldmask_utils "github.com/mennanov/fieldmask-utils"
 	"go.uber.org/zap"
@@ -28,18 +23,30 @@
 var re = regexp.MustCompile(`^wg\d+$`)
 
 type DeviceService struct {
-	logger     *zap.Logger
-	ctrl       app.WgCtrl
-	deviceRepo app.DeviceRepo
-	peerRepo   app.PeerRepo
+	logger       *zap.Logger
+	backend      app.InterfaceBackend
+	deviceRepo   app.DeviceRepo
+	peerRepo     app.PeerRepo
+	auditService app.AuditService
+
+	// reconcileMu serializes reconciliation passes and guards seenDrift
+	reconcileMu sync.Mutex
+	seenDrift   map[string]bool
+
+	reportMu   sync.RWMutex
+	lastReport *entity.ReconcileReport
 }
 
-func NewDeviceService(logger *zap.Logger, ctrl app.WgCtrl, deviceRepo app.DeviceRepo, peerRepo app.PeerRepo) *DeviceService {
+func NewDeviceService(
+	logger *zap.Logger, backend app.InterfaceBackend, deviceRepo app.DeviceRepo, peerRepo app.PeerRepo,
+	auditService app.AuditService,
+) *DeviceService {
 	return &DeviceService{
-		logger:     logger,
-		ctrl:       ctrl,
-		deviceRepo: deviceRepo,
-		peerRepo:   peerRepo,
+		logger:       logger,
+		backend:      backend,
+		deviceRepo:   deviceRepo,
+		peerRepo:     peerRepo,
+		auditService: auditService,
 	}
 }
 
@@ -54,111 +61,90 @@ func (ds *DeviceService) SyncDevices(ctx context.Context) error {
 			return fmt.Errorf("sync devices: %w", ErrInvalidDeviceData)
 		}
 
-		//nolint:gosec
-		if err := exec.Command("wg-quick", "down", device.Name).Run(); err != nil {
-			ds.logger.Error("sync devices", zap.Error(err))
-		}
-
-		if err := ds.setupDevice(device, false); err != nil {
-			return fmt.Errorf("sync devices: %w", err)
-		}
+		// cutover might have passed while the service was down
+		if device.HasPendingKey() && !device.KeyCutoverAt.After(time.Now()) {
+			before := device.AuditFields()
 
-		peers, err := ds.peerRepo.GetAll(ctx, nil, 0, 0, "", device.ID)
-		if err != nil {
-			return fmt.Errorf("sync devices: %w", err)
-		}
+			device.SetKey(device.PendingPrivateKey)
 
-		// sync peers with wg
-		for _, peer := range peers {
-			if errors := peer.IsValid(); len(errors) > 0 {
-				return common.NewErrInvalidData(fmt.Errorf("sync devices: invalid peer data"), errors)
-			}
-
-			peerConfig, err := peer.ToPeerConfig(device)
-			if err != nil {
+			if err := ds.deviceRepo.UpdateKey(ctx, nil, device); err != nil {
 				return fmt.Errorf("sync devices: %w", err)
 			}
 
-			if err := ds.ConfigureDevice(device.Name, *peerConfig); err != nil {
+			ds.auditService.Record(ctx, entity.AuditActionRotateKeys, entity.AuditEntityDevice, device.ID, before, device.AuditFields())
+		}
+
+		// interface kept running while the service was down (e.g. an imported one) is not restarted,
+		// that would drop live sessions
+		if wgdev, err := ds.backend.Device(device.Name); err != nil || !device.IsRunningOn(wgdev) {
+			if err := ds.setupDevice(device); err != nil {
 				return fmt.Errorf("sync devices: %w", err)
 			}
 		}
+
+		if err := ds.syncPeers(ctx, device); err != nil {
+			return fmt.Errorf("sync devices: %w", err)
+		}
 	}
 
 	return nil
 }
 
-func (ds *DeviceService) setupDevice(dev *entity.Device, update bool) error {
-	filename := fmt.Sprintf("/etc/wireguard/%s.conf", dev.Name)
-
-	t, err := template.New("config").Funcs(
-		template.FuncMap{
-			"StringsJoin": strings.Join,
-		},
-	).Parse(tmpl.ConfigTemplate)
+// syncPeers configures enabled peers on the interface kept running while the service was down and removes
+// disabled ones, the rest of interface peers are kept along with their sessions the same way import keeps them.
+func (ds *DeviceService) syncPeers(ctx context.Context, device *entity.Device) error {
+	peers, err := ds.peerRepo.GetAll(ctx, nil, 0, 0, "", device.ID)
 	if err != nil {
-		return fmt.Errorf("device service: %w", err)
+		return err
 	}
 
-	port := strings.Split(dev.Endpoint, ":")[1]
+	wgdev, err := ds.backend.Device(device.Name)
+	if err != nil {
+		return err
+	}
 
-	tmplData := tmpl.ConfigTmplData{
-		InterfacePrivateKey: dev.PrivateKey.String(),
-		InterfaceAddress:    []string{dev.Address},
-		InterfacePort:       port,
-		InterfaceMTU:        dev.MTU,
-		InterfaceTable:      dev.Table,
-		InterfaceDNS:        dev.DNS,
-		InterfaceFwMark:     dev.FirewallMark,
-		InterfacePreUp:      dev.PreUp,
-		InterfacePostUp:     dev.PostUp,
-		InterfacePreDown:    dev.PreDown,
-		InterfacePostDown:   dev.PostDown,
-		SaveConfig:          true,
+	configured := make(map[wgtypes.Key]bool, len(wgdev.Peers))
+	for _, wgpeer := range wgdev.Peers {
+		configured[wgpeer.PublicKey] = true
 	}
 
-	if update {
-		wgpeers, err := ds.GetConfiguredPeers(dev.Name)
-		if err != nil {
-			return fmt.Errorf("setup: %w", err)
-		}
+	configs := make([]wgtypes.PeerConfig, 0, len(peers))
 
-		for _, wgpeer := range wgpeers {
-			tmplData.InterfacePeers = append(tmplData.InterfacePeers, tmpl.PeerConfigTmplData{
-				PeerPublicKey:           wgpeer.PublicKey.String(),
-				PeerPresharedKey:        wgpeer.PresharedKey.String(),
-				PeerEndpoint:            wgpeer.Endpoint.String(),
-				PeerAllowedIPs:          stringifyAllowedIPs(wgpeer.AllowedIPs),
-				PeerPersistentKeepalive: int(wgpeer.PersistentKeepaliveInterval) / (1000 * 1000 * 1000),
-			})
+	for _, peer := range peers {
+		if errors := peer.IsValid(); len(errors) > 0 {
+			return common.NewErrInvalidData(fmt.Errorf("invalid peer data"), errors)
 		}
 
-		if matched := re.MatchString(dev.Name); !matched {
-			return fmt.Errorf("setup: %w", ErrInvalidDeviceData)
+		if !peer.IsEnabled {
+			if configured[peer.PublicKey] {
+				configs = append(configs, wgtypes.PeerConfig{PublicKey: peer.PublicKey, Remove: true})
+			}
+			continue
 		}
 
-		//nolint:gosec
-		if err := exec.Command("wg-quick", "down", dev.Name).Run(); err != nil {
-			ds.logger.Error("setup", zap.Error(err))
+		peerConfig, err := peer.ToPeerConfig(device)
+		if err != nil {
+			return err
 		}
+
+		configs = append(configs, *peerConfig)
 	}
 
-	var buf bytes.Buffer
+	ds.warnUnmanagedPeers(wgdev, configs)
 
-	if err := t.Execute(&buf, tmplData); err != nil {
-		return fmt.Errorf("setup: %w", err)
+	if len(configs) == 0 {
+		return nil
 	}
 
-	if err := ioutil.WriteFile(filename, buf.Bytes(), 0o600); err != nil {
-		return fmt.Errorf("setup: %w", err)
-	}
+	return ds.ConfigurePeers(device.Name, configs)
+}
 
+func (ds *DeviceService) setupDevice(dev *entity.Device) error {
 	if matched := re.MatchString(dev.Name); !matched {
-		return fmt.Errorf("sync devices: %w", ErrInvalidDeviceData)
+		return fmt.Errorf("setup: %w", ErrInvalidDeviceData)
 	}
 
-	//nolint:gosec
-	return exec.Command("wg-quick", "up", dev.Name).Run()
+	return ds.backend.Create(dev)
 }
 
 func (ds *DeviceService) Add(ctx context.Context, dto dt.AddDeviceDTO) (*entity.Device, error) {
@@ -173,15 +159,18 @@ func (ds *DeviceService) Add(ctx context.Context, dto dt.AddDeviceDTO) (*entity.
 		Description:         dto.Description,
 		Endpoint:            dto.Endpoint,
 		Address:             dto.Address,
+		Address6:            dto.Address6,
 		FirewallMark:        dto.FirewallMark,
 		PersistentKeepAlive: dto.PersistentKeepAlive,
 		MTU:                 dto.MTU,
 		DNS:                 dto.DNS,
 		Table:               dto.Table,
+		Netns:               dto.Netns,
 		PostUp:              dto.PostUp,
 		PostDown:            dto.PostDown,
 		PreUp:               dto.PreUp,
 		PreDown:             dto.PreDown,
+		ClientAllowedIPs:    dto.ClientAllowedIPs,
 	}
 
 	if dev.DNS == "" {
@@ -202,11 +191,13 @@ func (ds *DeviceService) Add(ctx context.Context, dto dt.AddDeviceDTO) (*entity.
 		return nil, fmt.Errorf("device service: %w", err)
 	}
 
-	if err := ds.setupDevice(dev, false); err != nil {
+	ds.auditService.Record(ctx, entity.AuditActionAdd, entity.AuditEntityDevice, dev.ID, nil, dev.AuditFields())
+
+	if err := ds.setupDevice(dev); err != nil {
 		return nil, fmt.Errorf("device service: %w", err)
 	}
 
-	wgdev, err := ds.ctrl.Device(dev.Name)
+	wgdev, err := ds.backend.Device(dev.Name)
 	if err != nil {
 		return nil, fmt.Errorf("device service: %w", err)
 	}
@@ -220,6 +211,8 @@ func (ds *DeviceService) Update(ctx context.Context, dto dt.UpdateDeviceDTO, mas
 		return nil, fmt.Errorf("device service: %w", err)
 	}
 
+	before := dev.AuditFields()
+
 	fieldmask_utils.StructToStruct(mask, dto, dev)
 
 	if dev.DNS == "" {
@@ -235,16 +228,19 @@ func (ds *DeviceService) Update(ctx context.Context, dto dt.UpdateDeviceDTO, mas
 		return nil, common.NewErrInvalidData(fmt.Errorf("device service: %w", ErrInvalidDeviceData), errors)
 	}
 
-	if err := ds.setupDevice(dev, true); err != nil {
+	dev, err = ds.deviceRepo.Update(ctx, nil, dev)
+	if err != nil {
 		return nil, fmt.Errorf("device service: %w", err)
 	}
 
-	dev, err = ds.deviceRepo.Update(ctx, nil, dev)
-	if err != nil {
+	ds.auditService.Record(ctx, entity.AuditActionUpdate, entity.AuditEntityDevice, dev.ID, before, dev.AuditFields())
+
+	// the interface is set up once the device is stored, so it never runs settings the database lacks
+	if err := ds.setupDevice(dev); err != nil {
 		return nil, fmt.Errorf("device service: %w", err)
 	}
 
-	wgdev, err := ds.ctrl.Device(dev.Name)
+	wgdev, err := ds.backend.Device(dev.Name)
 	if err != nil {
 		return nil, fmt.Errorf("device service: %w", err)
 	}
@@ -262,14 +258,10 @@ func (ds *DeviceService) Remove(ctx context.Context, id uuid.UUID) error {
 		return fmt.Errorf("device service: %w", err)
 	}
 
-	//nolint:gosec
-	if err := exec.Command("wg-quick", "down", dev.Name).Run(); err != nil {
-		ds.logger.Error(fmt.Sprintf("'wg-quick down %s' errored", dev.Name), zap.Error(err))
-	}
+	ds.auditService.Record(ctx, entity.AuditActionRemove, entity.AuditEntityDevice, dev.ID, dev.AuditFields(), nil)
 
-	filename := fmt.Sprintf("/etc/wireguard/%s.conf", dev.Name)
-	if err := os.Remove(filename); err != nil {
-		return err
+	if err := ds.backend.Destroy(dev); err != nil {
+		return fmt.Errorf("device service: %w", err)
 	}
 
 	return nil
@@ -281,7 +273,7 @@ func (ds *DeviceService) Get(ctx context.Context, id uuid.UUID) (*entity.Device,
 		return nil, fmt.Errorf("device service: %w", err)
 	}
 
-	wgdev, err := ds.ctrl.Device(dev.Name)
+	wgdev, err := ds.backend.Device(dev.Name)
 	if err != nil {
 		return nil, fmt.Errorf("device service: %w", err)
 	}
@@ -311,7 +303,7 @@ func (ds *DeviceService) GetAll(ctx context.Context, dto dt.GetDevicesRequestDTO
 	}
 
 	for i, dev := range devices {
-		wgdev, err := ds.ctrl.Device(dev.Name)
+		wgdev, err := ds.backend.Device(dev.Name)
 		if err != nil {
 			ds.logger.Error("failed to get device", zap.Error(err))
 			continue
@@ -330,7 +322,7 @@ func (ds *DeviceService) GetAll(ctx context.Context, dto dt.GetDevicesRequestDTO
 }
 
 func (ds *DeviceService) ConfigureDevice(device string, config wgtypes.PeerConfig) error {
-	return ds.ctrl.ConfigureDevice(
+	return ds.backend.Configure(
 		device,
 		wgtypes.Config{
 			Peers: []wgtypes.PeerConfig{config},
@@ -338,8 +330,18 @@ func (ds *DeviceService) ConfigureDevice(device string, config wgtypes.PeerConfi
 	)
 }
 
+// ConfigurePeers applies peer configs in a single call, so the interface never sees them applied partially.
+func (ds *DeviceService) ConfigurePeers(device string, configs []wgtypes.PeerConfig) error {
+	return ds.backend.Configure(
+		device,
+		wgtypes.Config{
+			Peers: configs,
+		},
+	)
+}
+
 func (ds *DeviceService) GetConfiguredPeer(dev string, publicKey wgtypes.Key) (wgtypes.Peer, error) {
-	device, err := ds.ctrl.Device(dev)
+	device, err := ds.backend.Device(dev)
 	if err != nil {
 		return wgtypes.Peer{}, fmt.Errorf("device service: %w", err)
 	}
@@ -354,20 +356,10 @@ func (ds *DeviceService) GetConfiguredPeer(dev string, publicKey wgtypes.Key) (w
 }
 
 func (ds *DeviceService) GetConfiguredPeers(dev string) ([]wgtypes.Peer, error) {
-	device, err := ds.ctrl.Device(dev)
+	device, err := ds.backend.Device(dev)
 	if err != nil {
 		return nil, fmt.Errorf("device service: %w", err)
 	}
 
 	return device.Peers, nil
 }
-
-func stringifyAllowedIPs(allowedIPs []net.IPNet) []string {
-	res := make([]string, 0, len(allowedIPs))
-
-	for _, allowedIP := range allowedIPs {
-		res = append(res, allowedIP.String())
-	}
-
-	return res
-}
@@ -0,0 +1,400 @@
+package deviceservice_test
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/AZhur771/wg-grpc-api/internal/app"
+	"github.com/AZhur771/wg-grpc-api/internal/dto"
+	"github.com/AZhur771/wg-grpc-api/internal/entity"
+	"github.com/AZhur771/wg-grpc-api/internal/repo"
+	"github.com/AZhur771/wg-grpc-api/internal/service/common"
+	deviceservice "github.com/AZhur771/wg-grpc-api/internal/service/device"
+	"github.com/AZhur771/wg-grpc-api/internal/servicetest"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	fieldmask_utils "github.com/mennanov/fieldmask-utils"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"golang.zx2c4.com/wireguard/wgctrl/wgtypes"
+)
+
+type deviceServiceTest struct {
+	*servicetest.Fixture
+	service *deviceservice.DeviceService
+}
+
+func newDeviceServiceTest(t *testing.T) *deviceServiceTest {
+	t.Helper()
+
+	fixture := servicetest.New(t)
+
+	return &deviceServiceTest{
+		Fixture: fixture,
+		service: deviceservice.NewDeviceService(
+			zap.NewNop(), fixture.Backend, fixture.DeviceRepo, fixture.PeerRepo, fixture.AuditService,
+		),
+	}
+}
+
+func generateTestDevice(t *testing.T) *entity.Device {
+	t.Helper()
+
+	privateKey, err := wgtypes.GeneratePrivateKey()
+	require.NoError(t, err)
+
+	return &entity.Device{
+		ID:         uuid.New(),
+		Name:       "wg0",
+		Endpoint:   "203.0.113.1:51820",
+		Address:    "10.6.0.1/24",
+		MTU:        1420,
+		PrivateKey: privateKey,
+		PublicKey:  privateKey.PublicKey(),
+	}
+}
+
+func generateTestPeer(t *testing.T, dev *entity.Device, addr string, enabled bool) *entity.Peer {
+	t.Helper()
+
+	privateKey, err := wgtypes.GeneratePrivateKey()
+	require.NoError(t, err)
+
+	return &entity.Peer{
+		ID:         uuid.New(),
+		DeviceID:   dev.ID,
+		Name:       "peer",
+		PrivateKey: privateKey,
+		PublicKey:  privateKey.PublicKey(),
+		AllowedIPs: []string{addr},
+		IsEnabled:  enabled,
+	}
+}
+
+func configuredKeys(t *testing.T, backend app.InterfaceBackend, name string) []wgtypes.Key {
+	t.Helper()
+
+	wgdev, err := backend.Device(name)
+	require.NoError(t, err)
+
+	keys := make([]wgtypes.Key, 0, len(wgdev.Peers))
+	for _, peer := range wgdev.Peers {
+		keys = append(keys, peer.PublicKey)
+	}
+
+	return keys
+}
+
+func TestDeviceService_SyncDevices(t *testing.T) {
+	test := newDeviceServiceTest(t)
+
+	dev := generateTestDevice(t)
+	enabled := generateTestPeer(t, dev, "10.6.0.2/24", true)
+	disabled := generateTestPeer(t, dev, "10.6.0.3/24", false)
+	missing := generateTestPeer(t, dev, "10.6.0.4/24", true)
+	unmanaged := generateTestPeer(t, dev, "10.6.0.5/24", true)
+
+	// interface kept running while the service was down, the enabled peer has a live session
+	endpoint := &net.UDPAddr{IP: net.IPv4(198, 51, 100, 7), Port: 41000}
+
+	require.NoError(t, test.Backend.Create(dev))
+	require.NoError(t, test.Backend.Configure(dev.Name, wgtypes.Config{
+		Peers: []wgtypes.PeerConfig{
+			{PublicKey: enabled.PublicKey, Endpoint: endpoint},
+			{PublicKey: disabled.PublicKey},
+			{PublicKey: unmanaged.PublicKey},
+		},
+	}))
+
+	test.DeviceRepo.EXPECT().GetAll(gomock.Any(), gomock.Any(), 0, 0, "").Return([]*entity.Device{dev}, nil)
+	test.PeerRepo.EXPECT().GetAll(gomock.Any(), gomock.Any(), 0, 0, "", dev.ID).
+		Return([]*entity.Peer{enabled, disabled, missing}, nil)
+
+	require.NoError(t, test.service.SyncDevices(context.Background()))
+
+	wgdev, err := test.Backend.Device(dev.Name)
+	require.NoError(t, err)
+	require.Equal(t, dev.PrivateKey, wgdev.PrivateKey)
+	require.Equal(t, 51820, wgdev.ListenPort)
+	// running interface matching the device is not recreated
+	require.Equal(t, 1, test.Backend.Created(dev.Name))
+
+	// enabled peers are upserted and disabled ones removed, peers unknown to the database are kept
+	require.ElementsMatch(t,
+		[]wgtypes.Key{enabled.PublicKey, missing.PublicKey, unmanaged.PublicKey},
+		configuredKeys(t, test.Backend, dev.Name),
+	)
+
+	for _, wgpeer := range wgdev.Peers {
+		if wgpeer.PublicKey == enabled.PublicKey {
+			require.Equal(t, endpoint.String(), wgpeer.Endpoint.String())
+			require.Equal(t, "10.6.0.2/32", wgpeer.AllowedIPs[0].String())
+		}
+	}
+}
+
+func TestDeviceService_SyncDevicesRecreatesChanged(t *testing.T) {
+	test := newDeviceServiceTest(t)
+
+	dev := generateTestDevice(t)
+
+	running := *dev
+	running.Endpoint = "203.0.113.1:51821"
+	require.NoError(t, test.Backend.Create(&running))
+
+	test.DeviceRepo.EXPECT().GetAll(gomock.Any(), gomock.Any(), 0, 0, "").Return([]*entity.Device{dev}, nil)
+	test.PeerRepo.EXPECT().GetAll(gomock.Any(), gomock.Any(), 0, 0, "", dev.ID).Return([]*entity.Peer{}, nil)
+
+	require.NoError(t, test.service.SyncDevices(context.Background()))
+
+	wgdev, err := test.Backend.Device(dev.Name)
+	require.NoError(t, err)
+	require.Equal(t, 2, test.Backend.Created(dev.Name))
+	require.Equal(t, 51820, wgdev.ListenPort)
+}
+
+func TestDeviceService_Reconcile(t *testing.T) {
+	test := newDeviceServiceTest(t)
+	ctx := context.Background()
+
+	dev := generateTestDevice(t)
+	missing := generateTestPeer(t, dev, "10.6.0.2/24", true)
+	unknown := generateTestPeer(t, dev, "10.6.0.3/24", true)
+
+	unmanaged := generateTestDevice(t)
+	unmanaged.Name = "wg9"
+
+	require.NoError(t, test.Backend.Create(dev))
+	require.NoError(t, test.Backend.Create(unmanaged))
+	require.NoError(t, test.Backend.Configure(dev.Name, wgtypes.Config{
+		Peers: []wgtypes.PeerConfig{{PublicKey: unknown.PublicKey}},
+	}))
+
+	test.DeviceRepo.EXPECT().GetAll(gomock.Any(), gomock.Any(), 0, 0, "").Return([]*entity.Device{dev}, nil).AnyTimes()
+	test.PeerRepo.EXPECT().GetAll(gomock.Any(), gomock.Any(), 0, 0, "", dev.ID).
+		Return([]*entity.Peer{missing}, nil).AnyTimes()
+
+	_, err := test.service.LastReconcileReport()
+	require.ErrorIs(t, err, deviceservice.ErrNoReconcileReport)
+
+	// drift seen once might be a change being applied right now
+	report := test.service.Reconcile(ctx, false)
+	require.Empty(t, report.Errors)
+	require.Equal(t, []string{"wg9"}, report.UnmanagedInterfaces)
+	require.Len(t, report.Drifts, 2)
+	require.Equal(t, entity.DriftPeerMissing, report.Drifts[0].Kind)
+	require.Equal(t, missing.ID, report.Drifts[0].PeerID)
+	require.Equal(t, entity.DriftPeerUnknown, report.Drifts[1].Kind)
+	require.Equal(t, unknown.PublicKey, report.Drifts[1].PublicKey)
+	require.False(t, report.Drifts[0].Fixed)
+	require.False(t, report.Drifts[1].Fixed)
+	require.Equal(t, []wgtypes.Key{unknown.PublicKey}, configuredKeys(t, test.Backend, dev.Name))
+
+	report = test.service.Reconcile(ctx, false)
+	require.Len(t, report.Drifts, 2)
+	require.True(t, report.Drifts[0].Fixed)
+	require.True(t, report.Drifts[1].Fixed)
+	require.Equal(t, []wgtypes.Key{missing.PublicKey}, configuredKeys(t, test.Backend, dev.Name))
+
+	report = test.service.Reconcile(ctx, false)
+	require.Empty(t, report.Drifts)
+
+	last, err := test.service.LastReconcileReport()
+	require.NoError(t, err)
+	require.Equal(t, report.StartedAt, last.StartedAt)
+
+	// unmanaged interfaces are never touched
+	_, err = test.Backend.Device(unmanaged.Name)
+	require.NoError(t, err)
+}
+
+func TestDeviceService_ReconcileMissingInterface(t *testing.T) {
+	test := newDeviceServiceTest(t)
+	ctx := context.Background()
+
+	dev := generateTestDevice(t)
+	peer := generateTestPeer(t, dev, "10.6.0.2/24", true)
+
+	test.DeviceRepo.EXPECT().GetAll(gomock.Any(), gomock.Any(), 0, 0, "").Return([]*entity.Device{dev}, nil).AnyTimes()
+	test.PeerRepo.EXPECT().GetAll(gomock.Any(), gomock.Any(), 0, 0, "", dev.ID).
+		Return([]*entity.Peer{peer}, nil).AnyTimes()
+
+	for i := 0; i < 2; i++ {
+		report := test.service.Reconcile(ctx, true)
+		require.Len(t, report.Drifts, 1)
+		require.Equal(t, entity.DriftInterfaceMissing, report.Drifts[0].Kind)
+		require.False(t, report.Drifts[0].Fixed)
+	}
+
+	_, err := test.Backend.Device(dev.Name)
+	require.Error(t, err)
+
+	// peers come back along with the recreated interface
+	report := test.service.Reconcile(ctx, false)
+	require.Len(t, report.Drifts, 2)
+	require.True(t, report.Drifts[0].Fixed)
+	require.Equal(t, entity.DriftPeerMissing, report.Drifts[1].Kind)
+	require.True(t, report.Drifts[1].Fixed)
+	require.Equal(t, []wgtypes.Key{peer.PublicKey}, configuredKeys(t, test.Backend, dev.Name))
+}
+
+func TestDeviceService_RotateKey(t *testing.T) {
+	test := newDeviceServiceTest(t)
+
+	dev := generateTestDevice(t)
+	peer := generateTestPeer(t, dev, "10.6.0.2/24", true)
+	oldKey := dev.PrivateKey
+
+	require.NoError(t, test.Backend.Create(dev))
+	peerConfig, err := peer.ToPeerConfig(dev)
+	require.NoError(t, err)
+	require.NoError(t, test.Backend.Configure(dev.Name, wgtypes.Config{Peers: []wgtypes.PeerConfig{*peerConfig}}))
+
+	test.DeviceRepo.EXPECT().Get(gomock.Any(), gomock.Any(), dev.ID).Return(dev, nil)
+	test.DeviceRepo.EXPECT().UpdateKey(gomock.Any(), gomock.Any(), dev).
+		DoAndReturn(func(context.Context, *sqlx.Tx, *entity.Device) error {
+			// the interface is restarted with the new key once it is stored
+			wgdev, err := test.Backend.Device(dev.Name)
+			require.NoError(t, err)
+			require.Equal(t, oldKey, wgdev.PrivateKey)
+			return nil
+		})
+	test.PeerRepo.EXPECT().GetAll(gomock.Any(), gomock.Any(), 0, 0, "", dev.ID).Return([]*entity.Peer{peer}, nil)
+
+	resp, err := test.service.RotateKey(context.Background(), dto.RotateDeviceKeyDTO{ID: dev.ID})
+	require.NoError(t, err)
+	require.NotEqual(t, oldKey, resp.Device.PrivateKey)
+	require.Equal(t, []*entity.Peer{peer}, resp.Peers)
+
+	wgdev, err := test.Backend.Device(dev.Name)
+	require.NoError(t, err)
+	require.Equal(t, resp.Device.PrivateKey, wgdev.PrivateKey)
+	require.Equal(t, []wgtypes.Key{peer.PublicKey}, configuredKeys(t, test.Backend, dev.Name))
+	require.Equal(t, []net.IPNet{peerConfig.AllowedIPs[0]}, wgdev.Peers[0].AllowedIPs)
+}
+
+func TestDeviceService_RotateKeyWithCutover(t *testing.T) {
+	test := newDeviceServiceTest(t)
+
+	dev := generateTestDevice(t)
+	peer := generateTestPeer(t, dev, "10.6.0.2/24", true)
+	oldKey := dev.PrivateKey
+	cutoverAt := time.Now().Add(time.Hour)
+
+	require.NoError(t, test.Backend.Create(dev))
+
+	test.DeviceRepo.EXPECT().Get(gomock.Any(), gomock.Any(), dev.ID).Return(dev, nil)
+	test.DeviceRepo.EXPECT().UpdateKey(gomock.Any(), gomock.Any(), dev).Return(nil)
+	test.PeerRepo.EXPECT().GetAll(gomock.Any(), gomock.Any(), 0, 0, "", dev.ID).Return([]*entity.Peer{peer}, nil)
+
+	resp, err := test.service.RotateKey(context.Background(), dto.RotateDeviceKeyDTO{ID: dev.ID, CutoverAt: cutoverAt})
+	require.NoError(t, err)
+	require.Equal(t, oldKey, resp.Device.PrivateKey)
+	require.True(t, resp.Device.HasPendingKey())
+	require.NotEqual(t, oldKey, resp.Device.PendingPrivateKey)
+	require.Equal(t, resp.Device.PendingPrivateKey.PublicKey(), resp.Device.PendingPublicKey)
+	require.Equal(t, cutoverAt, resp.Device.KeyCutoverAt)
+	require.Equal(t, []*entity.Peer{peer}, resp.Peers)
+
+	// interface keeps the current key until cutover
+	wgdev, err := test.Backend.Device(dev.Name)
+	require.NoError(t, err)
+	require.Equal(t, oldKey, wgdev.PrivateKey)
+}
+
+func TestDeviceService_RotateKeyPastCutover(t *testing.T) {
+	test := newDeviceServiceTest(t)
+
+	_, err := test.service.RotateKey(context.Background(), dto.RotateDeviceKeyDTO{
+		ID:        uuid.New(),
+		CutoverAt: time.Now().Add(-time.Minute),
+	})
+
+	errInvalidData := &common.ErrInvalidData{}
+	require.ErrorAs(t, err, errInvalidData)
+	require.Equal(t, "cutover_at", errInvalidData.Details().GetFieldViolations()[0].GetField())
+}
+
+func importConfig(t *testing.T) string {
+	t.Helper()
+
+	privateKey, err := wgtypes.GeneratePrivateKey()
+	require.NoError(t, err)
+
+	return "[Interface]\nPrivateKey = " + privateKey.String() + "\nAddress = 10.6.0.1/24\nListenPort = 51820\n"
+}
+
+func TestDeviceService_ImportEndpointPort(t *testing.T) {
+	test := newDeviceServiceTest(t)
+
+	// endpoint port other than the listen port would change the port on the next restart
+	_, err := test.service.Import(context.Background(), dto.ImportDeviceDTO{
+		Name:     "wg0",
+		Config:   importConfig(t),
+		Endpoint: "203.0.113.1:443",
+	})
+
+	errInvalidData := &common.ErrInvalidData{}
+	require.ErrorAs(t, err, errInvalidData)
+	require.Equal(t, "endpoint", errInvalidData.Details().GetFieldViolations()[0].GetField())
+	require.Contains(t, errInvalidData.Details().GetFieldViolations()[0].GetDescription(), "51820")
+}
+
+func TestDeviceService_ImportExists(t *testing.T) {
+	test := newDeviceServiceTest(t)
+
+	test.ExpectRollback(test.DeviceRepo.EXPECT().BeginTxx(gomock.Any(), gomock.Any()))
+	test.DeviceRepo.EXPECT().Add(gomock.Any(), gomock.Not(gomock.Nil()), gomock.Any()).
+		Return(nil, fmt.Errorf("device repo: %w", repo.ErrDeviceExists))
+
+	_, err := test.service.Import(context.Background(), dto.ImportDeviceDTO{
+		Name:     "wg0",
+		Config:   importConfig(t),
+		Endpoint: "203.0.113.1",
+	})
+	require.ErrorIs(t, err, deviceservice.ErrDeviceExists)
+
+	_, err = test.Backend.Device("wg0")
+	require.Error(t, err)
+}
+
+func TestDeviceService_Update(t *testing.T) {
+	test := newDeviceServiceTest(t)
+
+	dev := generateTestDevice(t)
+	require.NoError(t, test.Backend.Create(dev))
+
+	test.DeviceRepo.EXPECT().Get(gomock.Any(), gomock.Any(), dev.ID).Return(dev, nil)
+	test.DeviceRepo.EXPECT().Update(gomock.Any(), gomock.Any(), dev).
+		DoAndReturn(func(_ context.Context, _ *sqlx.Tx, dev *entity.Device) (*entity.Device, error) {
+			// the interface is not touched until the device is stored
+			require.Equal(t, 1, test.Backend.Created(dev.Name))
+			return dev, nil
+		})
+
+	updated, err := test.service.Update(context.Background(), dto.UpdateDeviceDTO{ID: dev.ID, MTU: 1380},
+		fieldmask_utils.Mask{"MTU": fieldmask_utils.Mask{}})
+	require.NoError(t, err)
+	require.Equal(t, 1380, updated.MTU)
+	require.Equal(t, 2, test.Backend.Created(dev.Name))
+}
+
+func TestDeviceService_UpdateFailed(t *testing.T) {
+	test := newDeviceServiceTest(t)
+
+	dev := generateTestDevice(t)
+	require.NoError(t, test.Backend.Create(dev))
+
+	test.DeviceRepo.EXPECT().Get(gomock.Any(), gomock.Any(), dev.ID).Return(dev, nil)
+	test.DeviceRepo.EXPECT().Update(gomock.Any(), gomock.Any(), dev).Return(nil, repo.ErrDeviceExists)
+
+	_, err := test.service.Update(context.Background(), dto.UpdateDeviceDTO{ID: dev.ID, MTU: 1380},
+		fieldmask_utils.Mask{"MTU": fieldmask_utils.Mask{}})
+	require.ErrorIs(t, err, deviceservice.ErrDeviceExists)
+	// interface keeps settings of the stored device
+	require.Equal(t, 1, test.Backend.Created(dev.Name))
+}
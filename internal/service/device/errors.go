@@ -2,10 +2,16 @@
 
 import (
 	"errors"
+
+	"github.com/AZhur771/wg-grpc-api/internal/repo"
 )
 
 var (
 	ErrPeerNotConfigured       = errors.New("peer not configured")
 	ErrInvalidPaginationParams = errors.New("invalid pagination params")
 	ErrInvalidDeviceData       = errors.New("invalid device data")
+	ErrNoReconcileReport       = errors.New("no reconciliation has run yet")
+
+	// ErrDeviceExists is shared with the repo, so devices added concurrently are reported the same way.
+	ErrDeviceExists = repo.ErrDeviceExists
 )
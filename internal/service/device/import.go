@@ -0,0 +1,360 @@
+package deviceservice
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	dt "github.com/AZhur771/wg-grpc-api/internal/dto"
+	"github.com/AZhur771/wg-grpc-api/internal/entity"
+	"github.com/AZhur771/wg-grpc-api/internal/service/common"
+	tmpl "github.com/AZhur771/wg-grpc-api/internal/template"
+	"go.uber.org/zap"
+	"golang.zx2c4.com/wireguard/wgctrl/wgtypes"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+)
+
+// Import creates device with its peers from wg-quick config and takes over the interface.
+// Running interface is reconfigured in place so live sessions are kept, stopped one is brought up.
+// Private keys of imported peers are unknown to the server.
+func (ds *DeviceService) Import(ctx context.Context, dto dt.ImportDeviceDTO) (dt.ImportDeviceResponseDTO, error) {
+	resp := dt.ImportDeviceResponseDTO{}
+
+	invalid := func(field, description string) error {
+		return common.NewErrInvalidData(
+			fmt.Errorf("device service: %w", ErrInvalidDeviceData),
+			[]*errdetails.BadRequest_FieldViolation{
+				{
+					Field:       field,
+					Description: description,
+				},
+			},
+		)
+	}
+
+	if matched := re.MatchString(dto.Name); !matched {
+		return resp, invalid("name", "name should be a wireguard interface name like wg0")
+	}
+
+	data, err := tmpl.ParseConfig(strings.NewReader(dto.Config))
+	if err != nil {
+		return resp, invalid("config", err.Error())
+	}
+
+	dev, errors := deviceFromConfig(dto, data)
+	if len(errors) > 0 {
+		return resp, common.NewErrInvalidData(fmt.Errorf("device service: %w", ErrInvalidDeviceData), errors)
+	}
+
+	peers, errors := peersFromConfig(dev, data)
+	if len(errors) > 0 {
+		return resp, common.NewErrInvalidData(fmt.Errorf("device service: %w", ErrInvalidDeviceData), errors)
+	}
+
+	wgdev, err := ds.backend.Device(dev.Name)
+	running := err == nil
+
+	if running && wgdev.PrivateKey != dev.PrivateKey {
+		return resp, invalid("config", "private key does not match the running interface")
+	}
+
+	tx, err := ds.deviceRepo.BeginTxx(ctx, nil)
+	if err != nil {
+		return resp, fmt.Errorf("device service: %w", err)
+	}
+	defer tx.Rollback()
+
+	dev, err = ds.deviceRepo.Add(ctx, tx, dev)
+	if err != nil {
+		return resp, fmt.Errorf("device service: %w", err)
+	}
+
+	configs := make([]wgtypes.PeerConfig, 0, len(peers))
+
+	for _, peer := range peers {
+		peer.DeviceID = dev.ID
+
+		peer, err := ds.peerRepo.Add(ctx, tx, peer)
+		if err != nil {
+			return resp, fmt.Errorf("device service: %w", err)
+		}
+
+		peerConfig, err := peer.ToPeerConfig(dev)
+		if err != nil {
+			return resp, fmt.Errorf("device service: %w", err)
+		}
+
+		configs = append(configs, *peerConfig)
+		resp.Peers = append(resp.Peers, peer)
+	}
+
+	// running interface is taken over as is, recreating it would drop live sessions
+	if running {
+		ds.warnUnmanagedPeers(wgdev, configs)
+
+		if err := ds.ConfigurePeers(dev.Name, configs); err != nil {
+			return resp, fmt.Errorf("device service: %w", err)
+		}
+	} else {
+		if err := ds.setupDevice(dev); err != nil {
+			return resp, fmt.Errorf("device service: %w", err)
+		}
+
+		if err := ds.ConfigurePeers(dev.Name, configs); err != nil {
+			return resp, fmt.Errorf("device service: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return resp, fmt.Errorf("device service: %w", err)
+	}
+
+	ds.auditService.Record(ctx, entity.AuditActionAdd, entity.AuditEntityDevice, dev.ID, nil, dev.AuditFields())
+
+	for _, peer := range resp.Peers {
+		ds.auditService.Record(ctx, entity.AuditActionAdd, entity.AuditEntityPeer, peer.ID, nil, peer.AuditFields())
+	}
+
+	if wgdev, err = ds.backend.Device(dev.Name); err != nil {
+		return resp, fmt.Errorf("device service: %w", err)
+	}
+
+	if resp.Device, err = dev.PopulateDynamicFields(wgdev); err != nil {
+		return resp, fmt.Errorf("device service: %w", err)
+	}
+
+	return resp, nil
+}
+
+// warnUnmanagedPeers logs peers of the running interface missing in configs (e.g. in the imported config),
+// they are kept on the interface but are not managed by the service.
+func (ds *DeviceService) warnUnmanagedPeers(wgdev *wgtypes.Device, configs []wgtypes.PeerConfig) {
+	imported := make(map[wgtypes.Key]bool, len(configs))
+	for _, config := range configs {
+		imported[config.PublicKey] = true
+	}
+
+	for _, wgpeer := range wgdev.Peers {
+		if !imported[wgpeer.PublicKey] {
+			ds.logger.Warn("peer of the running interface is not managed by the service",
+				zap.String("device", wgdev.Name), zap.String("public_key", wgpeer.PublicKey.String()))
+		}
+	}
+}
+
+func deviceFromConfig(
+	dto dt.ImportDeviceDTO, data *tmpl.ConfigTmplData,
+) (*entity.Device, []*errdetails.BadRequest_FieldViolation) {
+	errors := make([]*errdetails.BadRequest_FieldViolation, 0)
+
+	dev := &entity.Device{
+		Name:         dto.Name,
+		Description:  dto.Description,
+		Endpoint:     dto.Endpoint,
+		FirewallMark: data.InterfaceFwMark,
+		MTU:          data.InterfaceMTU,
+		DNS:          data.InterfaceDNS,
+		Table:        data.InterfaceTable,
+		PreUp:        data.InterfacePreUp,
+		PostUp:       data.InterfacePostUp,
+		PreDown:      data.InterfacePreDown,
+		PostDown:     data.InterfacePostDown,
+	}
+
+	privateKey, err := wgtypes.ParseKey(data.InterfacePrivateKey)
+	if err != nil {
+		errors = append(errors, &errdetails.BadRequest_FieldViolation{
+			Field:       "config",
+			Description: "interface private key is not a valid base64 wireguard key",
+		})
+	}
+	dev.PrivateKey = privateKey
+	dev.PublicKey = privateKey.PublicKey()
+
+	for _, addr := range data.InterfaceAddress {
+		ip, _, err := net.ParseCIDR(addr)
+
+		switch {
+		case err != nil:
+			errors = append(errors, &errdetails.BadRequest_FieldViolation{
+				Field:       "config",
+				Description: fmt.Sprintf("interface address %s is not a valid CIDR address", addr),
+			})
+		case ip.To4() != nil && dev.Address == "":
+			dev.Address = addr
+		case ip.To4() == nil && dev.Address6 == "":
+			dev.Address6 = addr
+		default:
+			errors = append(errors, &errdetails.BadRequest_FieldViolation{
+				Field:       "config",
+				Description: "only one interface address per IP family is supported",
+			})
+		}
+	}
+
+	// IPv6-only device keeps its address in the main field
+	if dev.Address == "" {
+		dev.Address, dev.Address6 = dev.Address6, ""
+	}
+
+	// endpoint port is the listen port the interface is brought up with
+	_, port, err := net.SplitHostPort(dto.Endpoint)
+
+	switch {
+	case dto.Endpoint == "":
+	case err == nil && data.InterfacePort != "" && port != data.InterfacePort:
+		errors = append(errors, &errdetails.BadRequest_FieldViolation{
+			Field:       "endpoint",
+			Description: fmt.Sprintf("endpoint port should match listen port %s of the config", data.InterfacePort),
+		})
+	case err == nil:
+	case data.InterfacePort == "":
+		errors = append(errors, &errdetails.BadRequest_FieldViolation{
+			Field:       "endpoint",
+			Description: "endpoint port is required when config has no listen port",
+		})
+	default:
+		dev.Endpoint = net.JoinHostPort(dto.Endpoint, data.InterfacePort)
+	}
+
+	if dev.DNS == "" {
+		dev.DNS = "9.9.9.9, 149.112.112.112"
+	}
+
+	if dev.MTU == 0 {
+		// https://gist.github.com/nitred/f16850ca48c48c79bf422e90ee5b9d95
+		dev.MTU = 1420
+	}
+
+	return dev, append(errors, dev.IsValid()...)
+}
+
+// peersFromConfig maps config peers to entities, allowed IPs inside device networks become peer addresses
+// and the rest become routes behind the peer.
+func peersFromConfig(dev *entity.Device, data *tmpl.ConfigTmplData) ([]*entity.Peer, []*errdetails.BadRequest_FieldViolation) {
+	errors := make([]*errdetails.BadRequest_FieldViolation, 0)
+	peers := make([]*entity.Peer, 0, len(data.InterfacePeers))
+
+	deviceNetworks := make([]*net.IPNet, 0)
+	for _, addr := range dev.Addresses() {
+		if _, ipnet, err := net.ParseCIDR(addr); err == nil {
+			deviceNetworks = append(deviceNetworks, ipnet)
+		}
+	}
+
+	publicKeys := make(map[wgtypes.Key]bool)
+	addresses := make(map[string]bool)
+	routes := make([]string, 0)
+
+	for i, config := range data.InterfacePeers {
+		peerErrors := make([]*errdetails.BadRequest_FieldViolation, 0)
+
+		peer := &entity.Peer{
+			Name:                        config.PeerName,
+			PersistentKeepaliveInterval: time.Duration(config.PeerPersistentKeepalive) * time.Second,
+			DNS:                         "9.9.9.9, 149.112.112.112",
+			// https://gist.github.com/nitred/f16850ca48c48c79bf422e90ee5b9d95
+			MTU:       1384,
+			IsEnabled: true,
+			Usage: entity.PeerUsage{
+				PeriodStart: time.Now(),
+			},
+		}
+
+		if peer.Name == "" {
+			peer.Name = fmt.Sprintf("peer%d", i+1)
+		}
+
+		publicKey, err := wgtypes.ParseKey(config.PeerPublicKey)
+		if err != nil || publicKeys[publicKey] {
+			peerErrors = append(peerErrors, &errdetails.BadRequest_FieldViolation{
+				Field:       "public_key",
+				Description: "public key should be a valid base64 wireguard key unique within the config",
+			})
+		}
+		publicKeys[publicKey] = true
+		peer.PublicKey = publicKey
+
+		if config.PeerPresharedKey != "" {
+			presharedKey, err := wgtypes.ParseKey(config.PeerPresharedKey)
+			if err != nil {
+				peerErrors = append(peerErrors, &errdetails.BadRequest_FieldViolation{
+					Field:       "preshared_key",
+					Description: "preshared key is not a valid base64 wireguard key",
+				})
+			}
+			peer.PresharedKey = presharedKey
+			peer.HasPresharedKey = true
+		}
+
+		hostAddrs := make([]string, 0)
+
+		for _, allowedIP := range config.PeerAllowedIPs {
+			ip, ipnet, err := net.ParseCIDR(allowedIP)
+			if err != nil {
+				peerErrors = append(peerErrors, &errdetails.BadRequest_FieldViolation{
+					Field:       "allowed_ips",
+					Description: fmt.Sprintf("%s is not a valid CIDR address", allowedIP),
+				})
+				continue
+			}
+
+			if ones, bits := ipnet.Mask.Size(); ones == bits && containsIP(deviceNetworks, ip) {
+				hostAddrs = append(hostAddrs, allowedIP)
+			} else {
+				peer.ExtraRoutes = append(peer.ExtraRoutes, allowedIP)
+			}
+		}
+
+		staticAddrs, addrErrors := dev.StaticPeerAddresses(hostAddrs)
+		peerErrors = append(peerErrors, addrErrors...)
+
+		for _, cidr := range dev.Addresses() {
+			addr, ok := staticAddrs[cidr]
+			if !ok {
+				continue
+			}
+
+			if addresses[addr] {
+				peerErrors = append(peerErrors, &errdetails.BadRequest_FieldViolation{
+					Field:       "addresses",
+					Description: fmt.Sprintf("%s is used by another peer", addr),
+				})
+			}
+			addresses[addr] = true
+			peer.AllowedIPs = append(peer.AllowedIPs, addr)
+		}
+
+		if len(peer.AllowedIPs) == 0 {
+			peerErrors = append(peerErrors, &errdetails.BadRequest_FieldViolation{
+				Field:       "addresses",
+				Description: "peer should have a host address in device networks",
+			})
+		}
+
+		peerErrors = append(peerErrors, peer.IsValid()...)
+		peerErrors = append(peerErrors, peer.ValidateExtraRoutes(dev, routes)...)
+		routes = append(routes, peer.ExtraRoutes...)
+
+		for _, peerError := range peerErrors {
+			peerError.Field = fmt.Sprintf("peers[%d].%s", i, peerError.Field)
+		}
+
+		errors = append(errors, peerErrors...)
+		peers = append(peers, peer)
+	}
+
+	return peers, errors
+}
+
+func containsIP(networks []*net.IPNet, ip net.IP) bool {
+	for _, network := range networks {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
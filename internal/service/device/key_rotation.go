@@ -0,0 +1,141 @@
+package deviceservice
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	dt "github.com/AZhur771/wg-grpc-api/internal/dto"
+	"github.com/AZhur771/wg-grpc-api/internal/entity"
+	"github.com/AZhur771/wg-grpc-api/internal/service/common"
+	"go.uber.org/zap"
+	"golang.zx2c4.com/wireguard/wgctrl/wgtypes"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+)
+
+// RotateKey generates a new device keypair and reports peers which need fresh configs.
+// New key is applied to the interface right away unless cutover time is given,
+// in that case the current key stays on the interface and in peer configs until cutover.
+func (ds *DeviceService) RotateKey(ctx context.Context, dto dt.RotateDeviceKeyDTO) (dt.RotateDeviceKeyResponseDTO, error) {
+	resp := dt.RotateDeviceKeyResponseDTO{}
+
+	if !dto.CutoverAt.IsZero() && !dto.CutoverAt.After(time.Now()) {
+		return resp, common.NewErrInvalidData(
+			fmt.Errorf("device service: %w", ErrInvalidDeviceData),
+			[]*errdetails.BadRequest_FieldViolation{
+				{
+					Field:       "cutover_at",
+					Description: "cutover_at should be in the future",
+				},
+			},
+		)
+	}
+
+	dev, err := ds.deviceRepo.Get(ctx, nil, dto.ID)
+	if err != nil {
+		return resp, fmt.Errorf("device service: %w", err)
+	}
+
+	privateKey, err := wgtypes.GeneratePrivateKey()
+	if err != nil {
+		return resp, fmt.Errorf("device service: %w", err)
+	}
+
+	if dto.CutoverAt.IsZero() {
+		if err := ds.switchKey(ctx, dev, privateKey); err != nil {
+			return resp, err
+		}
+	} else {
+		before := dev.AuditFields()
+
+		dev.SetPendingKey(privateKey, dto.CutoverAt)
+
+		if err := ds.deviceRepo.UpdateKey(ctx, nil, dev); err != nil {
+			return resp, fmt.Errorf("device service: %w", err)
+		}
+
+		ds.auditService.Record(ctx, entity.AuditActionRotateKeys, entity.AuditEntityDevice, dev.ID, before, dev.AuditFields())
+	}
+
+	peers, err := ds.peerRepo.GetAll(ctx, nil, 0, 0, "", dev.ID)
+	if err != nil {
+		return resp, fmt.Errorf("device service: %w", err)
+	}
+
+	wgdev, err := ds.backend.Device(dev.Name)
+	if err != nil {
+		return resp, fmt.Errorf("device service: %w", err)
+	}
+
+	if resp.Device, err = dev.PopulateDynamicFields(wgdev); err != nil {
+		return resp, fmt.Errorf("device service: %w", err)
+	}
+
+	resp.Peers = peers
+
+	return resp, nil
+}
+
+// RunKeyCutover applies pending device keys right away and then every interval until ctx is done.
+func (ds *DeviceService) RunKeyCutover(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := ds.ApplyPendingKeys(ctx); err != nil {
+			ds.logger.Error("scheduler: failed to apply pending device keys", zap.Error(err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// ApplyPendingKeys switches interfaces of devices which cutover time has passed to their pending keys.
+func (ds *DeviceService) ApplyPendingKeys(ctx context.Context) error {
+	devices, err := ds.deviceRepo.GetAll(ctx, nil, 0, 0, "")
+	if err != nil {
+		return fmt.Errorf("device service: %w", err)
+	}
+
+	now := time.Now()
+
+	for _, dev := range devices {
+		if !dev.HasPendingKey() || dev.KeyCutoverAt.After(now) {
+			continue
+		}
+
+		if err := ds.switchKey(ctx, dev, dev.PendingPrivateKey); err != nil {
+			ds.logger.Error("scheduler: failed to apply pending device key",
+				zap.String("device", dev.Name), zap.Error(err))
+			continue
+		}
+
+		ds.logger.Info("scheduler: pending device key applied", zap.String("device", dev.Name))
+	}
+
+	return nil
+}
+
+// switchKey persists the new device key and restarts the interface with it, configured peers are kept.
+// The key is stored before the restart, interface failed to restart is brought in line by the reconciler.
+func (ds *DeviceService) switchKey(ctx context.Context, dev *entity.Device, privateKey wgtypes.Key) error {
+	before := dev.AuditFields()
+
+	dev.SetKey(privateKey)
+
+	if err := ds.deviceRepo.UpdateKey(ctx, nil, dev); err != nil {
+		return fmt.Errorf("device service: %w", err)
+	}
+
+	ds.auditService.Record(ctx, entity.AuditActionRotateKeys, entity.AuditEntityDevice, dev.ID, before, dev.AuditFields())
+
+	if err := ds.setupDevice(dev); err != nil {
+		return fmt.Errorf("device service: %w", err)
+	}
+
+	return nil
+}
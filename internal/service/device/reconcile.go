@@ -0,0 +1,271 @@
+package deviceservice
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"time"
+
+	"github.com/AZhur771/wg-grpc-api/internal/entity"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"golang.zx2c4.com/wireguard/wgctrl/wgtypes"
+)
+
+// RunReconciler reconciles kernel state with the database right away and then every interval until ctx is done.
+func (ds *DeviceService) RunReconciler(ctx context.Context, interval time.Duration, driftOnly bool) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		report := ds.Reconcile(ctx, driftOnly)
+
+		for _, drift := range report.Drifts {
+			ds.logger.Warn("reconciler: drift found",
+				zap.String("kind", drift.Kind.String()), zap.String("device", drift.Device),
+				zap.String("public_key", drift.PublicKey.String()), zap.Bool("fixed", drift.Fixed))
+		}
+
+		for _, err := range report.Errors {
+			ds.logger.Error("reconciler: failed to reconcile", zap.String("error", err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Reconcile compares devices and peers in the database with wireguard interfaces and fixes the difference
+// unless driftOnly is set. Drift has to be seen by two consecutive passes before it is fixed,
+// so changes being applied by API calls at the same moment are not undone.
+func (ds *DeviceService) Reconcile(ctx context.Context, driftOnly bool) entity.ReconcileReport {
+	ds.reconcileMu.Lock()
+	defer ds.reconcileMu.Unlock()
+
+	report := entity.ReconcileReport{
+		StartedAt: time.Now(),
+		DriftOnly: driftOnly,
+		Drifts:    make([]entity.Drift, 0),
+	}
+
+	defer func() {
+		report.FinishedAt = time.Now()
+
+		ds.reportMu.Lock()
+		ds.lastReport = &report
+		ds.reportMu.Unlock()
+	}()
+
+	devices, err := ds.deviceRepo.GetAll(ctx, nil, 0, 0, "")
+	if err != nil {
+		report.Errors = append(report.Errors, err.Error())
+		return report
+	}
+
+	wgdevs, err := ds.backend.Devices()
+	if err != nil {
+		report.Errors = append(report.Errors, err.Error())
+		return report
+	}
+
+	interfaces := make(map[string]*wgtypes.Device, len(wgdevs))
+	for _, wgdev := range wgdevs {
+		interfaces[wgdev.Name] = wgdev
+	}
+
+	seen := make(map[string]bool)
+
+	for _, dev := range devices {
+		drifts, err := ds.reconcileDevice(ctx, dev, interfaces[dev.Name], driftOnly, seen)
+		report.Drifts = append(report.Drifts, drifts...)
+
+		if err != nil {
+			report.Errors = append(report.Errors, fmt.Sprintf("%s: %s", dev.Name, err.Error()))
+		}
+
+		delete(interfaces, dev.Name)
+	}
+
+	for name := range interfaces {
+		report.UnmanagedInterfaces = append(report.UnmanagedInterfaces, name)
+	}
+	sort.Strings(report.UnmanagedInterfaces)
+
+	ds.seenDrift = seen
+
+	return report
+}
+
+// LastReconcileReport returns result of the latest reconciliation pass.
+func (ds *DeviceService) LastReconcileReport() (*entity.ReconcileReport, error) {
+	ds.reportMu.RLock()
+	defer ds.reportMu.RUnlock()
+
+	if ds.lastReport == nil {
+		return nil, fmt.Errorf("device service: %w", ErrNoReconcileReport)
+	}
+
+	return ds.lastReport, nil
+}
+
+// reconcileDevice brings interface of the device in line with the database with a single configuration call,
+// missing interface is recreated along with all enabled peers.
+func (ds *DeviceService) reconcileDevice(
+	ctx context.Context, dev *entity.Device, wgdev *wgtypes.Device, driftOnly bool, seen map[string]bool,
+) ([]entity.Drift, error) {
+	drifts := make([]entity.Drift, 0)
+
+	peers, err := ds.peerRepo.GetAll(ctx, nil, 0, 0, "", dev.ID)
+	if err != nil {
+		return drifts, err
+	}
+
+	newDrift := func(kind entity.DriftKind, peerID uuid.UUID, publicKey wgtypes.Key) entity.Drift {
+		return entity.Drift{
+			Kind:      kind,
+			DeviceID:  dev.ID,
+			Device:    dev.Name,
+			PeerID:    peerID,
+			PublicKey: publicKey,
+		}
+	}
+
+	recreated := false
+
+	if wgdev == nil {
+		drift := newDrift(entity.DriftInterfaceMissing, uuid.Nil, wgtypes.Key{})
+
+		if confirmed := ds.confirmDrift(drift, seen); driftOnly || !confirmed {
+			return append(drifts, drift), nil
+		}
+
+		if err := ds.setupDevice(dev); err != nil {
+			return append(drifts, drift), err
+		}
+
+		drift.Fixed = true
+		drifts = append(drifts, drift)
+
+		// fresh interface has no peers, they are added right away as a part of the fix
+		recreated = true
+		wgdev = &wgtypes.Device{Name: dev.Name, PrivateKey: dev.PrivateKey}
+	}
+
+	apply := func(drift entity.Drift) bool {
+		confirmed := ds.confirmDrift(drift, seen)
+		return !driftOnly && (recreated || confirmed)
+	}
+
+	config := wgtypes.Config{}
+	fixed := make([]int, 0)
+
+	if wgdev.PrivateKey != dev.PrivateKey {
+		drift := newDrift(entity.DriftPrivateKey, uuid.Nil, wgtypes.Key{})
+		if apply(drift) {
+			config.PrivateKey = &dev.PrivateKey
+			fixed = append(fixed, len(drifts))
+		}
+		drifts = append(drifts, drift)
+	}
+
+	configured := make(map[wgtypes.Key]wgtypes.Peer, len(wgdev.Peers))
+	for _, wgpeer := range wgdev.Peers {
+		configured[wgpeer.PublicKey] = wgpeer
+	}
+
+	known := make(map[wgtypes.Key]uuid.UUID, len(peers))
+	enabled := make(map[wgtypes.Key]bool, len(peers))
+
+	for _, peer := range peers {
+		known[peer.PublicKey] = peer.ID
+
+		if !peer.IsEnabled {
+			continue
+		}
+		enabled[peer.PublicKey] = true
+
+		peerConfig, err := peer.ToPeerConfig(dev)
+		if err != nil {
+			return drifts, err
+		}
+
+		var kind entity.DriftKind
+
+		wgpeer, ok := configured[peer.PublicKey]
+
+		switch {
+		case !ok:
+			kind = entity.DriftPeerMissing
+		case !sameNetworks(wgpeer.AllowedIPs, peerConfig.AllowedIPs):
+			kind = entity.DriftPeerAllowedIPs
+		default:
+			continue
+		}
+
+		drift := newDrift(kind, peer.ID, peer.PublicKey)
+		if apply(drift) {
+			config.Peers = append(config.Peers, *peerConfig)
+			fixed = append(fixed, len(drifts))
+		}
+		drifts = append(drifts, drift)
+	}
+
+	for _, wgpeer := range wgdev.Peers {
+		if enabled[wgpeer.PublicKey] {
+			continue
+		}
+
+		drift := newDrift(entity.DriftPeerUnknown, known[wgpeer.PublicKey], wgpeer.PublicKey)
+		if apply(drift) {
+			config.Peers = append(config.Peers, wgtypes.PeerConfig{PublicKey: wgpeer.PublicKey, Remove: true})
+			fixed = append(fixed, len(drifts))
+		}
+		drifts = append(drifts, drift)
+	}
+
+	if len(fixed) == 0 {
+		return drifts, nil
+	}
+
+	if err := ds.backend.Configure(dev.Name, config); err != nil {
+		return drifts, err
+	}
+
+	for _, i := range fixed {
+		drifts[i].Fixed = true
+	}
+
+	return drifts, nil
+}
+
+// confirmDrift remembers drift for the next pass and reports whether the previous pass has seen it as well.
+func (ds *DeviceService) confirmDrift(drift entity.Drift, seen map[string]bool) bool {
+	key := fmt.Sprintf("%s/%s/%s", drift.DeviceID, drift.Kind, drift.PublicKey)
+	seen[key] = true
+
+	return ds.seenDrift[key]
+}
+
+func sameNetworks(a, b []net.IPNet) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	networks := make(map[string]int, len(a))
+	for _, ipnet := range a {
+		networks[ipnet.String()]++
+	}
+
+	for _, ipnet := range b {
+		if networks[ipnet.String()] == 0 {
+			return false
+		}
+		networks[ipnet.String()]--
+	}
+
+	return true
+}
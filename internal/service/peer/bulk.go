@@ -0,0 +1,135 @@
+package peerservice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	dt "github.com/AZhur771/wg-grpc-api/internal/dto"
+	"github.com/AZhur771/wg-grpc-api/internal/entity"
+	devicerepo "github.com/AZhur771/wg-grpc-api/internal/repo/device"
+	"github.com/AZhur771/wg-grpc-api/internal/service/common"
+	"golang.zx2c4.com/wireguard/wgctrl/wgtypes"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+)
+
+// BulkAdd adds peers to the device in a single transaction and configures them with a single call.
+// Entries failing validation are reported in their results and skipped, the rest are added.
+func (ps *PeerService) BulkAdd(ctx context.Context, dto dt.BulkAddPeersDTO) (dt.BulkAddPeersResponseDTO, error) {
+	resp := dt.BulkAddPeersResponseDTO{}
+
+	if errors := dto.IsValid(); len(errors) > 0 {
+		return resp, common.NewErrInvalidData(fmt.Errorf("peer service: %w", ErrInvalidPeerData), errors)
+	}
+
+	device, err := ps.deviceService.Get(ctx, dto.DeviceID)
+	if err != nil {
+		return resp, fmt.Errorf("peer service: %w", err)
+	}
+
+	tx, err := ps.peerRepo.BeginTxx(ctx, nil)
+	if err != nil {
+		return resp, fmt.Errorf("peer service: %w", err)
+	}
+	defer tx.Rollback()
+
+	resp.Results = make([]dt.BulkAddPeerResultDTO, 0, len(dto.Peers))
+	added := make([]*entity.Peer, 0, len(dto.Peers))
+
+	for i, entry := range dto.Peers {
+		result := dt.BulkAddPeerResultDTO{Index: i}
+
+		peer, err := newPeer(dt.AddPeerDTO{
+			DeviceID:        dto.DeviceID,
+			Name:            entry.Name,
+			Email:           entry.Email,
+			Description:     entry.Description,
+			AddPresharedKey: dto.AddPresharedKey,
+		})
+		if err != nil {
+			return resp, err
+		}
+
+		if result.Errors = peer.IsValid(); len(result.Errors) > 0 {
+			resp.Results = append(resp.Results, result)
+			continue
+		}
+
+		// dual-stack peers get an address from each device network
+		for _, cidr := range device.Addresses() {
+			addr, err := ps.assignAddress(ctx, tx, device, peer.ID, cidr, "")
+			if errors.Is(err, devicerepo.ErrRunOutOfAddresses) {
+				result.Errors = append(result.Errors, &errdetails.BadRequest_FieldViolation{
+					Field:       "addresses",
+					Description: fmt.Sprintf("no free addresses left in %s", cidr),
+				})
+				break
+			}
+
+			if err != nil {
+				return resp, err
+			}
+
+			peer.AllowedIPs = append(peer.AllowedIPs, addr)
+		}
+
+		if len(result.Errors) > 0 {
+			resp.Results = append(resp.Results, result)
+			continue
+		}
+
+		if result.Peer, err = ps.peerRepo.Add(ctx, tx, peer); err != nil {
+			return resp, fmt.Errorf("peer service: %w", err)
+		}
+
+		resp.Results = append(resp.Results, result)
+		added = append(added, result.Peer)
+	}
+
+	configs := make([]wgtypes.PeerConfig, 0, len(added))
+
+	for _, peer := range added {
+		peerConfig, err := peer.ToPeerConfig(device)
+		if err != nil {
+			return resp, fmt.Errorf("peer service: %w", err)
+		}
+
+		configs = append(configs, *peerConfig)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return resp, fmt.Errorf("peer service: %w", err)
+	}
+
+	for _, peer := range added {
+		ps.auditService.Record(ctx, entity.AuditActionAdd, entity.AuditEntityPeer, peer.ID, nil, peer.AuditFields())
+	}
+
+	resp.Added = len(added)
+
+	if len(configs) == 0 {
+		return resp, nil
+	}
+
+	if err := ps.deviceService.ConfigurePeers(device.Name, configs); err != nil {
+		return resp, fmt.Errorf("peer service: %w", err)
+	}
+
+	wgpeers, err := ps.deviceService.GetConfiguredPeers(device.Name)
+	if err != nil {
+		return resp, fmt.Errorf("peer service: %w", err)
+	}
+
+	configured := make(map[wgtypes.Key]wgtypes.Peer, len(wgpeers))
+	for _, wgpeer := range wgpeers {
+		configured[wgpeer.PublicKey] = wgpeer
+	}
+
+	for _, peer := range added {
+		if wgpeer, ok := configured[peer.PublicKey]; ok {
+			peer.PopulateDynamicFields(&wgpeer)
+		}
+	}
+
+	return resp, nil
+}
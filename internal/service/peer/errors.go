@@ -2,9 +2,16 @@
 
 import (
 	"errors"
+
+	"github.com/AZhur771/wg-grpc-api/internal/repo"
 )
 
 var (
 	ErrInvalidPaginationParams = errors.New("invalid pagination params")
 	ErrInvalidPeerData         = errors.New("invalid peer data")
+	ErrPrivateKeyUnknown       = errors.New("peer private key is unknown")
+	ErrPeerExpired             = errors.New("peer is expired")
+	ErrQuotaExceeded           = errors.New("peer quota is exceeded")
+	// ErrAddressTaken is shared with the repo, so addresses taken concurrently are reported the same way.
+	ErrAddressTaken = repo.ErrAddressTaken
 )
@@ -7,6 +7,7 @@
 	"net"
 	"strings"
 	"text/template"
+	"time"
 
 	"github.com/AZhur771/wg-grpc-api/internal/app"
 	dt "github.com/AZhur771/wg-grpc-api/internal/dto"
@@ -14,79 +15,57 @@
 	"github.com/AZhur771/wg-grpc-api/internal/service/common"
 	tmpl "github.com/AZhur771/wg-grpc-api/internal/template"
 	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
 	fieldmask_utils "github.com/mennanov/fieldmask-utils"
 	qrcode "github.com/skip2/go-qrcode"
 	"go.uber.org/zap"
 	"golang.zx2c4.com/wireguard/wgctrl/wgtypes"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 )
 
-const defaultLimit = 20
+const (
+	defaultLimit = 20
+
+	// privateKeyPlaceholder is rendered into configs of peers whose private key
+	// was generated on the client side and is unknown to the server.
+	privateKeyPlaceholder = "<PRIVATE_KEY>"
+)
 
 type PeerService struct {
 	logger        *zap.Logger
 	deviceService app.DeviceService
 	peerRepo      app.PeerRepo
 	deviceRepo    app.DeviceRepo
+	auditService  app.AuditService
 }
 
-func NewPeerService(logger *zap.Logger, deviceService app.DeviceService, deviceRepo app.DeviceRepo, peerRepo app.PeerRepo) *PeerService {
+func NewPeerService(
+	logger *zap.Logger, deviceService app.DeviceService, deviceRepo app.DeviceRepo, peerRepo app.PeerRepo,
+	auditService app.AuditService,
+) *PeerService {
 	return &PeerService{
 		logger:        logger,
 		deviceService: deviceService,
 		peerRepo:      peerRepo,
 		deviceRepo:    deviceRepo,
+		auditService:  auditService,
 	}
 }
 
 func (ps *PeerService) Add(ctx context.Context, dto dt.AddPeerDTO) (*entity.Peer, error) {
-	privateKey, err := wgtypes.GeneratePrivateKey()
+	peer, err := newPeer(dto)
 	if err != nil {
-		return nil, fmt.Errorf("peer service: %w", err)
+		return nil, err
 	}
 
-	publicKey := privateKey.PublicKey()
-
 	device, err := ps.deviceService.Get(ctx, dto.DeviceID)
 	if err != nil {
 		return nil, fmt.Errorf("peer service: %w", err)
 	}
 
-	_, ipnet, err := net.ParseCIDR(device.Address)
-	if err != nil {
-		return nil, fmt.Errorf("peer service: %w", err)
-	}
-
-	ones, _ := ipnet.Mask.Size()
-
-	peer := &entity.Peer{
-		DeviceID:                    dto.DeviceID,
-		PrivateKey:                  privateKey,
-		PublicKey:                   publicKey,
-		PersistentKeepaliveInterval: dto.PersistentKeepAlive,
-		Description:                 dto.Description,
-		Name:                        dto.Name,
-		Email:                       dto.Email,
-		DNS:                         dto.DNS,
-		MTU:                         dto.MTU,
-		IsEnabled:                   true,
-	}
-
-	if peer.DNS == "" {
-		peer.DNS = "9.9.9.9, 149.112.112.112"
-	}
-
-	if peer.MTU == 0 {
-		// https://gist.github.com/nitred/f16850ca48c48c79bf422e90ee5b9d95
-		peer.MTU = 1384
-	}
-
-	if dto.AddPresharedKey {
-		peer.HasPresharedKey = true
-		presharedKey, err := wgtypes.GenerateKey()
-		if err != nil {
-			return nil, fmt.Errorf("peer service: %w", err)
-		}
-		peer.PresharedKey = presharedKey
+	staticAddrs, errors := device.StaticPeerAddresses(dto.Addresses)
+	if len(errors) > 0 {
+		return nil, common.NewErrInvalidData(fmt.Errorf("peer service: %w", ErrInvalidPeerData), errors)
 	}
 
 	tx, err := ps.peerRepo.BeginTxx(ctx, nil)
@@ -95,16 +74,23 @@ func (ps *PeerService) Add(ctx context.Context, dto dt.AddPeerDTO) (*entity.Peer
 	}
 	defer tx.Rollback()
 
-	addr, err := ps.deviceRepo.GenerateAddress(ctx, tx, device)
-	if err != nil {
-		return nil, fmt.Errorf("peer service: %w", err)
+	// dual-stack peers get an address from each device network
+	for _, cidr := range device.Addresses() {
+		addr, err := ps.assignAddress(ctx, tx, device, peer.ID, cidr, staticAddrs[cidr])
+		if err != nil {
+			return nil, err
+		}
+		peer.AllowedIPs = append(peer.AllowedIPs, addr)
 	}
-	peer.AllowedIPs = []string{fmt.Sprintf("%s/%d", addr, ones)}
 
 	if errors := peer.IsValid(); len(errors) > 0 {
 		return nil, common.NewErrInvalidData(fmt.Errorf("peer service: %w", ErrInvalidPeerData), errors)
 	}
 
+	if err := ps.validateExtraRoutes(ctx, tx, peer, device); err != nil {
+		return nil, err
+	}
+
 	peer, err = ps.peerRepo.Add(ctx, tx, peer)
 	if err != nil {
 		return nil, fmt.Errorf("peer service: %w", err)
@@ -114,6 +100,8 @@ func (ps *PeerService) Add(ctx context.Context, dto dt.AddPeerDTO) (*entity.Peer
 		return nil, fmt.Errorf("peer service: %w", err)
 	}
 
+	ps.auditService.Record(ctx, entity.AuditActionAdd, entity.AuditEntityPeer, peer.ID, nil, peer.AuditFields())
+
 	peerConfig, err := peer.ToPeerConfig(device)
 	if err != nil {
 		return nil, fmt.Errorf("peer service: %w", err)
@@ -142,6 +130,12 @@ func (ps *PeerService) Update(ctx context.Context, dto dt.UpdatePeerDTO, mask fi
 		return nil, fmt.Errorf("peer service: %w", err)
 	}
 
+	before := peer.AuditFields()
+
+	// addresses have no counterpart in peer entity and are assigned separately
+	_, updateAddresses := mask.Get("Addresses")
+	delete(mask, "Addresses")
+
 	fieldmask_utils.StructToStruct(mask, dto, peer)
 
 	if peer.DNS == "" {
@@ -171,20 +165,62 @@ func (ps *PeerService) Update(ctx context.Context, dto dt.UpdatePeerDTO, mask fi
 		return nil, common.NewErrInvalidData(fmt.Errorf("peer service: %w", ErrInvalidPeerData), errors)
 	}
 
-	peerConfig, err := peer.ToPeerConfig(device)
+	tx, err := ps.peerRepo.BeginTxx(ctx, nil)
 	if err != nil {
 		return nil, fmt.Errorf("peer service: %w", err)
 	}
-	peerConfig.UpdateOnly = true
+	defer tx.Rollback()
 
-	if err := ps.deviceService.ConfigureDevice(device.Name, *peerConfig); err != nil {
+	if err := ps.validateExtraRoutes(ctx, tx, peer, device); err != nil {
+		return nil, err
+	}
+
+	if updateAddresses {
+		staticAddrs, errors := device.StaticPeerAddresses(dto.Addresses)
+		if len(errors) > 0 {
+			return nil, common.NewErrInvalidData(fmt.Errorf("peer service: %w", ErrInvalidPeerData), errors)
+		}
+
+		allowedIPs := make([]string, 0, len(device.Addresses()))
+
+		// networks without requested address keep the current one
+		for _, cidr := range device.Addresses() {
+			static, ok := staticAddrs[cidr]
+			if !ok {
+				static = addressInNetwork(peer.AllowedIPs, cidr)
+			}
+
+			addr, err := ps.assignAddress(ctx, tx, device, peer.ID, cidr, static)
+			if err != nil {
+				return nil, err
+			}
+			allowedIPs = append(allowedIPs, addr)
+		}
+
+		peer.AllowedIPs = allowedIPs
+	}
+
+	peer, err = ps.peerRepo.Update(ctx, tx, peer)
+	if err != nil {
+		return nil, fmt.Errorf("peer service: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
 		return nil, fmt.Errorf("peer service: %w", err)
 	}
 
-	peer, err = ps.peerRepo.Update(ctx, nil, peer)
+	ps.auditService.Record(ctx, entity.AuditActionUpdate, entity.AuditEntityPeer, peer.ID, before, peer.AuditFields())
+
+	// the interface is configured once the peer is stored, so it never runs settings the database lacks
+	peerConfig, err := peer.ToPeerConfig(device)
 	if err != nil {
 		return nil, fmt.Errorf("peer service: %w", err)
 	}
+	peerConfig.UpdateOnly = true
+
+	if err := ps.deviceService.ConfigureDevice(device.Name, *peerConfig); err != nil {
+		return nil, fmt.Errorf("peer service: %w", err)
+	}
 
 	wgpeer, err := ps.deviceService.GetConfiguredPeer(device.Name, peer.PublicKey)
 	if err != nil {
@@ -209,6 +245,8 @@ func (ps *PeerService) Remove(ctx context.Context, id uuid.UUID) error {
 		return fmt.Errorf("peer service: %w", err)
 	}
 
+	ps.auditService.Record(ctx, entity.AuditActionRemove, entity.AuditEntityPeer, peer.ID, peer.AuditFields(), nil)
+
 	if peer.IsEnabled {
 		peerConfig, err := peer.ToPeerConfig(device)
 		if err != nil {
@@ -292,6 +330,14 @@ func (ps *PeerService) Enable(ctx context.Context, id uuid.UUID) error {
 	}
 
 	if !peer.IsEnabled {
+		if peer.IsExpired(time.Now()) {
+			return fmt.Errorf("peer service: %w", ErrPeerExpired)
+		}
+
+		if peer.IsQuotaExceeded() {
+			return fmt.Errorf("peer service: %w", ErrQuotaExceeded)
+		}
+
 		peerConfig, err := peer.ToPeerConfig(device)
 		if err != nil {
 			return fmt.Errorf("peer service: %w", err)
@@ -305,11 +351,23 @@ func (ps *PeerService) Enable(ctx context.Context, id uuid.UUID) error {
 		if err = ps.deviceService.ConfigureDevice(device.Name, *peerConfig); err != nil {
 			return fmt.Errorf("peer service: %w", err)
 		}
+
+		before := peer.AuditFields()
 		peer.IsEnabled = true
 
 		if _, err := ps.peerRepo.Update(ctx, nil, peer); err != nil {
 			return fmt.Errorf("peer service: %w", err)
 		}
+
+		ps.auditService.Record(ctx, entity.AuditActionEnable, entity.AuditEntityPeer, peer.ID, before, peer.AuditFields())
+
+		// re-added peer starts with zero kernel counters
+		peer.Usage.LastReceiveBytes = 0
+		peer.Usage.LastTransmitBytes = 0
+
+		if err := ps.peerRepo.UpdateUsage(ctx, nil, peer); err != nil {
+			return fmt.Errorf("peer service: %w", err)
+		}
 	}
 
 	return nil
@@ -341,17 +399,155 @@ func (ps *PeerService) Disable(ctx context.Context, id uuid.UUID) error {
 		if err = ps.deviceService.ConfigureDevice(device.Name, *peerConfig); err != nil {
 			return fmt.Errorf("peer service: %w", err)
 		}
+
+		before := peer.AuditFields()
 		peer.IsEnabled = false
 
 		if _, err := ps.peerRepo.Update(ctx, nil, peer); err != nil {
 			return fmt.Errorf("peer service: %w", err)
 		}
+
+		ps.auditService.Record(ctx, entity.AuditActionDisable, entity.AuditEntityPeer, peer.ID, before, peer.AuditFields())
+	}
+
+	return nil
+}
+
+func (ps *PeerService) ResetQuota(ctx context.Context, id uuid.UUID) error {
+	peer, err := ps.peerRepo.Get(ctx, nil, id)
+	if err != nil {
+		return fmt.Errorf("peer service: %w", err)
+	}
+
+	peer.ResetUsage(time.Now())
+
+	if err := ps.peerRepo.UpdateUsage(ctx, nil, peer); err != nil {
+		return fmt.Errorf("peer service: %w", err)
 	}
 
+	ps.auditService.Record(ctx, entity.AuditActionResetQuota, entity.AuditEntityPeer, peer.ID, nil, nil)
+
 	return nil
 }
 
+// RotateKeys replaces peer keypair keeping its id, name and addresses,
+// the old public key is swapped for the new one on the interface at once after keys are stored.
+func (ps *PeerService) RotateKeys(ctx context.Context, dto dt.RotatePeerKeysDTO) (*entity.Peer, error) {
+	peer, err := ps.peerRepo.Get(ctx, nil, dto.ID)
+	if err != nil {
+		return nil, fmt.Errorf("peer service: %w", err)
+	}
+
+	device, err := ps.deviceService.Get(ctx, peer.DeviceID)
+	if err != nil {
+		return nil, fmt.Errorf("peer service: %w", err)
+	}
+
+	before := peer.AuditFields()
+	oldPublicKey := peer.PublicKey
+
+	if dto.PublicKey != "" {
+		publicKey, err := wgtypes.ParseKey(dto.PublicKey)
+		if err != nil || publicKey == oldPublicKey {
+			return nil, common.NewErrInvalidData(
+				fmt.Errorf("peer service: %w", ErrInvalidPeerData),
+				[]*errdetails.BadRequest_FieldViolation{
+					{
+						Field:       "public_key",
+						Description: "public key should be a valid base64 wireguard key different from the current one",
+					},
+				},
+			)
+		}
+		peer.PublicKey = publicKey
+		peer.PrivateKey = wgtypes.Key{}
+		peer.HasPrivateKey = false
+	} else {
+		privateKey, err := wgtypes.GeneratePrivateKey()
+		if err != nil {
+			return nil, fmt.Errorf("peer service: %w", err)
+		}
+		peer.PrivateKey = privateKey
+		peer.PublicKey = privateKey.PublicKey()
+		peer.HasPrivateKey = true
+	}
+
+	if dto.RotatePresharedKey {
+		presharedKey, err := wgtypes.GenerateKey()
+		if err != nil {
+			return nil, fmt.Errorf("peer service: %w", err)
+		}
+		peer.PresharedKey = presharedKey
+		peer.HasPresharedKey = true
+	}
+
+	// peer with the new key starts with zero kernel counters
+	peer.Usage.LastReceiveBytes = 0
+	peer.Usage.LastTransmitBytes = 0
+
+	tx, err := ps.peerRepo.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("peer service: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := ps.peerRepo.UpdateKeys(ctx, tx, peer); err != nil {
+		return nil, fmt.Errorf("peer service: %w", err)
+	}
+
+	if err := ps.peerRepo.UpdateUsage(ctx, tx, peer); err != nil {
+		return nil, fmt.Errorf("peer service: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("peer service: %w", err)
+	}
+
+	ps.auditService.Record(ctx, entity.AuditActionRotateKeys, entity.AuditEntityPeer, peer.ID, before, peer.AuditFields())
+
+	if !peer.IsEnabled {
+		return peer, nil
+	}
+
+	peerConfig, err := peer.ToPeerConfig(device)
+	if err != nil {
+		return nil, fmt.Errorf("peer service: %w", err)
+	}
+
+	configs := []wgtypes.PeerConfig{
+		{PublicKey: oldPublicKey, Remove: true},
+		*peerConfig,
+	}
+
+	// keys are already stored, so the interface is brought in line with the database by the reconciler
+	if err := ps.deviceService.ConfigurePeers(device.Name, configs); err != nil {
+		ps.logger.Error("failed to configure peer with rotated keys, it is left to the reconciler",
+			zap.String("id", peer.ID.String()), zap.Error(err))
+
+		return peer, nil
+	}
+
+	wgpeer, err := ps.deviceService.GetConfiguredPeer(device.Name, peer.PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("peer service: %w", err)
+	}
+
+	return peer.PopulateDynamicFields(&wgpeer), nil
+}
+
 func (ps *PeerService) DownloadConfig(ctx context.Context, id uuid.UUID) (dt.DownloadFileDTO, error) {
+	config, err := ps.renderConfig(ctx, id)
+	if err != nil {
+		return config, err
+	}
+
+	ps.auditService.Record(ctx, entity.AuditActionDownloadConfig, entity.AuditEntityPeer, id, nil, nil)
+
+	return config, nil
+}
+
+// renderConfig renders wireguard config of the peer, private key is replaced with a placeholder if it is unknown.
+func (ps *PeerService) renderConfig(ctx context.Context, id uuid.UUID) (dt.DownloadFileDTO, error) {
 	downloadFileDTO := dt.DownloadFileDTO{
 		Name: fmt.Sprintf("%s.conf", id.String()),
 	}
@@ -377,9 +573,14 @@ func (ps *PeerService) DownloadConfig(ctx context.Context, id uuid.UUID) (dt.Dow
 
 	var buf bytes.Buffer
 
+	privateKey := privateKeyPlaceholder
+	if peer.HasPrivateKey {
+		privateKey = peer.PrivateKey.String()
+	}
+
 	tmplData := tmpl.ConfigTmplData{
 		// interface data
-		InterfacePrivateKey: peer.PrivateKey.String(),
+		InterfacePrivateKey: privateKey,
 		InterfaceAddress:    peer.AllowedIPs,
 		InterfaceDNS:        peer.DNS,
 		InterfaceMTU:        peer.MTU,
@@ -389,7 +590,7 @@ func (ps *PeerService) DownloadConfig(ctx context.Context, id uuid.UUID) (dt.Dow
 				PeerPublicKey:           device.PublicKey.String(),
 				PeerPresharedKey:        peer.PresharedKey.String(),
 				PeerEndpoint:            device.Endpoint,
-				PeerAllowedIPs:          []string{"0.0.0.0/0"},
+				PeerAllowedIPs:          peer.EffectiveClientAllowedIPs(device),
 				PeerPersistentKeepalive: int(peer.PersistentKeepaliveInterval) / (1000 * 1000 * 1000),
 			},
 		},
@@ -410,7 +611,17 @@ func (ps *PeerService) DownloadQRCode(ctx context.Context, id uuid.UUID) (dt.Dow
 		Name: fmt.Sprintf("%s.png", id.String()),
 	}
 
-	config, err := ps.DownloadConfig(ctx, id)
+	peer, err := ps.peerRepo.Get(ctx, nil, id)
+	if err != nil {
+		return downloadFileDTO, fmt.Errorf("peer service: %w", err)
+	}
+
+	// qr-code is meant to be scanned as is, config with a placeholder is useless there.
+	if !peer.HasPrivateKey {
+		return downloadFileDTO, fmt.Errorf("peer service: %w", ErrPrivateKeyUnknown)
+	}
+
+	config, err := ps.renderConfig(ctx, id)
 	if err != nil {
 		return downloadFileDTO, err
 	}
@@ -423,5 +634,140 @@ func (ps *PeerService) DownloadQRCode(ctx context.Context, id uuid.UUID) (dt.Dow
 	downloadFileDTO.Data = png
 	downloadFileDTO.Size = int64(len(png))
 
+	ps.auditService.Record(ctx, entity.AuditActionDownloadQRCode, entity.AuditEntityPeer, id, nil, nil)
+
 	return downloadFileDTO, err
 }
+
+// validateExtraRoutes checks subnets routed behind the peer against device networks and other peers routes.
+func (ps *PeerService) validateExtraRoutes(ctx context.Context, tx *sqlx.Tx, peer *entity.Peer, device *entity.Device) error {
+	if len(peer.ExtraRoutes) == 0 {
+		return nil
+	}
+
+	taken, err := ps.peerRepo.GetRoutes(ctx, tx, device.ID, peer.ID)
+	if err != nil {
+		return fmt.Errorf("peer service: %w", err)
+	}
+
+	if errors := peer.ValidateExtraRoutes(device, taken); len(errors) > 0 {
+		return common.NewErrInvalidData(fmt.Errorf("peer service: %w", ErrInvalidPeerData), errors)
+	}
+
+	return nil
+}
+
+// newPeer builds peer from add request: generates keys unless public key is given and fills defaults.
+func newPeer(dto dt.AddPeerDTO) (*entity.Peer, error) {
+	peer := &entity.Peer{
+		DeviceID:                    dto.DeviceID,
+		PersistentKeepaliveInterval: dto.PersistentKeepAlive,
+		Description:                 dto.Description,
+		Name:                        dto.Name,
+		Email:                       dto.Email,
+		DNS:                         dto.DNS,
+		MTU:                         dto.MTU,
+		IsEnabled:                   true,
+		ExpiresAt:                   dto.ExpiresAt,
+		QuotaBytes:                  dto.QuotaBytes,
+		QuotaPeriod:                 dto.QuotaPeriod,
+		ClientAllowedIPs:            dto.ClientAllowedIPs,
+		ExtraRoutes:                 dto.ExtraRoutes,
+		Usage: entity.PeerUsage{
+			PeriodStart: time.Now(),
+		},
+	}
+
+	if dto.PublicKey != "" {
+		publicKey, err := wgtypes.ParseKey(dto.PublicKey)
+		if err != nil {
+			return nil, common.NewErrInvalidData(
+				fmt.Errorf("peer service: %w", ErrInvalidPeerData),
+				[]*errdetails.BadRequest_FieldViolation{
+					{
+						Field:       "public_key",
+						Description: "public key is not a valid base64 wireguard key",
+					},
+				},
+			)
+		}
+		peer.PublicKey = publicKey
+	} else {
+		privateKey, err := wgtypes.GeneratePrivateKey()
+		if err != nil {
+			return nil, fmt.Errorf("peer service: %w", err)
+		}
+		peer.PrivateKey = privateKey
+		peer.PublicKey = privateKey.PublicKey()
+		peer.HasPrivateKey = true
+	}
+
+	if peer.DNS == "" {
+		peer.DNS = "9.9.9.9, 149.112.112.112"
+	}
+
+	if peer.MTU == 0 {
+		// https://gist.github.com/nitred/f16850ca48c48c79bf422e90ee5b9d95
+		peer.MTU = 1384
+	}
+
+	if dto.AddPresharedKey {
+		peer.HasPresharedKey = true
+		presharedKey, err := wgtypes.GenerateKey()
+		if err != nil {
+			return nil, fmt.Errorf("peer service: %w", err)
+		}
+		peer.PresharedKey = presharedKey
+	}
+
+	return peer, nil
+}
+
+// assignAddress returns the requested static address from the device network if it is free,
+// the first free address of the network is generated when nothing is requested.
+func (ps *PeerService) assignAddress(
+	ctx context.Context, tx *sqlx.Tx, device *entity.Device, peerID uuid.UUID, cidr, static string,
+) (string, error) {
+	if static != "" {
+		taken, err := ps.peerRepo.IsAddressTaken(ctx, tx, device.ID, peerID, static)
+		if err != nil {
+			return "", fmt.Errorf("peer service: %w", err)
+		}
+
+		if taken {
+			return "", fmt.Errorf("peer service: %s: %w", static, ErrAddressTaken)
+		}
+
+		return static, nil
+	}
+
+	_, ipnet, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return "", fmt.Errorf("peer service: %w", err)
+	}
+
+	ones, _ := ipnet.Mask.Size()
+
+	addr, err := ps.deviceRepo.GenerateAddress(ctx, tx, device, cidr)
+	if err != nil {
+		return "", fmt.Errorf("peer service: %w", err)
+	}
+
+	return fmt.Sprintf("%s/%d", addr, ones), nil
+}
+
+// addressInNetwork returns the first address which belongs to the network, empty string if there is none.
+func addressInNetwork(addrs []string, cidr string) string {
+	_, ipnet, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return ""
+	}
+
+	for _, addr := range addrs {
+		if ip, _, err := net.ParseCIDR(addr); err == nil && ipnet.Contains(ip) {
+			return addr
+		}
+	}
+
+	return ""
+}
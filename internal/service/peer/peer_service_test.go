@@ -0,0 +1,480 @@
+package peerservice_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/AZhur771/wg-grpc-api/internal/dto"
+	"github.com/AZhur771/wg-grpc-api/internal/entity"
+	"github.com/AZhur771/wg-grpc-api/internal/repo"
+	devicerepo "github.com/AZhur771/wg-grpc-api/internal/repo/device"
+	"github.com/AZhur771/wg-grpc-api/internal/service/common"
+	deviceservice "github.com/AZhur771/wg-grpc-api/internal/service/device"
+	peerservice "github.com/AZhur771/wg-grpc-api/internal/service/peer"
+	"github.com/AZhur771/wg-grpc-api/internal/servicetest"
+	"github.com/AZhur771/wg-grpc-api/internal/wgfake"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	fieldmask_utils "github.com/mennanov/fieldmask-utils"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"golang.zx2c4.com/wireguard/wgctrl/wgtypes"
+)
+
+type peerServiceTest struct {
+	*servicetest.Fixture
+	service *peerservice.PeerService
+	device  *entity.Device
+}
+
+// newPeerServiceTest runs peer service on top of the real device service with a fake interface backend,
+// the device is up and returned by the repo.
+func newPeerServiceTest(t *testing.T) *peerServiceTest {
+	t.Helper()
+
+	privateKey, err := wgtypes.GeneratePrivateKey()
+	require.NoError(t, err)
+
+	test := &peerServiceTest{
+		Fixture: servicetest.New(t),
+		device: &entity.Device{
+			ID:         uuid.New(),
+			Name:       "wg0",
+			Endpoint:   "203.0.113.1:51820",
+			Address:    "10.6.0.1/24",
+			MTU:        1420,
+			PrivateKey: privateKey,
+			PublicKey:  privateKey.PublicKey(),
+		},
+	}
+
+	require.NoError(t, test.Backend.Create(test.device))
+
+	test.DeviceRepo.EXPECT().Get(gomock.Any(), gomock.Any(), test.device.ID).
+		DoAndReturn(func(context.Context, *sqlx.Tx, uuid.UUID) (*entity.Device, error) {
+			dev := *test.device
+			return &dev, nil
+		}).AnyTimes()
+
+	deviceService := deviceservice.NewDeviceService(
+		zap.NewNop(), test.Backend, test.DeviceRepo, test.PeerRepo, test.AuditService,
+	)
+	test.service = peerservice.NewPeerService(
+		zap.NewNop(), deviceService, test.DeviceRepo, test.PeerRepo, test.AuditService,
+	)
+
+	return test
+}
+
+// addConfiguredPeer makes the repo return enabled peer which is configured on the interface.
+func (test *peerServiceTest) addConfiguredPeer(t *testing.T) *entity.Peer {
+	t.Helper()
+
+	privateKey, err := wgtypes.GeneratePrivateKey()
+	require.NoError(t, err)
+
+	peer := &entity.Peer{
+		ID:         uuid.New(),
+		DeviceID:   test.device.ID,
+		Name:       "alice",
+		PrivateKey: privateKey,
+		PublicKey:  privateKey.PublicKey(),
+		AllowedIPs: []string{"10.6.0.2/24"},
+		IsEnabled:  true,
+	}
+
+	peerConfig, err := peer.ToPeerConfig(test.device)
+	require.NoError(t, err)
+	require.NoError(t, test.Backend.Configure(test.device.Name, wgtypes.Config{Peers: []wgtypes.PeerConfig{*peerConfig}}))
+
+	test.PeerRepo.EXPECT().Get(gomock.Any(), gomock.Any(), peer.ID).Return(peer, nil).AnyTimes()
+
+	return peer
+}
+
+func configuredPeers(t *testing.T, backend *wgfake.Backend, name string) map[wgtypes.Key]wgtypes.Peer {
+	t.Helper()
+
+	wgdev, err := backend.Device(name)
+	require.NoError(t, err)
+
+	peers := make(map[wgtypes.Key]wgtypes.Peer, len(wgdev.Peers))
+	for _, peer := range wgdev.Peers {
+		peers[peer.PublicKey] = peer
+	}
+
+	return peers
+}
+
+func TestPeerService_Add(t *testing.T) {
+	test := newPeerServiceTest(t)
+
+	test.ExpectTx(test.PeerRepo.EXPECT().BeginTxx(gomock.Any(), gomock.Any()))
+	test.DeviceRepo.EXPECT().GenerateAddress(gomock.Any(), gomock.Not(gomock.Nil()), gomock.Any(), "10.6.0.1/24").
+		Return("10.6.0.7", nil)
+	test.PeerRepo.EXPECT().Add(gomock.Any(), gomock.Not(gomock.Nil()), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ *sqlx.Tx, peer *entity.Peer) (*entity.Peer, error) {
+			peer.ID = uuid.New()
+			return peer, nil
+		})
+
+	peer, err := test.service.Add(context.Background(), dto.AddPeerDTO{
+		DeviceID:        test.device.ID,
+		Name:            "alice",
+		AddPresharedKey: true,
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"10.6.0.7/24"}, peer.AllowedIPs)
+
+	wgpeer, ok := configuredPeers(t, test.Backend, test.device.Name)[peer.PublicKey]
+	require.True(t, ok)
+	require.Equal(t, peer.PresharedKey, wgpeer.PresharedKey)
+	require.Len(t, wgpeer.AllowedIPs, 1)
+	require.Equal(t, "10.6.0.7/32", wgpeer.AllowedIPs[0].String())
+}
+
+func TestPeerService_AddInvalid(t *testing.T) {
+	test := newPeerServiceTest(t)
+
+	_, err := test.service.Add(context.Background(), dto.AddPeerDTO{
+		DeviceID:  test.device.ID,
+		Name:      "alice",
+		PublicKey: "not a key",
+	})
+
+	errInvalidData := &common.ErrInvalidData{}
+	require.ErrorAs(t, err, errInvalidData)
+	require.Equal(t, "public_key", errInvalidData.Details().GetFieldViolations()[0].GetField())
+	require.Empty(t, configuredPeers(t, test.Backend, test.device.Name))
+}
+
+func TestPeerService_DisableEnable(t *testing.T) {
+	test := newPeerServiceTest(t)
+	ctx := context.Background()
+
+	peer := test.addConfiguredPeer(t)
+
+	test.PeerRepo.EXPECT().Update(gomock.Any(), gomock.Any(), peer).Return(peer, nil).Times(2)
+	test.PeerRepo.EXPECT().UpdateUsage(gomock.Any(), gomock.Any(), peer).Return(nil)
+
+	require.NoError(t, test.service.Disable(ctx, peer.ID))
+	require.False(t, peer.IsEnabled)
+	require.NotContains(t, configuredPeers(t, test.Backend, test.device.Name), peer.PublicKey)
+
+	// disabling twice does not touch the interface or the repo
+	require.NoError(t, test.service.Disable(ctx, peer.ID))
+
+	require.NoError(t, test.service.Enable(ctx, peer.ID))
+	require.True(t, peer.IsEnabled)
+	require.Contains(t, configuredPeers(t, test.Backend, test.device.Name), peer.PublicKey)
+}
+
+func TestPeerService_Remove(t *testing.T) {
+	test := newPeerServiceTest(t)
+
+	peer := test.addConfiguredPeer(t)
+
+	test.PeerRepo.EXPECT().Remove(gomock.Any(), gomock.Any(), peer.ID).Return(nil)
+
+	require.NoError(t, test.service.Remove(context.Background(), peer.ID))
+	require.Empty(t, configuredPeers(t, test.Backend, test.device.Name))
+}
+
+func TestPeerService_RotateKeys(t *testing.T) {
+	test := newPeerServiceTest(t)
+
+	peer := test.addConfiguredPeer(t)
+	peer.Usage.LastReceiveBytes = 1024
+	peer.Usage.LastTransmitBytes = 2048
+	oldPublicKey := peer.PublicKey
+
+	test.ExpectTx(test.PeerRepo.EXPECT().BeginTxx(gomock.Any(), gomock.Any()))
+	test.PeerRepo.EXPECT().UpdateKeys(gomock.Any(), gomock.Not(gomock.Nil()), peer).Return(nil)
+	test.PeerRepo.EXPECT().UpdateUsage(gomock.Any(), gomock.Not(gomock.Nil()), peer).
+		DoAndReturn(func(_ context.Context, _ *sqlx.Tx, peer *entity.Peer) error {
+			require.Zero(t, peer.Usage.LastReceiveBytes)
+			require.Zero(t, peer.Usage.LastTransmitBytes)
+			return nil
+		})
+
+	rotated, err := test.service.RotateKeys(context.Background(), dto.RotatePeerKeysDTO{ID: peer.ID})
+	require.NoError(t, err)
+	require.NotEqual(t, oldPublicKey, rotated.PublicKey)
+
+	peers := configuredPeers(t, test.Backend, test.device.Name)
+	require.NotContains(t, peers, oldPublicKey)
+	require.Contains(t, peers, rotated.PublicKey)
+}
+
+func TestPeerService_RotateKeysConfigureFailed(t *testing.T) {
+	test := newPeerServiceTest(t)
+
+	peer := test.addConfiguredPeer(t)
+	oldPublicKey := peer.PublicKey
+
+	test.ExpectTx(test.PeerRepo.EXPECT().BeginTxx(gomock.Any(), gomock.Any()))
+	test.PeerRepo.EXPECT().UpdateUsage(gomock.Any(), gomock.Not(gomock.Nil()), peer).Return(nil)
+	// interface goes away while keys are stored
+	test.PeerRepo.EXPECT().UpdateKeys(gomock.Any(), gomock.Not(gomock.Nil()), peer).
+		DoAndReturn(func(context.Context, *sqlx.Tx, *entity.Peer) error {
+			return test.Backend.Destroy(test.device)
+		})
+
+	// stored keys are kept and returned, the interface is left to the reconciler
+	rotated, err := test.service.RotateKeys(context.Background(), dto.RotatePeerKeysDTO{ID: peer.ID})
+	require.NoError(t, err)
+	require.NotEqual(t, oldPublicKey, rotated.PublicKey)
+}
+
+func TestPeerService_RotateKeysDisabled(t *testing.T) {
+	test := newPeerServiceTest(t)
+
+	peer := test.addConfiguredPeer(t)
+	peer.IsEnabled = false
+	require.NoError(t, test.Backend.Configure(test.device.Name, wgtypes.Config{ReplacePeers: true}))
+
+	privateKey, err := wgtypes.GeneratePrivateKey()
+	require.NoError(t, err)
+
+	test.ExpectTx(test.PeerRepo.EXPECT().BeginTxx(gomock.Any(), gomock.Any()))
+	test.PeerRepo.EXPECT().UpdateKeys(gomock.Any(), gomock.Not(gomock.Nil()), peer).Return(nil)
+	test.PeerRepo.EXPECT().UpdateUsage(gomock.Any(), gomock.Not(gomock.Nil()), peer).Return(nil)
+
+	rotated, err := test.service.RotateKeys(context.Background(), dto.RotatePeerKeysDTO{
+		ID:        peer.ID,
+		PublicKey: privateKey.PublicKey().String(),
+	})
+	require.NoError(t, err)
+	require.Equal(t, privateKey.PublicKey(), rotated.PublicKey)
+	require.False(t, rotated.HasPrivateKey)
+	require.Empty(t, configuredPeers(t, test.Backend, test.device.Name))
+}
+
+func TestPeerService_BulkAdd(t *testing.T) {
+	test := newPeerServiceTest(t)
+
+	// all addresses are allocated within the same transaction
+	var txs []*sqlx.Tx
+
+	addresses := []string{"10.6.0.2", "10.6.0.3"}
+
+	test.ExpectTx(test.PeerRepo.EXPECT().BeginTxx(gomock.Any(), gomock.Any()))
+	test.DeviceRepo.EXPECT().GenerateAddress(gomock.Any(), gomock.Not(gomock.Nil()), gomock.Any(), "10.6.0.1/24").
+		DoAndReturn(func(_ context.Context, tx *sqlx.Tx, _ *entity.Device, _ string) (string, error) {
+			txs = append(txs, tx)
+
+			if len(addresses) == 0 {
+				return "", devicerepo.ErrRunOutOfAddresses
+			}
+
+			addr := addresses[0]
+			addresses = addresses[1:]
+
+			return addr, nil
+		}).Times(3)
+	test.PeerRepo.EXPECT().Add(gomock.Any(), gomock.Not(gomock.Nil()), gomock.Any()).
+		DoAndReturn(func(_ context.Context, tx *sqlx.Tx, peer *entity.Peer) (*entity.Peer, error) {
+			require.Same(t, txs[0], tx)
+			return peer, nil
+		}).Times(2)
+
+	resp, err := test.service.BulkAdd(context.Background(), dto.BulkAddPeersDTO{
+		DeviceID: test.device.ID,
+		Peers: []dto.BulkPeerEntryDTO{
+			{Name: "alice"},
+			{Name: ""},
+			{Name: "bob", Email: "bob@example.com"},
+			{Name: "carol"},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, 2, resp.Added)
+	require.Len(t, resp.Results, 4)
+
+	for _, tx := range txs {
+		require.Same(t, txs[0], tx)
+	}
+
+	// every entry gets its own result in the request order
+	for i, result := range resp.Results {
+		require.Equal(t, i, result.Index)
+	}
+
+	require.Empty(t, resp.Results[0].Errors)
+	require.Equal(t, []string{"10.6.0.2/24"}, resp.Results[0].Peer.AllowedIPs)
+
+	require.Nil(t, resp.Results[1].Peer)
+	require.Equal(t, "name", resp.Results[1].Errors[0].GetField())
+
+	require.Empty(t, resp.Results[2].Errors)
+	require.Equal(t, []string{"10.6.0.3/24"}, resp.Results[2].Peer.AllowedIPs)
+
+	require.Nil(t, resp.Results[3].Peer)
+	require.Equal(t, "addresses", resp.Results[3].Errors[0].GetField())
+
+	// added peers are configured at once
+	require.Equal(t, 1, test.Backend.Configured(test.device.Name))
+
+	peers := configuredPeers(t, test.Backend, test.device.Name)
+	require.Len(t, peers, 2)
+	require.Contains(t, peers, resp.Results[0].Peer.PublicKey)
+	require.Contains(t, peers, resp.Results[2].Peer.PublicKey)
+}
+
+func TestPeerService_BulkAddNothingValid(t *testing.T) {
+	test := newPeerServiceTest(t)
+
+	test.ExpectTx(test.PeerRepo.EXPECT().BeginTxx(gomock.Any(), gomock.Any()))
+
+	resp, err := test.service.BulkAdd(context.Background(), dto.BulkAddPeersDTO{
+		DeviceID: test.device.ID,
+		Peers:    []dto.BulkPeerEntryDTO{{Name: "alice", Email: "not an email"}},
+	})
+	require.NoError(t, err)
+	require.Zero(t, resp.Added)
+	require.Equal(t, "email", resp.Results[0].Errors[0].GetField())
+	require.Zero(t, test.Backend.Configured(test.device.Name))
+}
+
+// allowedIPs returns allowed IPs of the peer configured on the interface.
+func allowedIPs(t *testing.T, backend *wgfake.Backend, name string, key wgtypes.Key) []string {
+	t.Helper()
+
+	wgpeer, ok := configuredPeers(t, backend, name)[key]
+	require.True(t, ok)
+
+	ips := make([]string, 0, len(wgpeer.AllowedIPs))
+	for _, ip := range wgpeer.AllowedIPs {
+		ips = append(ips, ip.String())
+	}
+
+	return ips
+}
+
+func TestPeerService_UpdateAddresses(t *testing.T) {
+	test := newPeerServiceTest(t)
+
+	peer := test.addConfiguredPeer(t)
+
+	test.ExpectTx(test.PeerRepo.EXPECT().BeginTxx(gomock.Any(), gomock.Any()))
+	test.PeerRepo.EXPECT().IsAddressTaken(gomock.Any(), gomock.Not(gomock.Nil()), test.device.ID, peer.ID, "10.6.0.9/24").
+		Return(false, nil)
+	test.PeerRepo.EXPECT().Update(gomock.Any(), gomock.Not(gomock.Nil()), peer).
+		DoAndReturn(func(_ context.Context, _ *sqlx.Tx, peer *entity.Peer) (*entity.Peer, error) {
+			// the interface is not touched until the peer is stored
+			require.Equal(t, []string{"10.6.0.2/32"}, allowedIPs(t, test.Backend, test.device.Name, peer.PublicKey))
+			return peer, nil
+		})
+
+	updated, err := test.service.Update(context.Background(), dto.UpdatePeerDTO{
+		ID:        peer.ID,
+		Name:      "bob",
+		Addresses: []string{"10.6.0.9"},
+	}, fieldmask_utils.Mask{"Name": fieldmask_utils.Mask{}, "Addresses": fieldmask_utils.Mask{}})
+	require.NoError(t, err)
+	require.Equal(t, []string{"10.6.0.9/24"}, updated.AllowedIPs)
+	require.Equal(t, []string{"10.6.0.9/32"}, allowedIPs(t, test.Backend, test.device.Name, peer.PublicKey))
+}
+
+func TestPeerService_UpdateAddressTaken(t *testing.T) {
+	test := newPeerServiceTest(t)
+
+	peer := test.addConfiguredPeer(t)
+
+	test.ExpectRollback(test.PeerRepo.EXPECT().BeginTxx(gomock.Any(), gomock.Any()))
+	test.PeerRepo.EXPECT().IsAddressTaken(gomock.Any(), gomock.Not(gomock.Nil()), test.device.ID, peer.ID, "10.6.0.9/24").
+		Return(false, nil)
+	// address is assigned to another peer concurrently
+	test.PeerRepo.EXPECT().Update(gomock.Any(), gomock.Not(gomock.Nil()), peer).Return(nil, repo.ErrAddressTaken)
+
+	_, err := test.service.Update(context.Background(), dto.UpdatePeerDTO{
+		ID:        peer.ID,
+		Name:      "bob",
+		Addresses: []string{"10.6.0.9"},
+	}, fieldmask_utils.Mask{"Name": fieldmask_utils.Mask{}, "Addresses": fieldmask_utils.Mask{}})
+	require.ErrorIs(t, err, peerservice.ErrAddressTaken)
+	require.Equal(t, []string{"10.6.0.2/32"}, allowedIPs(t, test.Backend, test.device.Name, peer.PublicKey))
+}
+
+func TestPeerService_UpdateRoutes(t *testing.T) {
+	test := newPeerServiceTest(t)
+
+	peer := test.addConfiguredPeer(t)
+
+	test.ExpectTx(test.PeerRepo.EXPECT().BeginTxx(gomock.Any(), gomock.Any()))
+	test.PeerRepo.EXPECT().GetRoutes(gomock.Any(), gomock.Not(gomock.Nil()), test.device.ID, peer.ID).Return([]string{}, nil)
+	test.PeerRepo.EXPECT().Update(gomock.Any(), gomock.Not(gomock.Nil()), peer).Return(peer, nil)
+
+	_, err := test.service.Update(context.Background(), dto.UpdatePeerDTO{
+		ID:          peer.ID,
+		ExtraRoutes: []string{"192.168.10.0/24"},
+	}, fieldmask_utils.Mask{"ExtraRoutes": fieldmask_utils.Mask{}})
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"10.6.0.2/32", "192.168.10.0/24"},
+		allowedIPs(t, test.Backend, test.device.Name, peer.PublicKey))
+}
+
+func TestPeerService_UpdateRoutesFailed(t *testing.T) {
+	test := newPeerServiceTest(t)
+
+	peer := test.addConfiguredPeer(t)
+
+	test.ExpectRollback(test.PeerRepo.EXPECT().BeginTxx(gomock.Any(), gomock.Any()))
+	test.PeerRepo.EXPECT().GetRoutes(gomock.Any(), gomock.Not(gomock.Nil()), test.device.ID, peer.ID).Return([]string{}, nil)
+	test.PeerRepo.EXPECT().Update(gomock.Any(), gomock.Not(gomock.Nil()), peer).Return(nil, errors.New("route insert failed"))
+
+	_, err := test.service.Update(context.Background(), dto.UpdatePeerDTO{
+		ID:          peer.ID,
+		ExtraRoutes: []string{"192.168.10.0/24"},
+	}, fieldmask_utils.Mask{"ExtraRoutes": fieldmask_utils.Mask{}})
+	require.Error(t, err)
+	// routes missing in the database are not applied to the interface
+	require.Equal(t, []string{"10.6.0.2/32"}, allowedIPs(t, test.Backend, test.device.Name, peer.PublicKey))
+}
+
+func TestPeerService_WatchNotConfigured(t *testing.T) {
+	test := newPeerServiceTest(t)
+
+	privateKey, err := wgtypes.GeneratePrivateKey()
+	require.NoError(t, err)
+
+	// enabled peer is not on the interface yet
+	peer := &entity.Peer{
+		ID:         uuid.New(),
+		DeviceID:   test.device.ID,
+		Name:       "alice",
+		PrivateKey: privateKey,
+		PublicKey:  privateKey.PublicKey(),
+		AllowedIPs: []string{"10.6.0.2/24"},
+		IsEnabled:  true,
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	polls := 0
+
+	test.PeerRepo.EXPECT().Get(gomock.Any(), gomock.Any(), peer.ID).
+		DoAndReturn(func(context.Context, *sqlx.Tx, uuid.UUID) (*entity.Peer, error) {
+			if polls++; polls == 3 {
+				cancel()
+			}
+
+			p := *peer
+			return &p, nil
+		}).MinTimes(3)
+
+	var events []entity.PeerEvent
+
+	err = test.service.Watch(ctx, test.device.ID, peer.ID, time.Millisecond, func(event entity.PeerEvent) error {
+		events = append(events, event)
+		return nil
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, events)
+	require.Equal(t, entity.PeerEventSnapshot, events[0].Type)
+	require.Zero(t, events[0].Peer.ReceiveBytes)
+}
@@ -0,0 +1,134 @@
+package peerservice
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"golang.zx2c4.com/wireguard/wgctrl/wgtypes"
+)
+
+// RunScheduler runs peer housekeeping tasks right away and then every interval until ctx is done.
+func (ps *PeerService) RunScheduler(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		ps.runScheduledTasks(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (ps *PeerService) runScheduledTasks(ctx context.Context) {
+	if err := ps.EnforceQuotas(ctx); err != nil {
+		ps.logger.Error("scheduler: failed to enforce peer quotas", zap.Error(err))
+	}
+
+	if err := ps.DisableExpired(ctx); err != nil {
+		ps.logger.Error("scheduler: failed to disable expired peers", zap.Error(err))
+	}
+}
+
+// DisableExpired disables enabled peers which expiration deadline has passed.
+func (ps *PeerService) DisableExpired(ctx context.Context) error {
+	peers, err := ps.peerRepo.GetExpired(ctx, nil, time.Now())
+	if err != nil {
+		return fmt.Errorf("peer service: %w", err)
+	}
+
+	for _, peer := range peers {
+		if err := ps.Disable(ctx, peer.ID); err != nil {
+			ps.logger.Error("scheduler: failed to disable expired peer",
+				zap.String("id", peer.ID.String()), zap.Error(err))
+			continue
+		}
+
+		ps.logger.Info("scheduler: expired peer disabled", zap.String("id", peer.ID.String()))
+	}
+
+	return nil
+}
+
+// EnforceQuotas accounts traffic of enabled peers, renews monthly quotas
+// and disables peers which exceeded their quota.
+func (ps *PeerService) EnforceQuotas(ctx context.Context) error {
+	peers, err := ps.peerRepo.GetAll(ctx, nil, 0, 0, "", uuid.Nil)
+	if err != nil {
+		return fmt.Errorf("peer service: %w", err)
+	}
+
+	now := time.Now()
+	configured := make(map[uuid.UUID]map[wgtypes.Key]wgtypes.Peer)
+
+	for _, peer := range peers {
+		renewed := peer.RenewQuotaPeriod(now)
+
+		if !peer.IsEnabled {
+			if renewed {
+				if err := ps.peerRepo.UpdateUsage(ctx, nil, peer); err != nil {
+					ps.logger.Error("scheduler: failed to renew peer quota",
+						zap.String("id", peer.ID.String()), zap.Error(err))
+				}
+			}
+			continue
+		}
+
+		wgpeers, ok := configured[peer.DeviceID]
+		if !ok {
+			wgpeers, err = ps.getConfiguredPeers(ctx, peer.DeviceID)
+			if err != nil {
+				ps.logger.Error("scheduler: failed to get configured peers",
+					zap.String("device_id", peer.DeviceID.String()), zap.Error(err))
+			}
+			configured[peer.DeviceID] = wgpeers
+		}
+
+		if wgpeer, ok := wgpeers[peer.PublicKey]; ok {
+			peer.AccountTraffic(&wgpeer)
+		}
+
+		if err := ps.peerRepo.UpdateUsage(ctx, nil, peer); err != nil {
+			ps.logger.Error("scheduler: failed to update peer usage",
+				zap.String("id", peer.ID.String()), zap.Error(err))
+			continue
+		}
+
+		if peer.IsQuotaExceeded() {
+			if err := ps.Disable(ctx, peer.ID); err != nil {
+				ps.logger.Error("scheduler: failed to disable peer over quota",
+					zap.String("id", peer.ID.String()), zap.Error(err))
+				continue
+			}
+
+			ps.logger.Info("scheduler: peer over quota disabled", zap.String("id", peer.ID.String()))
+		}
+	}
+
+	return nil
+}
+
+func (ps *PeerService) getConfiguredPeers(ctx context.Context, deviceID uuid.UUID) (map[wgtypes.Key]wgtypes.Peer, error) {
+	device, err := ps.deviceService.Get(ctx, deviceID)
+	if err != nil {
+		return nil, err
+	}
+
+	wgpeers, err := ps.deviceService.GetConfiguredPeers(device.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make(map[wgtypes.Key]wgtypes.Peer, len(wgpeers))
+	for _, wgpeer := range wgpeers {
+		res[wgpeer.PublicKey] = wgpeer
+	}
+
+	return res, nil
+}
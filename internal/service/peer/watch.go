@@ -0,0 +1,157 @@
+package peerservice
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/AZhur771/wg-grpc-api/internal/entity"
+	deviceservice "github.com/AZhur771/wg-grpc-api/internal/service/device"
+	"github.com/google/uuid"
+	"golang.zx2c4.com/wireguard/wgctrl/wgtypes"
+)
+
+// Watch polls peers state every interval and sends observed changes until ctx is done.
+// Single peer is watched when peer id is given, otherwise all peers of the device.
+func (ps *PeerService) Watch(
+	ctx context.Context, deviceID, peerID uuid.UUID, interval time.Duration, send func(entity.PeerEvent) error,
+) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	prev, err := ps.snapshotPeers(ctx, deviceID, peerID)
+	if err != nil {
+		return err
+	}
+
+	if peerID != uuid.Nil && len(prev) == 0 {
+		return fmt.Errorf("peer service: %w", sql.ErrNoRows)
+	}
+
+	now := time.Now()
+
+	for _, peer := range prev {
+		if err := send(entity.PeerEvent{Type: entity.PeerEventSnapshot, Time: now, Peer: peer}); err != nil {
+			return fmt.Errorf("peer service: %w", err)
+		}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		cur, err := ps.snapshotPeers(ctx, deviceID, peerID)
+		if err != nil {
+			return err
+		}
+
+		for _, event := range diffSnapshots(prev, cur, time.Now()) {
+			if err := send(event); err != nil {
+				return fmt.Errorf("peer service: %w", err)
+			}
+		}
+
+		// watched peer is gone, nothing left to watch
+		if peerID != uuid.Nil && len(cur) == 0 {
+			return nil
+		}
+
+		prev = cur
+	}
+}
+
+// snapshotPeers returns watched peers with populated dynamic fields,
+// removed peer yields an empty snapshot once watching has started.
+func (ps *PeerService) snapshotPeers(ctx context.Context, deviceID, peerID uuid.UUID) (map[uuid.UUID]*entity.Peer, error) {
+	res := make(map[uuid.UUID]*entity.Peer)
+
+	if peerID != uuid.Nil {
+		peer, err := ps.peerRepo.Get(ctx, nil, peerID)
+		if errors.Is(err, sql.ErrNoRows) {
+			return res, nil
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("peer service: %w", err)
+		}
+
+		if peer.IsEnabled {
+			device, err := ps.deviceService.Get(ctx, peer.DeviceID)
+			if err != nil {
+				return nil, fmt.Errorf("peer service: %w", err)
+			}
+
+			// enabled peer is not configured on the interface until the reconciler catches up,
+			// it is reported with zero stats meanwhile
+			wgpeer, err := ps.deviceService.GetConfiguredPeer(device.Name, peer.PublicKey)
+			if err != nil && !errors.Is(err, deviceservice.ErrPeerNotConfigured) {
+				return nil, fmt.Errorf("peer service: %w", err)
+			}
+
+			if err == nil {
+				peer = peer.PopulateDynamicFields(&wgpeer)
+			}
+		}
+
+		res[peer.ID] = peer
+
+		return res, nil
+	}
+
+	device, err := ps.deviceService.Get(ctx, deviceID)
+	if err != nil {
+		return nil, fmt.Errorf("peer service: %w", err)
+	}
+
+	peers, err := ps.peerRepo.GetAll(ctx, nil, 0, 0, "", deviceID)
+	if err != nil {
+		return nil, fmt.Errorf("peer service: %w", err)
+	}
+
+	wgpeers, err := ps.deviceService.GetConfiguredPeers(device.Name)
+	if err != nil {
+		return nil, fmt.Errorf("peer service: %w", err)
+	}
+
+	configured := make(map[wgtypes.Key]wgtypes.Peer, len(wgpeers))
+	for _, wgpeer := range wgpeers {
+		configured[wgpeer.PublicKey] = wgpeer
+	}
+
+	for _, peer := range peers {
+		if wgpeer, ok := configured[peer.PublicKey]; ok && peer.IsEnabled {
+			peer = peer.PopulateDynamicFields(&wgpeer)
+		}
+
+		res[peer.ID] = peer
+	}
+
+	return res, nil
+}
+
+func diffSnapshots(prev, cur map[uuid.UUID]*entity.Peer, now time.Time) []entity.PeerEvent {
+	events := make([]entity.PeerEvent, 0)
+
+	for id, peer := range cur {
+		prevPeer, ok := prev[id]
+		if !ok {
+			events = append(events, entity.PeerEvent{Type: entity.PeerEventAdded, Time: now, Peer: peer})
+			continue
+		}
+
+		events = append(events, entity.DiffPeer(prevPeer, peer, now)...)
+	}
+
+	for id, peer := range prev {
+		if _, ok := cur[id]; !ok {
+			events = append(events, entity.PeerEvent{Type: entity.PeerEventRemoved, Time: now, Peer: peer})
+		}
+	}
+
+	return events
+}
@@ -0,0 +1,72 @@
+// Package servicetest holds fixture shared by service tests: repos are mocks, transactions they begin
+// are sqlmock ones and interfaces are kept in memory by wgfake backend.
+package servicetest
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	app_mocks "github.com/AZhur771/wg-grpc-api/internal/app/mocks"
+	"github.com/AZhur771/wg-grpc-api/internal/wgfake"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/require"
+)
+
+type Fixture struct {
+	Backend      *wgfake.Backend
+	DeviceRepo   *app_mocks.MockDeviceRepo
+	PeerRepo     *app_mocks.MockPeerRepo
+	AuditService *app_mocks.MockAuditService
+	SQLMock      sqlmock.Sqlmock
+	DB           *sqlx.DB
+}
+
+// New returns fixture checking sqlmock expectations once the test is done, audit records are accepted as is.
+func New(t *testing.T) *Fixture {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, mock.ExpectationsWereMet())
+		db.Close()
+	})
+
+	auditService := app_mocks.NewMockAuditService(ctrl)
+	auditService.EXPECT().Record(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		AnyTimes()
+
+	return &Fixture{
+		Backend:      wgfake.New(),
+		DeviceRepo:   app_mocks.NewMockDeviceRepo(ctrl),
+		PeerRepo:     app_mocks.NewMockPeerRepo(ctrl),
+		AuditService: auditService,
+		SQLMock:      mock,
+		DB:           sqlx.NewDb(db, "sqlmock"),
+	}
+}
+
+// ExpectTx makes the BeginTxx call of a repo begin a transaction which is expected to be committed.
+func (f *Fixture) ExpectTx(call *gomock.Call) {
+	f.SQLMock.ExpectBegin()
+	f.SQLMock.ExpectCommit()
+	f.beginTx(call)
+}
+
+// ExpectRollback makes the BeginTxx call of a repo begin a transaction which is expected to be rolled back.
+func (f *Fixture) ExpectRollback(call *gomock.Call) {
+	f.SQLMock.ExpectBegin()
+	f.SQLMock.ExpectRollback()
+	f.beginTx(call)
+}
+
+func (f *Fixture) beginTx(call *gomock.Call) {
+	call.DoAndReturn(func(ctx context.Context, options *sql.TxOptions) (*sqlx.Tx, error) {
+		return f.DB.BeginTxx(ctx, options)
+	})
+}
@@ -1,6 +1,8 @@
 package template
 
 type PeerConfigTmplData struct {
+	// PeerName is filled by ParseConfig only and is not rendered.
+	PeerName                string
 	PeerPublicKey           string
 	PeerPresharedKey        string
 	PeerEndpoint            string
@@ -0,0 +1,205 @@
+package template
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+var ErrInvalidConfig = errors.New("invalid wireguard config")
+
+const (
+	sectionNone = iota
+	sectionInterface
+	sectionPeer
+)
+
+// ParseConfig reads wg-quick config with Interface and Peer sections.
+// Peer name is taken from `# Name = ...` comment of the peer section, other comments are skipped.
+func ParseConfig(r io.Reader) (*ConfigTmplData, error) {
+	data := &ConfigTmplData{}
+	section := sectionNone
+	hasInterface := false
+
+	var peer *PeerConfigTmplData
+
+	scanner := bufio.NewScanner(r)
+
+	for num := 1; scanner.Scan(); num++ {
+		line := strings.TrimSpace(scanner.Text())
+
+		if strings.HasPrefix(line, "#") {
+			if key, value, ok := splitKeyValue(strings.TrimPrefix(line, "#")); ok && section == sectionPeer &&
+				strings.EqualFold(key, "name") {
+				peer.PeerName = value
+			}
+			continue
+		}
+
+		// everything after # is a comment, the same way wg-quick strips it
+		if i := strings.Index(line, "#"); i >= 0 {
+			line = strings.TrimSpace(line[:i])
+		}
+
+		if line == "" {
+			continue
+		}
+
+		switch strings.ToLower(line) {
+		case "[interface]":
+			if hasInterface {
+				return nil, fmt.Errorf("line %d: duplicate interface section: %w", num, ErrInvalidConfig)
+			}
+			hasInterface = true
+			section = sectionInterface
+			continue
+		case "[peer]":
+			data.InterfacePeers = append(data.InterfacePeers, PeerConfigTmplData{})
+			peer = &data.InterfacePeers[len(data.InterfacePeers)-1]
+			section = sectionPeer
+			continue
+		}
+
+		key, value, ok := splitKeyValue(line)
+		if !ok {
+			return nil, fmt.Errorf("line %d: key = value expected: %w", num, ErrInvalidConfig)
+		}
+
+		var err error
+
+		switch section {
+		case sectionInterface:
+			err = parseInterfaceKey(data, key, value)
+		case sectionPeer:
+			err = parsePeerKey(peer, key, value)
+		default:
+			err = errors.New("key outside of section")
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %s: %w", num, err.Error(), ErrInvalidConfig)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if !hasInterface || data.InterfacePrivateKey == "" {
+		return nil, fmt.Errorf("interface private key is missing: %w", ErrInvalidConfig)
+	}
+
+	for i, peer := range data.InterfacePeers {
+		if peer.PeerPublicKey == "" {
+			return nil, fmt.Errorf("peer %d: public key is missing: %w", i, ErrInvalidConfig)
+		}
+	}
+
+	return data, nil
+}
+
+func parseInterfaceKey(data *ConfigTmplData, key, value string) error {
+	var err error
+
+	switch strings.ToLower(key) {
+	case "privatekey":
+		data.InterfacePrivateKey = value
+	case "address":
+		data.InterfaceAddress = append(data.InterfaceAddress, splitList(value)...)
+	case "listenport":
+		data.InterfacePort = value
+	case "mtu":
+		data.InterfaceMTU, err = strconv.Atoi(value)
+	case "dns":
+		data.InterfaceDNS = joinNonEmpty(", ", data.InterfaceDNS, strings.Join(splitList(value), ", "))
+	case "table":
+		data.InterfaceTable = value
+	case "fwmark":
+		if !strings.EqualFold(value, "off") {
+			var mark int64
+			mark, err = strconv.ParseInt(value, 0, 32)
+			data.InterfaceFwMark = int(mark)
+		}
+	case "preup":
+		data.InterfacePreUp = joinNonEmpty("; ", data.InterfacePreUp, value)
+	case "postup":
+		data.InterfacePostUp = joinNonEmpty("; ", data.InterfacePostUp, value)
+	case "predown":
+		data.InterfacePreDown = joinNonEmpty("; ", data.InterfacePreDown, value)
+	case "postdown":
+		data.InterfacePostDown = joinNonEmpty("; ", data.InterfacePostDown, value)
+	case "saveconfig":
+		data.SaveConfig = strings.EqualFold(value, "true")
+	default:
+		return fmt.Errorf("unknown interface key %s", key)
+	}
+
+	if err != nil {
+		return fmt.Errorf("%s: %w", key, err)
+	}
+
+	return nil
+}
+
+func parsePeerKey(peer *PeerConfigTmplData, key, value string) error {
+	var err error
+
+	switch strings.ToLower(key) {
+	case "publickey":
+		peer.PeerPublicKey = value
+	case "presharedkey":
+		peer.PeerPresharedKey = value
+	case "allowedips":
+		peer.PeerAllowedIPs = append(peer.PeerAllowedIPs, splitList(value)...)
+	case "endpoint":
+		peer.PeerEndpoint = value
+	case "persistentkeepalive":
+		if !strings.EqualFold(value, "off") {
+			peer.PeerPersistentKeepalive, err = strconv.Atoi(value)
+		}
+	default:
+		return fmt.Errorf("unknown peer key %s", key)
+	}
+
+	if err != nil {
+		return fmt.Errorf("%s: %w", key, err)
+	}
+
+	return nil
+}
+
+func splitKeyValue(line string) (string, string, bool) {
+	i := strings.Index(line, "=")
+	if i < 0 {
+		return "", "", false
+	}
+
+	return strings.TrimSpace(line[:i]), strings.TrimSpace(line[i+1:]), true
+}
+
+func splitList(value string) []string {
+	res := make([]string, 0)
+
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			res = append(res, item)
+		}
+	}
+
+	return res
+}
+
+func joinNonEmpty(sep string, values ...string) string {
+	res := make([]string, 0, len(values))
+
+	for _, value := range values {
+		if value != "" {
+			res = append(res, value)
+		}
+	}
+
+	return strings.Join(res, sep)
+}
@@ -0,0 +1,69 @@
+package template_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/AZhur771/wg-grpc-api/internal/template"
+	"github.com/stretchr/testify/require"
+)
+
+const configMock = `
+[Interface]
+# comments are skipped
+PrivateKey = cPxJG8YNW1uHC6Evfl8yHgwAd8kV3V7KIRWxVlfdRlw=
+Address = 10.0.0.1/24, fd00::1/64
+ListenPort = 51820
+FwMark = 0x10
+PostUp = iptables -A FORWARD -i %i -j ACCEPT
+PostUp = iptables -t nat -A POSTROUTING -o eth0 -j MASQUERADE # inline comment
+
+[Peer]
+# Name = alice
+PublicKey = xTIBA5rboUvnH4htodjb6e697QjLERt1NAB4mZqp8Dg=
+PresharedKey = E3l1gB1WqQFx/OMQq4hS2MdW5bF1XZ9Wdq0cPjA8pEM=
+AllowedIPs = 10.0.0.2/32, fd00::2/128
+AllowedIPs = 192.168.1.0/24
+PersistentKeepalive = 25
+
+[peer]
+PublicKey = TrMvSoP4jYQlY6RIzBgbssQqY3vxI2Pi+y71lOWWXX0=
+AllowedIPs = 10.0.0.3/32
+PersistentKeepalive = off
+`
+
+func TestParseConfig(t *testing.T) {
+	data, err := template.ParseConfig(strings.NewReader(configMock))
+	require.NoError(t, err)
+
+	require.Equal(t, "cPxJG8YNW1uHC6Evfl8yHgwAd8kV3V7KIRWxVlfdRlw=", data.InterfacePrivateKey)
+	require.Equal(t, []string{"10.0.0.1/24", "fd00::1/64"}, data.InterfaceAddress)
+	require.Equal(t, "51820", data.InterfacePort)
+	require.Equal(t, 16, data.InterfaceFwMark)
+	require.Equal(t,
+		"iptables -A FORWARD -i %i -j ACCEPT; iptables -t nat -A POSTROUTING -o eth0 -j MASQUERADE",
+		data.InterfacePostUp,
+	)
+
+	require.Equal(t, 2, len(data.InterfacePeers))
+	require.Equal(t, template.PeerConfigTmplData{
+		PeerName:                "alice",
+		PeerPublicKey:           "xTIBA5rboUvnH4htodjb6e697QjLERt1NAB4mZqp8Dg=",
+		PeerPresharedKey:        "E3l1gB1WqQFx/OMQq4hS2MdW5bF1XZ9Wdq0cPjA8pEM=",
+		PeerAllowedIPs:          []string{"10.0.0.2/32", "fd00::2/128", "192.168.1.0/24"},
+		PeerPersistentKeepalive: 25,
+	}, data.InterfacePeers[0])
+	require.Equal(t, "", data.InterfacePeers[1].PeerName)
+	require.Equal(t, 0, data.InterfacePeers[1].PeerPersistentKeepalive)
+
+	for _, config := range []string{
+		"",
+		"[Interface]\nAddress = 10.0.0.1/24",
+		"PrivateKey = cPxJG8YNW1uHC6Evfl8yHgwAd8kV3V7KIRWxVlfdRlw=",
+		"[Interface]\nPrivateKey = cPxJG8YNW1uHC6Evfl8yHgwAd8kV3V7KIRWxVlfdRlw=\nUnknown = 1",
+		"[Interface]\nPrivateKey = cPxJG8YNW1uHC6Evfl8yHgwAd8kV3V7KIRWxVlfdRlw=\n[Peer]\nAllowedIPs = 10.0.0.2/32",
+	} {
+		_, err := template.ParseConfig(strings.NewReader(config))
+		require.ErrorIs(t, err, template.ErrInvalidConfig, config)
+	}
+}
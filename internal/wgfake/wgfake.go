@@ -0,0 +1,210 @@
+package wgfake
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/AZhur771/wg-grpc-api/internal/entity"
+	"golang.zx2c4.com/wireguard/wgctrl/wgtypes"
+)
+
+// Backend keeps wireguard interfaces in memory the way the kernel would, so services run without root in tests.
+type Backend struct {
+	mu         sync.Mutex
+	devices    map[string]*wgtypes.Device
+	created    map[string]int
+	configured map[string]int
+}
+
+func New() *Backend {
+	return &Backend{
+		devices:    make(map[string]*wgtypes.Device),
+		created:    make(map[string]int),
+		configured: make(map[string]int),
+	}
+}
+
+// Create mirrors wgctrl semantics of the other backends: key, listen port and firewall mark of the device
+// are applied and peers of an existing interface are kept.
+func (b *Backend) Create(dev *entity.Device) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	_, port, err := net.SplitHostPort(dev.Endpoint)
+	if err != nil {
+		return fmt.Errorf("fake: %w", err)
+	}
+
+	listenPort, err := strconv.Atoi(port)
+	if err != nil {
+		return fmt.Errorf("fake: %w", err)
+	}
+
+	wgdev, ok := b.devices[dev.Name]
+	if !ok {
+		wgdev = &wgtypes.Device{Name: dev.Name, Type: wgtypes.Unknown}
+		b.devices[dev.Name] = wgdev
+	}
+
+	b.created[dev.Name]++
+
+	wgdev.PrivateKey = dev.PrivateKey
+	wgdev.PublicKey = dev.PrivateKey.PublicKey()
+	wgdev.ListenPort = listenPort
+	wgdev.FirewallMark = dev.FirewallMark
+
+	return nil
+}
+
+func (b *Backend) Configure(name string, cfg wgtypes.Config) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	wgdev, ok := b.devices[name]
+	if !ok {
+		return fmt.Errorf("fake: %s: %w", name, os.ErrNotExist)
+	}
+
+	b.configured[name]++
+
+	if cfg.PrivateKey != nil {
+		wgdev.PrivateKey = *cfg.PrivateKey
+		wgdev.PublicKey = cfg.PrivateKey.PublicKey()
+	}
+
+	if cfg.ListenPort != nil {
+		wgdev.ListenPort = *cfg.ListenPort
+	}
+
+	if cfg.FirewallMark != nil {
+		wgdev.FirewallMark = *cfg.FirewallMark
+	}
+
+	if cfg.ReplacePeers {
+		wgdev.Peers = nil
+	}
+
+	for _, peerConfig := range cfg.Peers {
+		i := peerIndex(wgdev.Peers, peerConfig.PublicKey)
+
+		if peerConfig.Remove {
+			if i >= 0 {
+				wgdev.Peers = append(wgdev.Peers[:i], wgdev.Peers[i+1:]...)
+			}
+			continue
+		}
+
+		if i < 0 {
+			if peerConfig.UpdateOnly {
+				continue
+			}
+
+			wgdev.Peers = append(wgdev.Peers, wgtypes.Peer{PublicKey: peerConfig.PublicKey, ProtocolVersion: 1})
+			i = len(wgdev.Peers) - 1
+		}
+
+		applyPeerConfig(&wgdev.Peers[i], peerConfig)
+	}
+
+	return nil
+}
+
+// Created returns number of successful Create calls made for the interface.
+func (b *Backend) Created(name string) int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.created[name]
+}
+
+// Configured returns number of successful Configure calls made for the interface.
+func (b *Backend) Configured(name string) int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.configured[name]
+}
+
+func (b *Backend) Destroy(dev *entity.Device) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	delete(b.devices, dev.Name)
+
+	return nil
+}
+
+// Device returns a copy of the interface, so callers never race with later changes.
+func (b *Backend) Device(name string) (*wgtypes.Device, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	wgdev, ok := b.devices[name]
+	if !ok {
+		return nil, fmt.Errorf("fake: %s: %w", name, os.ErrNotExist)
+	}
+
+	return copyDevice(wgdev), nil
+}
+
+func (b *Backend) Devices() ([]*wgtypes.Device, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	wgdevs := make([]*wgtypes.Device, 0, len(b.devices))
+	for _, wgdev := range b.devices {
+		wgdevs = append(wgdevs, copyDevice(wgdev))
+	}
+
+	sort.Slice(wgdevs, func(i, j int) bool {
+		return wgdevs[i].Name < wgdevs[j].Name
+	})
+
+	return wgdevs, nil
+}
+
+func applyPeerConfig(peer *wgtypes.Peer, cfg wgtypes.PeerConfig) {
+	if cfg.PresharedKey != nil {
+		peer.PresharedKey = *cfg.PresharedKey
+	}
+
+	if cfg.Endpoint != nil {
+		peer.Endpoint = cfg.Endpoint
+	}
+
+	if cfg.PersistentKeepaliveInterval != nil {
+		peer.PersistentKeepaliveInterval = *cfg.PersistentKeepaliveInterval
+	}
+
+	if cfg.ReplaceAllowedIPs {
+		peer.AllowedIPs = nil
+	}
+
+	peer.AllowedIPs = append(peer.AllowedIPs, cfg.AllowedIPs...)
+}
+
+func peerIndex(peers []wgtypes.Peer, publicKey wgtypes.Key) int {
+	for i := range peers {
+		if peers[i].PublicKey == publicKey {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func copyDevice(wgdev *wgtypes.Device) *wgtypes.Device {
+	res := *wgdev
+	res.Peers = make([]wgtypes.Peer, 0, len(wgdev.Peers))
+
+	for _, peer := range wgdev.Peers {
+		peer.AllowedIPs = append([]net.IPNet(nil), peer.AllowedIPs...)
+		res.Peers = append(res.Peers, peer)
+	}
+
+	return &res
+}
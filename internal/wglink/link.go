@@ -0,0 +1,564 @@
+package wglink
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/AZhur771/wg-grpc-api/internal/app"
+	"github.com/AZhur771/wg-grpc-api/internal/entity"
+	"github.com/vishvananda/netlink"
+	"go.uber.org/zap"
+	"golang.org/x/sys/unix"
+	"golang.zx2c4.com/wireguard/wgctrl/wgtypes"
+)
+
+// routeProtocol marks routes added for peers, so routes added by anyone else are never removed.
+const routeProtocol netlink.RouteProtocol = 0x57
+
+var (
+	ErrNotWireguard  = errors.New("link is not a wireguard one")
+	ErrInvalidTable  = errors.New("invalid routing table")
+	ErrNetnsNotFound = errors.New("network namespace does not exist")
+)
+
+// Backend creates wireguard links and applies device settings to them through netlink,
+// keys and peers are configured through wgctrl. Settings are applied in place, so peer sessions
+// survive device updates. DNS of the device is only used in peer configs.
+//
+// Links of devices with a network namespace are created in the namespace of the process, so their
+// UDP sockets stay there, and moved to the named one.
+type Backend struct {
+	logger *zap.Logger
+	ctrl   app.WgCtrl
+
+	mu sync.Mutex
+	// tables maps link names to routing tables of peer routes, zero table disables them
+	tables map[string]int
+	// namespaces maps link names to network namespaces they were moved to
+	namespaces map[string]string
+	// clients are wgctrl clients dialed inside network namespaces
+	clients map[string]app.WgCtrl
+	// userspace is set when links are run with wireguard-go instead of the kernel module
+	userspace map[string]*userspaceDevice
+}
+
+// New returns backend managing kernel wireguard links. Namespaces map names of existing devices
+// to their network namespaces, so links kept in them while the process was down are found.
+func New(logger *zap.Logger, ctrl app.WgCtrl, namespaces map[string]string) *Backend {
+	b := &Backend{
+		logger:     logger,
+		ctrl:       ctrl,
+		tables:     make(map[string]int),
+		namespaces: make(map[string]string, len(namespaces)),
+		clients:    make(map[string]app.WgCtrl),
+	}
+
+	for name, ns := range namespaces {
+		b.namespaces[name] = ns
+	}
+
+	return b
+}
+
+// Create creates wireguard link of the device unless it exists and brings it in line with the device:
+// namespace, MTU, key, listen port, firewall mark, addresses and peer routes. Configured peers are kept.
+// PreUp and PostUp hooks are run in the namespace of the process and only when the link is created.
+func (b *Backend) Create(dev *entity.Device) error {
+	table, err := parseTable(dev.Table)
+	if err != nil {
+		return fmt.Errorf("link: %w", err)
+	}
+
+	_, port, err := net.SplitHostPort(dev.Endpoint)
+	if err != nil {
+		return fmt.Errorf("link: %w", err)
+	}
+
+	listenPort, err := strconv.Atoi(port)
+	if err != nil {
+		return fmt.Errorf("link: %w", err)
+	}
+
+	h, err := netnsHandle(dev.Netns)
+	if err != nil {
+		return fmt.Errorf("link: %w", err)
+	}
+	defer h.Close()
+
+	link, created, err := b.findOrAddLink(h, dev)
+	if err != nil {
+		return fmt.Errorf("link: %w", err)
+	}
+
+	if link.Type() != "wireguard" && !b.isUserspace(dev.Name) {
+		return fmt.Errorf("link: %s: %w", dev.Name, ErrNotWireguard)
+	}
+
+	if link.Attrs().MTU != dev.MTU {
+		if err := h.LinkSetMTU(link, dev.MTU); err != nil {
+			return fmt.Errorf("link: set mtu: %w", err)
+		}
+	}
+
+	ctrl, err := b.ctrlFor(dev.Netns)
+	if err != nil {
+		return fmt.Errorf("link: %w", err)
+	}
+
+	if err := ctrl.ConfigureDevice(dev.Name, wgtypes.Config{
+		PrivateKey:   &dev.PrivateKey,
+		ListenPort:   &listenPort,
+		FirewallMark: &dev.FirewallMark,
+	}); err != nil {
+		return fmt.Errorf("link: %w", err)
+	}
+
+	if err := syncAddresses(h, link, dev.Addresses()); err != nil {
+		return fmt.Errorf("link: %w", err)
+	}
+
+	if err := h.LinkSetUp(link); err != nil {
+		return fmt.Errorf("link: set up: %w", err)
+	}
+
+	b.mu.Lock()
+	b.tables[dev.Name] = table
+	b.namespaces[dev.Name] = dev.Netns
+	b.mu.Unlock()
+
+	if err := b.syncRoutes(dev.Name); err != nil {
+		return err
+	}
+
+	if created {
+		if err := runHook(dev.PostUp, dev.Name); err != nil {
+			return fmt.Errorf("link: post up: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// findOrAddLink looks link of the device up in its namespace, link found in the previous namespace
+// of the device or in the namespace of the process is moved there along with its peers and UDP socket.
+// Missing link is added in the namespace of the process after running PreUp hook.
+func (b *Backend) findOrAddLink(h *netlink.Handle, dev *entity.Device) (netlink.Link, bool, error) {
+	link, err := h.LinkByName(dev.Name)
+	if err == nil || !errors.As(err, &netlink.LinkNotFoundError{}) {
+		return link, false, err
+	}
+
+	created := false
+
+	link, src, err := b.findElsewhere(dev)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if link == nil {
+		if err := runHook(dev.PreUp, dev.Name); err != nil {
+			return nil, false, fmt.Errorf("pre up: %w", err)
+		}
+
+		if link, err = b.addLink(dev.Name, dev.MTU); err != nil {
+			return nil, false, err
+		}
+
+		created = true
+
+		// link is already where it should be
+		if dev.Netns == "" {
+			return link, created, nil
+		}
+
+		if src, err = netlink.NewHandle(); err != nil {
+			return nil, false, err
+		}
+	}
+	defer src.Close()
+
+	if err := moveLink(src, link, dev.Netns); err != nil {
+		return nil, false, err
+	}
+
+	if link, err = h.LinkByName(dev.Name); err != nil {
+		return nil, false, err
+	}
+
+	return link, created, nil
+}
+
+// findElsewhere looks link of the device up in the namespace it was last seen in and in the namespace
+// of the process, nil link is returned if there is none. Handle of the namespace the link is found in
+// should be closed by the caller.
+func (b *Backend) findElsewhere(dev *entity.Device) (netlink.Link, *netlink.Handle, error) {
+	candidates := []string{""}
+	if prev := b.netnsOf(dev.Name); prev != "" {
+		candidates = []string{prev, ""}
+	}
+
+	for _, ns := range candidates {
+		if ns == dev.Netns {
+			continue
+		}
+
+		h, err := netnsHandle(ns)
+		// links are removed along with their namespace, so there is nothing left to look up
+		if errors.Is(err, ErrNetnsNotFound) {
+			continue
+		}
+
+		if err != nil {
+			return nil, nil, err
+		}
+
+		link, err := h.LinkByName(dev.Name)
+		if err == nil {
+			return link, h, nil
+		}
+
+		h.Close()
+
+		if !errors.As(err, &netlink.LinkNotFoundError{}) {
+			return nil, nil, err
+		}
+	}
+
+	return nil, nil, nil
+}
+
+// Destroy runs PreDown hook, removes wireguard link of the device along with its addresses and routes
+// and runs PostDown hook. Missing link is not an error.
+func (b *Backend) Destroy(dev *entity.Device) error {
+	b.mu.Lock()
+	delete(b.tables, dev.Name)
+	delete(b.namespaces, dev.Name)
+	b.mu.Unlock()
+
+	h, err := netnsHandle(dev.Netns)
+	if err != nil {
+		return fmt.Errorf("link: %w", err)
+	}
+	defer h.Close()
+
+	link, err := h.LinkByName(dev.Name)
+	if errors.As(err, &netlink.LinkNotFoundError{}) {
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("link: %w", err)
+	}
+
+	if err := runHook(dev.PreDown, dev.Name); err != nil {
+		b.logger.Error("link: pre down", zap.String("device", dev.Name), zap.Error(err))
+	}
+
+	// closing userspace device removes its TUN link
+	if !b.stopUserspace(dev.Name) {
+		if err := h.LinkDel(link); err != nil {
+			return fmt.Errorf("link: delete %s: %w", dev.Name, err)
+		}
+	}
+
+	if err := runHook(dev.PostDown, dev.Name); err != nil {
+		b.logger.Error("link: post down", zap.String("device", dev.Name), zap.Error(err))
+	}
+
+	return nil
+}
+
+// Configure applies config to the link, routes of its peers follow.
+func (b *Backend) Configure(name string, cfg wgtypes.Config) error {
+	ctrl, err := b.ctrlFor(b.netnsOf(name))
+	if err != nil {
+		return fmt.Errorf("link: %w", err)
+	}
+
+	if err := ctrl.ConfigureDevice(name, cfg); err != nil {
+		return err
+	}
+
+	return b.syncRoutes(name)
+}
+
+func (b *Backend) Device(name string) (*wgtypes.Device, error) {
+	ctrl, err := b.ctrlFor(b.netnsOf(name))
+	if err != nil {
+		return nil, fmt.Errorf("link: %w", err)
+	}
+
+	return ctrl.Device(name)
+}
+
+// Devices lists wireguard interfaces of the namespace of the process and of namespaces of created links.
+func (b *Backend) Devices() ([]*wgtypes.Device, error) {
+	wgdevs, err := b.ctrl.Devices()
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool, len(wgdevs))
+	for _, wgdev := range wgdevs {
+		seen[wgdev.Name] = true
+	}
+
+	for _, ns := range b.netnsList() {
+		ctrl, err := b.ctrlFor(ns)
+		if err != nil {
+			return nil, fmt.Errorf("link: %w", err)
+		}
+
+		nsdevs, err := ctrl.Devices()
+		if err != nil {
+			return nil, err
+		}
+
+		// userspace interfaces are listed by every client, as their UAPI sockets are not namespaced
+		for _, wgdev := range nsdevs {
+			if !seen[wgdev.Name] {
+				seen[wgdev.Name] = true
+				wgdevs = append(wgdevs, wgdev)
+			}
+		}
+	}
+
+	return wgdevs, nil
+}
+
+// Close stops userspace devices run by the backend, their TUN links are removed along,
+// and closes wgctrl clients of network namespaces.
+func (b *Backend) Close() error {
+	b.mu.Lock()
+	names := make([]string, 0, len(b.userspace))
+	for name := range b.userspace {
+		names = append(names, name)
+	}
+
+	clients := b.clients
+	b.clients = make(map[string]app.WgCtrl)
+	b.mu.Unlock()
+
+	for _, name := range names {
+		b.stopUserspace(name)
+	}
+
+	for ns, client := range clients {
+		if err := client.Close(); err != nil {
+			b.logger.Error("link: close wgctrl", zap.String("netns", ns), zap.Error(err))
+		}
+	}
+
+	return nil
+}
+
+// addLink adds wireguard link in the namespace of the process,
+// the kernel one unless the backend runs userspace devices.
+func (b *Backend) addLink(name string, mtu int) (netlink.Link, error) {
+	if b.userspace != nil {
+		return b.startUserspace(name, mtu)
+	}
+
+	attrs := netlink.NewLinkAttrs()
+	attrs.Name = name
+	attrs.MTU = mtu
+
+	link := &netlink.Wireguard{LinkAttrs: attrs}
+	if err := netlink.LinkAdd(link); err != nil {
+		return nil, fmt.Errorf("add %s: %w", name, err)
+	}
+
+	return link, nil
+}
+
+// syncRoutes routes allowed IPs of the peers configured on the link through it,
+// networks of the link addresses are skipped in the main table as the kernel routes them already.
+// Routes of removed peers are deleted.
+func (b *Backend) syncRoutes(name string) error {
+	b.mu.Lock()
+	table, ok := b.tables[name]
+	ns := b.namespaces[name]
+	b.mu.Unlock()
+
+	if !ok {
+		return nil
+	}
+
+	h, err := netnsHandle(ns)
+	if err != nil {
+		return fmt.Errorf("link: %w", err)
+	}
+	defer h.Close()
+
+	link, err := h.LinkByName(name)
+	if err != nil {
+		return fmt.Errorf("link: %w", err)
+	}
+
+	ctrl, err := b.ctrlFor(ns)
+	if err != nil {
+		return fmt.Errorf("link: %w", err)
+	}
+
+	wgdev, err := ctrl.Device(name)
+	if err != nil {
+		return fmt.Errorf("link: %w", err)
+	}
+
+	addrs, err := h.AddrList(link, netlink.FAMILY_ALL)
+	if err != nil {
+		return fmt.Errorf("link: %w", err)
+	}
+
+	wanted := make(map[string]*netlink.Route)
+
+	if table != 0 {
+		for _, peer := range wgdev.Peers {
+			for i := range peer.AllowedIPs {
+				dst := peer.AllowedIPs[i]
+
+				if ones, _ := dst.Mask.Size(); ones == 0 {
+					b.logger.Warn("link: default route is not added",
+						zap.String("device", name), zap.String("public_key", peer.PublicKey.String()))
+					continue
+				}
+
+				if table == unix.RT_TABLE_MAIN && coveredByAddrs(addrs, dst) {
+					continue
+				}
+
+				wanted[dst.String()] = &netlink.Route{
+					LinkIndex: link.Attrs().Index,
+					Dst:       &dst,
+					Table:     table,
+					Protocol:  routeProtocol,
+					Scope:     netlink.SCOPE_LINK,
+				}
+			}
+		}
+	}
+
+	routes, err := h.RouteListFiltered(netlink.FAMILY_ALL, &netlink.Route{
+		LinkIndex: link.Attrs().Index,
+		Table:     unix.RT_TABLE_UNSPEC,
+		Protocol:  routeProtocol,
+	}, netlink.RT_FILTER_OIF|netlink.RT_FILTER_TABLE|netlink.RT_FILTER_PROTOCOL)
+	if err != nil {
+		return fmt.Errorf("link: %w", err)
+	}
+
+	for i := range routes {
+		route := routes[i]
+
+		if w, ok := wanted[route.Dst.String()]; ok && w.Table == route.Table {
+			delete(wanted, route.Dst.String())
+			continue
+		}
+
+		if err := h.RouteDel(&route); err != nil {
+			return fmt.Errorf("link: delete route %s: %w", route.Dst, err)
+		}
+	}
+
+	for _, route := range wanted {
+		if err := h.RouteReplace(route); err != nil {
+			return fmt.Errorf("link: add route %s: %w", route.Dst, err)
+		}
+	}
+
+	return nil
+}
+
+// syncAddresses assigns addresses to the link and removes all others but IPv6 link local ones.
+func syncAddresses(h *netlink.Handle, link netlink.Link, addresses []string) error {
+	wanted := make(map[string]*netlink.Addr, len(addresses))
+
+	for _, address := range addresses {
+		addr, err := netlink.ParseAddr(address)
+		if err != nil {
+			return err
+		}
+
+		wanted[addr.IPNet.String()] = addr
+	}
+
+	current, err := h.AddrList(link, netlink.FAMILY_ALL)
+	if err != nil {
+		return err
+	}
+
+	for i := range current {
+		addr := current[i]
+
+		if _, ok := wanted[addr.IPNet.String()]; ok {
+			delete(wanted, addr.IPNet.String())
+			continue
+		}
+
+		if addr.IP.IsLinkLocalUnicast() {
+			continue
+		}
+
+		if err := h.AddrDel(link, &addr); err != nil {
+			return fmt.Errorf("delete address %s: %w", addr.IPNet, err)
+		}
+	}
+
+	for _, addr := range wanted {
+		if err := h.AddrAdd(link, addr); err != nil {
+			return fmt.Errorf("add address %s: %w", addr.IPNet, err)
+		}
+	}
+
+	return nil
+}
+
+// parseTable follows wg-quick Table semantics: empty and auto mean the main table, off disables routes.
+func parseTable(table string) (int, error) {
+	switch strings.ToLower(table) {
+	case "", "auto", "main":
+		return unix.RT_TABLE_MAIN, nil
+	case "off":
+		return 0, nil
+	}
+
+	n, err := strconv.Atoi(table)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("%s: %w", table, ErrInvalidTable)
+	}
+
+	return n, nil
+}
+
+func coveredByAddrs(addrs []netlink.Addr, dst net.IPNet) bool {
+	dstOnes, dstBits := dst.Mask.Size()
+
+	for _, addr := range addrs {
+		ones, bits := addr.Mask.Size()
+		if bits == dstBits && ones <= dstOnes && addr.IPNet.Contains(dst.IP) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// runHook runs wg-quick style hook command, %i is replaced with the interface name.
+func runHook(hook, name string) error {
+	if hook == "" {
+		return nil
+	}
+
+	//nolint:gosec
+	out, err := exec.Command("bash", "-c", strings.ReplaceAll(hook, "%i", name)).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("%w: %s", err, strings.TrimSpace(string(out)))
+	}
+
+	return nil
+}
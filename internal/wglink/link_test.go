@@ -0,0 +1,110 @@
+package wglink
+
+import (
+	"net"
+	"testing"
+
+	"github.com/AZhur771/wg-grpc-api/internal/entity"
+	"github.com/stretchr/testify/require"
+	"github.com/vishvananda/netlink"
+	"go.uber.org/zap"
+	"golang.org/x/sys/unix"
+)
+
+func TestParseTable(t *testing.T) {
+	tests := []struct {
+		table    string
+		expected int
+		invalid  bool
+	}{
+		{table: "", expected: unix.RT_TABLE_MAIN},
+		{table: "auto", expected: unix.RT_TABLE_MAIN},
+		{table: "Auto", expected: unix.RT_TABLE_MAIN},
+		{table: "main", expected: unix.RT_TABLE_MAIN},
+		{table: "off", expected: 0},
+		{table: "1234", expected: 1234},
+		{table: "0", invalid: true},
+		{table: "-1", invalid: true},
+		{table: "vpn", invalid: true},
+	}
+
+	for _, test := range tests {
+		table, err := parseTable(test.table)
+
+		if test.invalid {
+			require.ErrorIsf(t, err, ErrInvalidTable, "table %q", test.table)
+			continue
+		}
+
+		require.NoErrorf(t, err, "table %q", test.table)
+		require.Equalf(t, test.expected, table, "table %q", test.table)
+	}
+}
+
+func TestCoveredByAddrs(t *testing.T) {
+	parseAddr := func(s string) netlink.Addr {
+		ip, ipnet, err := net.ParseCIDR(s)
+		require.NoError(t, err)
+
+		ipnet.IP = ip
+
+		return netlink.Addr{IPNet: ipnet}
+	}
+
+	addrs := []netlink.Addr{
+		parseAddr("10.6.0.1/24"),
+		parseAddr("fd00:6::1/64"),
+	}
+
+	tests := []struct {
+		dst      string
+		expected bool
+	}{
+		{dst: "10.6.0.0/24", expected: true},
+		{dst: "10.6.0.2/32", expected: true},
+		{dst: "10.6.0.128/25", expected: true},
+		{dst: "10.6.0.0/16", expected: false},
+		{dst: "10.7.0.2/32", expected: false},
+		{dst: "fd00:6::2/128", expected: true},
+		{dst: "fd00:6::/64", expected: true},
+		{dst: "fd00::/16", expected: false},
+		{dst: "fd00:7::2/128", expected: false},
+		// IPv4-mapped IPv6 destination is not covered by the IPv4 network
+		{dst: "::ffff:10.6.0.2/128", expected: false},
+	}
+
+	for _, test := range tests {
+		_, dst, err := net.ParseCIDR(test.dst)
+		require.NoError(t, err)
+
+		require.Equalf(t, test.expected, coveredByAddrs(addrs, *dst), "destination %s", test.dst)
+	}
+
+	require.False(t, coveredByAddrs(nil, net.IPNet{IP: net.IPv4(10, 6, 0, 2), Mask: net.CIDRMask(32, 32)}))
+}
+
+func TestNew_Namespaces(t *testing.T) {
+	namespaces := map[string]string{"wg0": "vpn"}
+
+	b := New(zap.NewNop(), nil, namespaces)
+	namespaces["wg0"] = "other"
+
+	require.Equal(t, "vpn", b.netnsOf("wg0"))
+	require.Empty(t, b.netnsOf("wg1"))
+}
+
+func TestOpenNetns_Missing(t *testing.T) {
+	_, err := openNetns("wg-grpc-api-missing")
+	require.ErrorIs(t, err, ErrNetnsNotFound)
+	require.ErrorContains(t, err, "ip netns add wg-grpc-api-missing")
+}
+
+func TestFindElsewhere_MissingNamespace(t *testing.T) {
+	// link in the namespace left by the previous run is gone along with the namespace
+	b := New(zap.NewNop(), nil, map[string]string{"wgmissing0": "wg-grpc-api-missing"})
+
+	link, h, err := b.findElsewhere(&entity.Device{Name: "wgmissing0"})
+	require.NoError(t, err)
+	require.Nil(t, link)
+	require.Nil(t, h)
+}
@@ -0,0 +1,158 @@
+package wglink
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"runtime"
+	"sort"
+
+	"github.com/AZhur771/wg-grpc-api/internal/app"
+	"github.com/vishvananda/netlink"
+	"github.com/vishvananda/netns"
+	"golang.zx2c4.com/wireguard/wgctrl"
+)
+
+// netnsHandle returns netlink handle working in the named network namespace,
+// empty name stands for the namespace of the process.
+func netnsHandle(name string) (*netlink.Handle, error) {
+	if name == "" {
+		return netlink.NewHandle()
+	}
+
+	ns, err := openNetns(name)
+	if err != nil {
+		return nil, err
+	}
+	defer ns.Close()
+
+	h, err := netlink.NewHandleAt(ns)
+	if err != nil {
+		return nil, fmt.Errorf("netns %s: %w", name, err)
+	}
+
+	return h, nil
+}
+
+// openNetns opens the named network namespace, namespaces are never created by the backend.
+func openNetns(name string) (netns.NsHandle, error) {
+	ns, err := netns.GetFromName(name)
+	if errors.Is(err, os.ErrNotExist) {
+		return ns, fmt.Errorf("netns %s: %w, create it with 'ip netns add %s'", name, ErrNetnsNotFound, name)
+	}
+
+	if err != nil {
+		return ns, fmt.Errorf("netns %s: %w", name, err)
+	}
+
+	return ns, nil
+}
+
+// moveLink moves the link from the namespace of the handle to the named one.
+func moveLink(h *netlink.Handle, link netlink.Link, name string) error {
+	var (
+		ns  netns.NsHandle
+		err error
+	)
+
+	if name == "" {
+		ns, err = netns.GetFromPid(os.Getpid())
+		if err != nil {
+			err = fmt.Errorf("netns of process: %w", err)
+		}
+	} else {
+		ns, err = openNetns(name)
+	}
+
+	if err != nil {
+		return err
+	}
+	defer ns.Close()
+
+	if err := h.LinkSetNsFd(link, int(ns)); err != nil {
+		return fmt.Errorf("move %s to netns %s: %w", link.Attrs().Name, name, err)
+	}
+
+	return nil
+}
+
+// inNetns runs fn on a thread switched to the named namespace. The thread is never unlocked,
+// so the runtime terminates it along with the goroutine instead of reusing it in a foreign namespace.
+func inNetns(name string, fn func() error) error {
+	ns, err := openNetns(name)
+	if err != nil {
+		return err
+	}
+	defer ns.Close()
+
+	errc := make(chan error, 1)
+
+	go func() {
+		runtime.LockOSThread()
+
+		if err := netns.Set(ns); err != nil {
+			errc <- fmt.Errorf("netns %s: %w", name, err)
+			return
+		}
+
+		errc <- fn()
+	}()
+
+	return <-errc
+}
+
+// ctrlFor returns wgctrl client working in the named namespace. Clients are dialed inside the namespace
+// and cached, as their netlink sockets stay bound to the namespace they were created in.
+func (b *Backend) ctrlFor(name string) (app.WgCtrl, error) {
+	if name == "" {
+		return b.ctrl, nil
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if client, ok := b.clients[name]; ok {
+		return client, nil
+	}
+
+	var client *wgctrl.Client
+
+	if err := inNetns(name, func() (err error) {
+		client, err = wgctrl.New()
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	b.clients[name] = client
+
+	return client, nil
+}
+
+// netnsOf returns namespace the link was moved to on creation.
+func (b *Backend) netnsOf(name string) string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.namespaces[name]
+}
+
+// netnsList returns namespaces of created links.
+func (b *Backend) netnsList() []string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	seen := make(map[string]bool)
+	res := make([]string, 0)
+
+	for _, ns := range b.namespaces {
+		if ns != "" && !seen[ns] {
+			seen[ns] = true
+			res = append(res, ns)
+		}
+	}
+
+	sort.Strings(res)
+
+	return res
+}
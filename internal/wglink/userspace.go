@@ -0,0 +1,129 @@
+package wglink
+
+import (
+	"errors"
+	"fmt"
+	"net"
+
+	"github.com/AZhur771/wg-grpc-api/internal/app"
+	"github.com/vishvananda/netlink"
+	"go.uber.org/zap"
+	"golang.zx2c4.com/wireguard/conn"
+	"golang.zx2c4.com/wireguard/device"
+	"golang.zx2c4.com/wireguard/ipc"
+	"golang.zx2c4.com/wireguard/tun"
+)
+
+// userspaceDevice is wireguard-go device run by the process on a TUN link,
+// wgctrl reaches it through the UAPI socket, so it reports userspace type.
+type userspaceDevice struct {
+	device *device.Device
+	uapi   net.Listener
+}
+
+// NewUserspace returns backend running interfaces with the embedded wireguard-go on TUN links,
+// for hosts without the wireguard kernel module. Interfaces live as long as the process does.
+// Links are managed through netlink as kernel ones are, so CAP_NET_ADMIN and /dev/net/tun are required.
+func NewUserspace(logger *zap.Logger, ctrl app.WgCtrl, namespaces map[string]string) *Backend {
+	b := New(logger, ctrl, namespaces)
+	b.userspace = make(map[string]*userspaceDevice)
+
+	return b
+}
+
+// startUserspace creates TUN link and runs wireguard-go device on it along with its UAPI socket.
+func (b *Backend) startUserspace(name string, mtu int) (netlink.Link, error) {
+	tunDevice, err := tun.CreateTUN(name, mtu)
+	if err != nil {
+		return nil, fmt.Errorf("create tun %s (CAP_NET_ADMIN and /dev/net/tun are required): %w", name, err)
+	}
+
+	uapiFile, err := ipc.UAPIOpen(name)
+	if err != nil {
+		tunDevice.Close()
+		return nil, fmt.Errorf("open uapi %s: %w", name, err)
+	}
+
+	wgdev := device.NewDevice(tunDevice, conn.NewDefaultBind(), b.deviceLogger(name))
+
+	// link events are not seen once the TUN link is moved to another namespace, so the device is brought up
+	// right away instead of waiting for the link to be set up
+	if err := wgdev.Up(); err != nil {
+		wgdev.Close()
+		uapiFile.Close()
+		return nil, fmt.Errorf("up %s: %w", name, err)
+	}
+
+	uapi, err := ipc.UAPIListen(name, uapiFile)
+	uapiFile.Close()
+
+	if err != nil {
+		wgdev.Close()
+		return nil, fmt.Errorf("listen uapi %s: %w", name, err)
+	}
+
+	go func() {
+		for {
+			conn, err := uapi.Accept()
+			if err != nil {
+				return
+			}
+
+			go wgdev.IpcHandle(conn)
+		}
+	}()
+
+	b.mu.Lock()
+	b.userspace[name] = &userspaceDevice{device: wgdev, uapi: uapi}
+	b.mu.Unlock()
+
+	link, err := netlink.LinkByName(name)
+	if err != nil {
+		b.stopUserspace(name)
+		return nil, err
+	}
+
+	return link, nil
+}
+
+// stopUserspace closes wireguard-go device run by the backend, false is returned if there is none.
+func (b *Backend) stopUserspace(name string) bool {
+	b.mu.Lock()
+	ud, ok := b.userspace[name]
+	delete(b.userspace, name)
+	b.mu.Unlock()
+
+	if !ok {
+		return false
+	}
+
+	if err := ud.uapi.Close(); err != nil && !errors.Is(err, net.ErrClosed) {
+		b.logger.Error("link: close uapi", zap.String("device", name), zap.Error(err))
+	}
+
+	ud.device.Close()
+
+	return true
+}
+
+func (b *Backend) isUserspace(name string) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	_, ok := b.userspace[name]
+
+	return ok
+}
+
+func (b *Backend) deviceLogger(name string) *device.Logger {
+	logger := b.logger.With(zap.String("device", name))
+
+	return &device.Logger{
+		Verbosef: func(format string, args ...any) {
+			logger.Debug("wireguard-go: " + fmt.Sprintf(format, args...))
+		},
+		Errorf: func(format string, args ...any) {
+			logger.Error("wireguard-go: " + fmt.Sprintf(format, args...))
+		},
+	}
+}
@@ -0,0 +1,134 @@
+package wglink_test
+
+import (
+	"net"
+	"os"
+	"testing"
+
+	"github.com/AZhur771/wg-grpc-api/internal/entity"
+	"github.com/AZhur771/wg-grpc-api/internal/wglink"
+	"github.com/stretchr/testify/require"
+	"github.com/vishvananda/netlink"
+	"go.uber.org/zap"
+	"golang.zx2c4.com/wireguard/wgctrl"
+	"golang.zx2c4.com/wireguard/wgctrl/wgtypes"
+)
+
+// newUserspaceTest returns userspace backend, the test is skipped unless TUN links can be created,
+// that takes CAP_NET_ADMIN and /dev/net/tun.
+func newUserspaceTest(t *testing.T) *wglink.Backend {
+	t.Helper()
+
+	tun, err := os.OpenFile("/dev/net/tun", os.O_RDWR, 0)
+	if err != nil || os.Geteuid() != 0 {
+		t.Skip("userspace backend needs root and /dev/net/tun")
+	}
+	tun.Close()
+
+	client, err := wgctrl.New()
+	require.NoError(t, err)
+
+	backend := wglink.NewUserspace(zap.NewNop(), client, nil)
+	t.Cleanup(func() {
+		backend.Close()
+		client.Close()
+	})
+
+	return backend
+}
+
+func generateTestDevice(t *testing.T, name string) *entity.Device {
+	t.Helper()
+
+	privateKey, err := wgtypes.GeneratePrivateKey()
+	require.NoError(t, err)
+
+	return &entity.Device{
+		Name:       name,
+		Endpoint:   "127.0.0.1:51899",
+		Address:    "10.99.0.1/24",
+		MTU:        1420,
+		PrivateKey: privateKey,
+		PublicKey:  privateKey.PublicKey(),
+	}
+}
+
+func TestUserspace_Lifecycle(t *testing.T) {
+	backend := newUserspaceTest(t)
+	dev := generateTestDevice(t, "wgtest0")
+
+	require.NoError(t, backend.Create(dev))
+
+	wgdev, err := backend.Device(dev.Name)
+	require.NoError(t, err)
+	require.Equal(t, wgtypes.Userspace, wgdev.Type)
+	require.Equal(t, dev.PrivateKey, wgdev.PrivateKey)
+	require.Equal(t, 51899, wgdev.ListenPort)
+
+	link, err := netlink.LinkByName(dev.Name)
+	require.NoError(t, err)
+	require.Equal(t, "tuntap", link.Type())
+	require.NotZero(t, link.Attrs().Flags&net.FlagUp)
+
+	addrs, err := netlink.AddrList(link, netlink.FAMILY_V4)
+	require.NoError(t, err)
+	require.Len(t, addrs, 1)
+	require.Equal(t, "10.99.0.1/24", addrs[0].IPNet.String())
+
+	peerKey, err := wgtypes.GeneratePrivateKey()
+	require.NoError(t, err)
+
+	_, allowedIP, err := net.ParseCIDR("10.99.0.2/32")
+	require.NoError(t, err)
+
+	require.NoError(t, backend.Configure(dev.Name, wgtypes.Config{
+		Peers: []wgtypes.PeerConfig{{PublicKey: peerKey.PublicKey(), AllowedIPs: []net.IPNet{*allowedIP}}},
+	}))
+
+	// device update is applied in place, the running wireguard-go device keeps its peers
+	dev.MTU = 1380
+	require.NoError(t, backend.Create(dev))
+
+	wgdev, err = backend.Device(dev.Name)
+	require.NoError(t, err)
+	require.Len(t, wgdev.Peers, 1)
+	require.Equal(t, peerKey.PublicKey(), wgdev.Peers[0].PublicKey)
+
+	link, err = netlink.LinkByName(dev.Name)
+	require.NoError(t, err)
+	require.Equal(t, 1380, link.Attrs().MTU)
+
+	wgdevs, err := backend.Devices()
+	require.NoError(t, err)
+	require.Contains(t, deviceNames(wgdevs), dev.Name)
+
+	require.NoError(t, backend.Destroy(dev))
+
+	_, err = netlink.LinkByName(dev.Name)
+	require.Error(t, err)
+
+	_, err = backend.Device(dev.Name)
+	require.Error(t, err)
+}
+
+func TestUserspace_Close(t *testing.T) {
+	backend := newUserspaceTest(t)
+	dev := generateTestDevice(t, "wgtest1")
+
+	require.NoError(t, backend.Create(dev))
+
+	// interfaces live as long as the process does
+	require.NoError(t, backend.Close())
+
+	_, err := netlink.LinkByName(dev.Name)
+	require.Error(t, err)
+}
+
+func deviceNames(wgdevs []*wgtypes.Device) []string {
+	names := make([]string, 0, len(wgdevs))
+	for _, wgdev := range wgdevs {
+		names = append(names, wgdev.Name)
+	}
+
+	return names
+}
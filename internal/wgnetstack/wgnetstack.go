@@ -0,0 +1,266 @@
+package wgnetstack
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"os"
+	"slices"
+	"strconv"
+	"sync"
+
+	"github.com/AZhur771/wg-grpc-api/internal/app"
+	"github.com/AZhur771/wg-grpc-api/internal/entity"
+	"go.uber.org/zap"
+	"golang.zx2c4.com/wireguard/conn"
+	"golang.zx2c4.com/wireguard/device"
+	"golang.zx2c4.com/wireguard/ipc"
+	"golang.zx2c4.com/wireguard/tun/netstack"
+	"golang.zx2c4.com/wireguard/wgctrl/wgtypes"
+)
+
+var ErrNetnsUnsupported = errors.New("network namespaces are not supported by netstack backend")
+
+// Backend runs interfaces with the embedded wireguard-go on gVisor network stacks inside the process,
+// so neither the wireguard kernel module nor CAP_NET_ADMIN and /dev/net/tun are needed. Keys and peers
+// are configured through UAPI sockets, as wgctrl does for any userspace interface.
+//
+// Interfaces have no kernel links: peer traffic ends in the network stack of the interface and is never
+// routed to the host, so routing tables and hooks of devices are ignored. Interfaces live as long as
+// the process does.
+type Backend struct {
+	logger *zap.Logger
+	ctrl   app.WgCtrl
+
+	mu      sync.Mutex
+	devices map[string]*netstackDevice
+}
+
+// netstackDevice is wireguard-go device run on a network stack, addresses and MTU of the stack
+// are fixed once it is created.
+type netstackDevice struct {
+	device *device.Device
+	uapi   net.Listener
+	addrs  []netip.Addr
+	mtu    int
+}
+
+func New(logger *zap.Logger, ctrl app.WgCtrl) *Backend {
+	return &Backend{
+		logger:  logger,
+		ctrl:    ctrl,
+		devices: make(map[string]*netstackDevice),
+	}
+}
+
+// Create starts interface of the device unless it runs, existing interface gets key, listen port and
+// firewall mark in place. Interface is restarted with its peers when addresses or MTU change.
+func (b *Backend) Create(dev *entity.Device) error {
+	if dev.Netns != "" {
+		return fmt.Errorf("netstack: %s: %w", dev.Name, ErrNetnsUnsupported)
+	}
+
+	_, port, err := net.SplitHostPort(dev.Endpoint)
+	if err != nil {
+		return fmt.Errorf("netstack: %w", err)
+	}
+
+	listenPort, err := strconv.Atoi(port)
+	if err != nil {
+		return fmt.Errorf("netstack: %w", err)
+	}
+
+	addrs, err := parseAddrs(dev.Addresses())
+	if err != nil {
+		return fmt.Errorf("netstack: %w", err)
+	}
+
+	if dev.PreUp != "" || dev.PostUp != "" || dev.PreDown != "" || dev.PostDown != "" {
+		b.logger.Warn("netstack: hooks are not run for interfaces without kernel links", zap.String("device", dev.Name))
+	}
+
+	var peers []wgtypes.PeerConfig
+
+	b.mu.Lock()
+	nd, running := b.devices[dev.Name]
+	b.mu.Unlock()
+
+	if running && (nd.mtu != dev.MTU || !slices.Equal(nd.addrs, addrs)) {
+		wgdev, err := b.ctrl.Device(dev.Name)
+		if err != nil {
+			return fmt.Errorf("netstack: %w", err)
+		}
+
+		peers = peerConfigs(wgdev.Peers)
+
+		b.stop(dev.Name)
+		running = false
+	}
+
+	if !running {
+		if err := b.start(dev.Name, addrs, dev.MTU); err != nil {
+			return fmt.Errorf("netstack: %w", err)
+		}
+	}
+
+	if err := b.ctrl.ConfigureDevice(dev.Name, wgtypes.Config{
+		PrivateKey:   &dev.PrivateKey,
+		ListenPort:   &listenPort,
+		FirewallMark: &dev.FirewallMark,
+		Peers:        peers,
+	}); err != nil {
+		return fmt.Errorf("netstack: %w", err)
+	}
+
+	return nil
+}
+
+func (b *Backend) Configure(name string, cfg wgtypes.Config) error {
+	return b.ctrl.ConfigureDevice(name, cfg)
+}
+
+// Destroy stops interface of the device, missing interface is not an error.
+func (b *Backend) Destroy(dev *entity.Device) error {
+	b.stop(dev.Name)
+
+	return nil
+}
+
+func (b *Backend) Device(name string) (*wgtypes.Device, error) {
+	return b.ctrl.Device(name)
+}
+
+func (b *Backend) Devices() ([]*wgtypes.Device, error) {
+	return b.ctrl.Devices()
+}
+
+// Close stops all interfaces run by the backend.
+func (b *Backend) Close() error {
+	b.mu.Lock()
+	names := make([]string, 0, len(b.devices))
+	for name := range b.devices {
+		names = append(names, name)
+	}
+	b.mu.Unlock()
+
+	for _, name := range names {
+		b.stop(name)
+	}
+
+	return nil
+}
+
+// start creates network stack with the addresses and runs wireguard-go device on it along with its UAPI socket.
+func (b *Backend) start(name string, addrs []netip.Addr, mtu int) error {
+	tunDevice, _, err := netstack.CreateNetTUN(addrs, nil, mtu)
+	if err != nil {
+		return fmt.Errorf("create netstack %s: %w", name, err)
+	}
+
+	uapiFile, err := ipc.UAPIOpen(name)
+	if err != nil {
+		tunDevice.Close()
+		return fmt.Errorf("open uapi %s: %w", name, err)
+	}
+
+	wgdev := device.NewDevice(tunDevice, conn.NewDefaultBind(), b.deviceLogger(name))
+
+	if err := wgdev.Up(); err != nil {
+		wgdev.Close()
+		uapiFile.Close()
+		return fmt.Errorf("up %s: %w", name, err)
+	}
+
+	uapi, err := ipc.UAPIListen(name, uapiFile)
+	uapiFile.Close()
+
+	if err != nil {
+		wgdev.Close()
+		return fmt.Errorf("listen uapi %s: %w", name, err)
+	}
+
+	go func() {
+		for {
+			conn, err := uapi.Accept()
+			if err != nil {
+				return
+			}
+
+			go wgdev.IpcHandle(conn)
+		}
+	}()
+
+	b.mu.Lock()
+	b.devices[name] = &netstackDevice{device: wgdev, uapi: uapi, addrs: addrs, mtu: mtu}
+	b.mu.Unlock()
+
+	return nil
+}
+
+// stop closes wireguard-go device run by the backend along with its UAPI socket.
+func (b *Backend) stop(name string) {
+	b.mu.Lock()
+	nd, ok := b.devices[name]
+	delete(b.devices, name)
+	b.mu.Unlock()
+
+	if !ok {
+		return
+	}
+
+	if err := nd.uapi.Close(); err != nil && !errors.Is(err, net.ErrClosed) && !errors.Is(err, os.ErrNotExist) {
+		b.logger.Error("netstack: close uapi", zap.String("device", name), zap.Error(err))
+	}
+
+	nd.device.Close()
+}
+
+func (b *Backend) deviceLogger(name string) *device.Logger {
+	logger := b.logger.With(zap.String("device", name))
+
+	return &device.Logger{
+		Verbosef: func(format string, args ...any) {
+			logger.Debug("wireguard-go: " + fmt.Sprintf(format, args...))
+		},
+		Errorf: func(format string, args ...any) {
+			logger.Error("wireguard-go: " + fmt.Sprintf(format, args...))
+		},
+	}
+}
+
+// parseAddrs returns interface addresses of the device networks.
+func parseAddrs(cidrs []string) ([]netip.Addr, error) {
+	addrs := make([]netip.Addr, 0, len(cidrs))
+
+	for _, cidr := range cidrs {
+		prefix, err := netip.ParsePrefix(cidr)
+		if err != nil {
+			return nil, err
+		}
+
+		addrs = append(addrs, prefix.Addr())
+	}
+
+	return addrs, nil
+}
+
+// peerConfigs returns configs recreating the peers on a fresh interface.
+func peerConfigs(peers []wgtypes.Peer) []wgtypes.PeerConfig {
+	configs := make([]wgtypes.PeerConfig, 0, len(peers))
+
+	for i := range peers {
+		peer := peers[i]
+
+		configs = append(configs, wgtypes.PeerConfig{
+			PublicKey:                   peer.PublicKey,
+			PresharedKey:                &peer.PresharedKey,
+			Endpoint:                    peer.Endpoint,
+			PersistentKeepaliveInterval: &peer.PersistentKeepaliveInterval,
+			ReplaceAllowedIPs:           true,
+			AllowedIPs:                  peer.AllowedIPs,
+		})
+	}
+
+	return configs
+}
@@ -0,0 +1,145 @@
+package wgnetstack_test
+
+import (
+	"net"
+	"os"
+	"testing"
+
+	"github.com/AZhur771/wg-grpc-api/internal/entity"
+	"github.com/AZhur771/wg-grpc-api/internal/wgnetstack"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"golang.zx2c4.com/wireguard/wgctrl"
+	"golang.zx2c4.com/wireguard/wgctrl/wgtypes"
+)
+
+// newNetstackTest returns netstack backend, the test is skipped unless UAPI sockets can be created
+// in /var/run/wireguard.
+func newNetstackTest(t *testing.T) *wgnetstack.Backend {
+	t.Helper()
+
+	if err := os.MkdirAll("/var/run/wireguard", 0o755); err != nil {
+		t.Skip("netstack backend needs writable /var/run/wireguard")
+	}
+
+	client, err := wgctrl.New()
+	require.NoError(t, err)
+
+	backend := wgnetstack.New(zap.NewNop(), client)
+	t.Cleanup(func() {
+		backend.Close()
+		client.Close()
+	})
+
+	return backend
+}
+
+func generateTestDevice(t *testing.T, name string) *entity.Device {
+	t.Helper()
+
+	privateKey, err := wgtypes.GeneratePrivateKey()
+	require.NoError(t, err)
+
+	return &entity.Device{
+		Name:       name,
+		Endpoint:   "127.0.0.1:51898",
+		Address:    "10.98.0.1/24",
+		MTU:        1420,
+		PrivateKey: privateKey,
+		PublicKey:  privateKey.PublicKey(),
+	}
+}
+
+func TestNetstack_Lifecycle(t *testing.T) {
+	backend := newNetstackTest(t)
+	dev := generateTestDevice(t, "wgstack0")
+
+	require.NoError(t, backend.Create(dev))
+
+	wgdev, err := backend.Device(dev.Name)
+	require.NoError(t, err)
+	require.Equal(t, wgtypes.Userspace, wgdev.Type)
+	require.Equal(t, dev.PrivateKey, wgdev.PrivateKey)
+	require.Equal(t, 51898, wgdev.ListenPort)
+
+	// no kernel link is created
+	_, err = net.InterfaceByName(dev.Name)
+	require.Error(t, err)
+
+	peerKey, err := wgtypes.GeneratePrivateKey()
+	require.NoError(t, err)
+
+	_, allowedIP, err := net.ParseCIDR("10.98.0.2/32")
+	require.NoError(t, err)
+
+	require.NoError(t, backend.Configure(dev.Name, wgtypes.Config{
+		Peers: []wgtypes.PeerConfig{{PublicKey: peerKey.PublicKey(), AllowedIPs: []net.IPNet{*allowedIP}}},
+	}))
+
+	// key change is applied in place
+	newKey, err := wgtypes.GeneratePrivateKey()
+	require.NoError(t, err)
+
+	dev.PrivateKey = newKey
+	require.NoError(t, backend.Create(dev))
+
+	wgdev, err = backend.Device(dev.Name)
+	require.NoError(t, err)
+	require.Equal(t, newKey, wgdev.PrivateKey)
+	require.Len(t, wgdev.Peers, 1)
+
+	// network stack is recreated with the new address, peers are kept
+	dev.Address = "10.97.0.1/24"
+	dev.MTU = 1380
+	require.NoError(t, backend.Create(dev))
+
+	wgdev, err = backend.Device(dev.Name)
+	require.NoError(t, err)
+	require.Equal(t, newKey, wgdev.PrivateKey)
+	require.Len(t, wgdev.Peers, 1)
+	require.Equal(t, peerKey.PublicKey(), wgdev.Peers[0].PublicKey)
+	require.Equal(t, []net.IPNet{*allowedIP}, wgdev.Peers[0].AllowedIPs)
+
+	wgdevs, err := backend.Devices()
+	require.NoError(t, err)
+	require.Contains(t, deviceNames(wgdevs), dev.Name)
+
+	require.NoError(t, backend.Destroy(dev))
+
+	_, err = backend.Device(dev.Name)
+	require.ErrorIs(t, err, os.ErrNotExist)
+
+	// destroying missing interface is not an error
+	require.NoError(t, backend.Destroy(dev))
+}
+
+func TestNetstack_Netns(t *testing.T) {
+	backend := wgnetstack.New(zap.NewNop(), nil)
+	dev := generateTestDevice(t, "wgstack1")
+	dev.Netns = "vpn"
+
+	require.ErrorIs(t, backend.Create(dev), wgnetstack.ErrNetnsUnsupported)
+}
+
+func TestNetstack_Close(t *testing.T) {
+	backend := newNetstackTest(t)
+	dev := generateTestDevice(t, "wgstack2")
+	dev.Endpoint = "127.0.0.1:51897"
+
+	require.NoError(t, backend.Create(dev))
+
+	// interfaces live as long as the process does
+	require.NoError(t, backend.Close())
+
+	_, err := backend.Device(dev.Name)
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func deviceNames(wgdevs []*wgtypes.Device) []string {
+	names := make([]string, 0, len(wgdevs))
+	for _, wgdev := range wgdevs {
+		names = append(names, wgdev.Name)
+	}
+
+	return names
+}
@@ -0,0 +1,165 @@
+package wgquick
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/AZhur771/wg-grpc-api/internal/app"
+	"github.com/AZhur771/wg-grpc-api/internal/entity"
+	tmpl "github.com/AZhur771/wg-grpc-api/internal/template"
+	"go.uber.org/zap"
+	"golang.zx2c4.com/wireguard/wgctrl/wgtypes"
+)
+
+var ErrNetnsUnsupported = errors.New("network namespaces are not supported by wg-quick backend")
+
+// Backend renders /etc/wireguard configs of devices and brings interfaces up and down with wg-quick,
+// keys and peers are configured through wgctrl. Applying device settings restarts the interface.
+type Backend struct {
+	logger *zap.Logger
+	ctrl   app.WgCtrl
+	dir    string
+	run    func(args ...string) error
+}
+
+func New(logger *zap.Logger, ctrl app.WgCtrl) *Backend {
+	return &Backend{
+		logger: logger,
+		ctrl:   ctrl,
+		dir:    "/etc/wireguard",
+		run:    wgQuick,
+	}
+}
+
+// Create restarts existing interface with peers configured on it. The interface is brought down
+// before the config is written, since wg-quick saves the running interface to the config on down.
+func (b *Backend) Create(dev *entity.Device) error {
+	if dev.Netns != "" {
+		return fmt.Errorf("wg-quick: %s: %w", dev.Name, ErrNetnsUnsupported)
+	}
+
+	var peers []wgtypes.Peer
+
+	wgdev, err := b.ctrl.Device(dev.Name)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("wg-quick: %w", err)
+	}
+
+	if err == nil {
+		peers = wgdev.Peers
+
+		if err := b.run("down", dev.Name); err != nil {
+			b.logger.Error(fmt.Sprintf("'wg-quick down %s' errored", dev.Name), zap.Error(err))
+		}
+	}
+
+	if err := b.writeConfig(dev, peers); err != nil {
+		return fmt.Errorf("wg-quick: %w", err)
+	}
+
+	if err := b.run("up", dev.Name); err != nil {
+		return fmt.Errorf("wg-quick: up %s: %w", dev.Name, err)
+	}
+
+	return nil
+}
+
+func (b *Backend) Configure(name string, cfg wgtypes.Config) error {
+	return b.ctrl.ConfigureDevice(name, cfg)
+}
+
+func (b *Backend) Destroy(dev *entity.Device) error {
+	if err := b.run("down", dev.Name); err != nil {
+		b.logger.Error(fmt.Sprintf("'wg-quick down %s' errored", dev.Name), zap.Error(err))
+	}
+
+	filename := b.configPath(dev.Name)
+	if err := os.Remove(filename); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("wg-quick: %w", err)
+	}
+
+	return nil
+}
+
+func (b *Backend) Device(name string) (*wgtypes.Device, error) {
+	return b.ctrl.Device(name)
+}
+
+func (b *Backend) Devices() ([]*wgtypes.Device, error) {
+	return b.ctrl.Devices()
+}
+
+// writeConfig renders /etc/wireguard config of the device along with the given peers.
+func (b *Backend) writeConfig(dev *entity.Device, peers []wgtypes.Peer) error {
+	filename := b.configPath(dev.Name)
+
+	t, err := template.New("config").Funcs(
+		template.FuncMap{
+			"StringsJoin": strings.Join,
+		},
+	).Parse(tmpl.ConfigTemplate)
+	if err != nil {
+		return err
+	}
+
+	port := strings.Split(dev.Endpoint, ":")[1]
+
+	tmplData := tmpl.ConfigTmplData{
+		InterfacePrivateKey: dev.PrivateKey.String(),
+		InterfaceAddress:    dev.Addresses(),
+		InterfacePort:       port,
+		InterfaceMTU:        dev.MTU,
+		InterfaceTable:      dev.Table,
+		InterfaceDNS:        dev.DNS,
+		InterfaceFwMark:     dev.FirewallMark,
+		InterfacePreUp:      dev.PreUp,
+		InterfacePostUp:     dev.PostUp,
+		InterfacePreDown:    dev.PreDown,
+		InterfacePostDown:   dev.PostDown,
+		SaveConfig:          true,
+	}
+
+	for _, wgpeer := range peers {
+		tmplData.InterfacePeers = append(tmplData.InterfacePeers, tmpl.PeerConfigTmplData{
+			PeerPublicKey:           wgpeer.PublicKey.String(),
+			PeerPresharedKey:        wgpeer.PresharedKey.String(),
+			PeerEndpoint:            wgpeer.Endpoint.String(),
+			PeerAllowedIPs:          stringifyAllowedIPs(wgpeer.AllowedIPs),
+			PeerPersistentKeepalive: int(wgpeer.PersistentKeepaliveInterval) / (1000 * 1000 * 1000),
+		})
+	}
+
+	var buf bytes.Buffer
+
+	if err := t.Execute(&buf, tmplData); err != nil {
+		return err
+	}
+
+	return os.WriteFile(filename, buf.Bytes(), 0o600)
+}
+
+func (b *Backend) configPath(name string) string {
+	return filepath.Join(b.dir, name+".conf")
+}
+
+func wgQuick(args ...string) error {
+	//nolint:gosec
+	return exec.Command("wg-quick", args...).Run()
+}
+
+func stringifyAllowedIPs(allowedIPs []net.IPNet) []string {
+	res := make([]string, 0, len(allowedIPs))
+
+	for _, allowedIP := range allowedIPs {
+		res = append(res, allowedIP.String())
+	}
+
+	return res
+}
@@ -0,0 +1,112 @@
+package wgquick
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	app_mocks "github.com/AZhur771/wg-grpc-api/internal/app/mocks"
+	"github.com/AZhur771/wg-grpc-api/internal/entity"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"golang.zx2c4.com/wireguard/wgctrl/wgtypes"
+)
+
+func TestBackend_CreateRunning(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	wgctrl := app_mocks.NewMockWgCtrl(ctrl)
+
+	oldKey, err := wgtypes.GeneratePrivateKey()
+	require.NoError(t, err)
+
+	newKey, err := wgtypes.GeneratePrivateKey()
+	require.NoError(t, err)
+
+	peerKey, err := wgtypes.GeneratePrivateKey()
+	require.NoError(t, err)
+
+	dev := &entity.Device{
+		Name:       "wg0",
+		Endpoint:   "203.0.113.1:51820",
+		Address:    "10.6.0.1/24",
+		MTU:        1420,
+		PrivateKey: newKey,
+		PublicKey:  newKey.PublicKey(),
+	}
+
+	wgctrl.EXPECT().Device(dev.Name).Return(&wgtypes.Device{
+		Name:       dev.Name,
+		PrivateKey: oldKey,
+		Peers: []wgtypes.Peer{{
+			PublicKey:  peerKey.PublicKey(),
+			AllowedIPs: []net.IPNet{{IP: net.IPv4(10, 6, 0, 2), Mask: net.CIDRMask(32, 32)}},
+		}},
+	}, nil)
+
+	b := New(zap.NewNop(), wgctrl)
+	b.dir = t.TempDir()
+
+	filename := filepath.Join(b.dir, dev.Name+".conf")
+
+	var commands []string
+
+	b.run = func(args ...string) error {
+		commands = append(commands, strings.Join(args, " "))
+
+		// wg-quick saves the running interface to the config on down, as SaveConfig is set
+		if args[0] == "down" {
+			return os.WriteFile(filename, []byte("PrivateKey = "+oldKey.String()+"\n"), 0o600)
+		}
+
+		return nil
+	}
+
+	require.NoError(t, b.Create(dev))
+	require.Equal(t, []string{"down wg0", "up wg0"}, commands)
+
+	config, err := os.ReadFile(filename)
+	require.NoError(t, err)
+	require.Contains(t, string(config), "PrivateKey = "+newKey.String())
+	require.NotContains(t, string(config), oldKey.String())
+	require.Contains(t, string(config), "PublicKey = "+peerKey.PublicKey().String())
+	require.Contains(t, string(config), "AllowedIPs = 10.6.0.2/32")
+}
+
+func TestBackend_CreateNotRunning(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	wgctrl := app_mocks.NewMockWgCtrl(ctrl)
+
+	key, err := wgtypes.GeneratePrivateKey()
+	require.NoError(t, err)
+
+	dev := &entity.Device{
+		Name:       "wg0",
+		Endpoint:   "203.0.113.1:51820",
+		Address:    "10.6.0.1/24",
+		PrivateKey: key,
+		PublicKey:  key.PublicKey(),
+	}
+
+	wgctrl.EXPECT().Device(dev.Name).Return(nil, os.ErrNotExist)
+
+	b := New(zap.NewNop(), wgctrl)
+	b.dir = t.TempDir()
+
+	var commands []string
+
+	b.run = func(args ...string) error {
+		commands = append(commands, strings.Join(args, " "))
+		return nil
+	}
+
+	require.NoError(t, b.Create(dev))
+	require.Equal(t, []string{"up wg0"}, commands)
+
+	config, err := os.ReadFile(filepath.Join(b.dir, dev.Name+".conf"))
+	require.NoError(t, err)
+	require.Contains(t, string(config), "PrivateKey = "+key.String())
+	require.NotContains(t, string(config), "[Peer]")
+}
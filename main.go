@@ -4,23 +4,38 @@
 	"context"
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
 	"log"
 	"os"
 	"os/signal"
+	"path/filepath"
+	"strings"
 	"syscall"
 
 	"github.com/AZhur771/wg-grpc-api/internal/app"
 	database "github.com/AZhur771/wg-grpc-api/internal/db"
+	"github.com/AZhur771/wg-grpc-api/internal/dto"
+	"github.com/AZhur771/wg-grpc-api/internal/metrics"
+	apikeyrepo "github.com/AZhur771/wg-grpc-api/internal/repo/apikey"
+	auditrepo "github.com/AZhur771/wg-grpc-api/internal/repo/audit"
 	devicerepo "github.com/AZhur771/wg-grpc-api/internal/repo/device"
 	peerrepo "github.com/AZhur771/wg-grpc-api/internal/repo/peer"
+	"github.com/AZhur771/wg-grpc-api/internal/secrets"
 	"github.com/AZhur771/wg-grpc-api/internal/server"
+	apikeyservice "github.com/AZhur771/wg-grpc-api/internal/service/apikey"
+	auditservice "github.com/AZhur771/wg-grpc-api/internal/service/audit"
+	"github.com/AZhur771/wg-grpc-api/internal/service/common"
 	deviceservice "github.com/AZhur771/wg-grpc-api/internal/service/device"
 	peerservice "github.com/AZhur771/wg-grpc-api/internal/service/peer"
+	"github.com/AZhur771/wg-grpc-api/internal/wglink"
+	"github.com/AZhur771/wg-grpc-api/internal/wgnetstack"
+	"github.com/AZhur771/wg-grpc-api/internal/wgquick"
 	_ "github.com/AZhur771/wg-grpc-api/migrations"
 	"github.com/caarlos0/env/v6"
 	_ "github.com/jackc/pgx/v4/stdlib"
+	"github.com/jmoiron/sqlx"
 	"github.com/pressly/goose/v3"
 	"go.uber.org/zap"
 	"golang.zx2c4.com/wireguard/wgctrl"
@@ -48,6 +63,117 @@ func initializeLogger(isProd bool) (*zap.Logger, error) {
 	return zap.NewDevelopment()
 }
 
+// rotateMasterKey re-encrypts all stored keys with the new master key in a single transaction,
+// the new key has to replace the current one in the configuration afterwards.
+// Unencrypted keys are encrypted as well, so it is also run once master key is set for the first time.
+func rotateMasterKey(
+	ctx context.Context, logger *zap.Logger, db *sqlx.DB,
+	deviceRepo *devicerepo.DeviceRepo, peerRepo *peerrepo.PeerRepo, cfg app.Config,
+) error {
+	newCipher, err := secrets.LoadCipher(cfg.NewMasterKey, cfg.NewMasterKeyFile)
+	if err != nil {
+		return fmt.Errorf("rotate master key: %w", err)
+	}
+
+	if newCipher == nil {
+		return fmt.Errorf("rotate master key: %w", secrets.ErrInvalidMasterKey)
+	}
+
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("rotate master key: %w", err)
+	}
+	defer tx.Rollback()
+
+	devices, err := deviceRepo.ReencryptKeys(ctx, tx, newCipher)
+	if err != nil {
+		return fmt.Errorf("rotate master key: %w", err)
+	}
+
+	peers, err := peerRepo.ReencryptKeys(ctx, tx, newCipher)
+	if err != nil {
+		return fmt.Errorf("rotate master key: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("rotate master key: %w", err)
+	}
+
+	logger.Info("master key rotated", zap.Int("device_keys", devices), zap.Int("peer_keys", peers))
+
+	return nil
+}
+
+// importDevice imports wg-quick config file given as the first argument, interface name defaults to the file name.
+func importDevice(ctx context.Context, logger *zap.Logger, deviceService *deviceservice.DeviceService, args []string) error {
+	flags := flag.NewFlagSet("import-device", flag.ContinueOnError)
+	name := flags.String("name", "", "interface name, defaults to config file name")
+	endpoint := flags.String("endpoint", "", "public host or host:port of the device")
+	description := flags.String("description", "", "device description")
+
+	if err := flags.Parse(args); err != nil {
+		return fmt.Errorf("import device: %w", err)
+	}
+
+	if flags.NArg() != 1 {
+		return fmt.Errorf("import device: usage: import-device [flags] /etc/wireguard/wg0.conf")
+	}
+
+	filename := flags.Arg(0)
+
+	config, err := os.ReadFile(filename)
+	if err != nil {
+		return fmt.Errorf("import device: %w", err)
+	}
+
+	if *name == "" {
+		*name = strings.TrimSuffix(filepath.Base(filename), filepath.Ext(filename))
+	}
+
+	resp, err := deviceService.Import(ctx, dto.ImportDeviceDTO{
+		Name:        *name,
+		Config:      string(config),
+		Endpoint:    *endpoint,
+		Description: *description,
+	})
+
+	errInvalidData := &common.ErrInvalidData{}
+	if errors.As(err, errInvalidData) {
+		for _, violation := range errInvalidData.Details().GetFieldViolations() {
+			logger.Error("import device: invalid config",
+				zap.String("field", violation.GetField()), zap.String("description", violation.GetDescription()))
+		}
+	}
+
+	if err != nil {
+		return fmt.Errorf("import device: %w", err)
+	}
+
+	logger.Info("device imported",
+		zap.String("id", resp.Device.ID.String()), zap.String("name", resp.Device.Name), zap.Int("peers", len(resp.Peers)))
+
+	return nil
+}
+
+// deviceNamespaces returns network namespaces of stored devices by their names,
+// so the link backend finds interfaces left in them by the previous run.
+func deviceNamespaces(ctx context.Context, deviceRepo *devicerepo.DeviceRepo) (map[string]string, error) {
+	devices, err := deviceRepo.GetAll(ctx, nil, 0, 0, "")
+	if err != nil {
+		return nil, fmt.Errorf("device namespaces: %w", err)
+	}
+
+	namespaces := make(map[string]string)
+
+	for _, dev := range devices {
+		if dev.Netns != "" {
+			namespaces[dev.Name] = dev.Netns
+		}
+	}
+
+	return namespaces, nil
+}
+
 func main() {
 	flag.Parse()
 
@@ -81,6 +207,13 @@ func main() {
 	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM, syscall.SIGHUP)
 	defer stop()
 
+	keyCipher, err := secrets.LoadCipher(cfg.MasterKey, cfg.MasterKeyFile)
+	logErrorAndExit(err)
+
+	if keyCipher == nil {
+		logger.Warn("master key is not set, private and preshared keys are stored unencrypted")
+	}
+
 	mdb, err := sql.Open("pgx", database.GetConnectionString(cfg))
 	logErrorAndExit(err)
 	err = goose.Up(mdb, "migrations")
@@ -88,19 +221,81 @@ func main() {
 
 	db, err := database.New(cfg)
 	logErrorAndExit(err)
-	deviceRepo := devicerepo.New(db)
-	peerRepo := peerrepo.New(db)
+	deviceRepo := devicerepo.New(db, keyCipher)
+	peerRepo := peerrepo.New(db, keyCipher)
+
+	if flag.Arg(0) == "rotate-master-key" {
+		err = rotateMasterKey(ctx, logger, db, deviceRepo, peerRepo, cfg)
+		logErrorAndExit(err)
+		return
+	}
+
+	apiKeyRepo := apikeyrepo.New(db)
+	auditRepo := auditrepo.New(db)
 
 	wgclient, err := wgctrl.New()
 	logErrorAndExit(err)
 
-	deviceService := deviceservice.NewDeviceService(logger, wgclient, deviceRepo, peerRepo)
+	auditService := auditservice.NewAuditService(logger, auditRepo)
+
+	var backend app.InterfaceBackend
+
+	switch cfg.InterfaceBackend {
+	case "wg-quick":
+		backend = wgquick.New(logger, wgclient)
+	case "netlink":
+		namespaces, err := deviceNamespaces(ctx, deviceRepo)
+		logErrorAndExit(err)
+
+		linkBackend := wglink.New(logger, wgclient, namespaces)
+		defer linkBackend.Close()
+
+		backend = linkBackend
+	case "userspace":
+		namespaces, err := deviceNamespaces(ctx, deviceRepo)
+		logErrorAndExit(err)
+
+		userspaceBackend := wglink.NewUserspace(logger, wgclient, namespaces)
+		defer userspaceBackend.Close()
+
+		backend = userspaceBackend
+	case "netstack":
+		netstackBackend := wgnetstack.New(logger, wgclient)
+		defer netstackBackend.Close()
+
+		backend = netstackBackend
+	default:
+		logErrorAndExit(fmt.Errorf("unknown interface backend %s", cfg.InterfaceBackend))
+	}
+
+	deviceService := deviceservice.NewDeviceService(logger, backend, deviceRepo, peerRepo, auditService)
+
+	// import is run before syncing devices, so interfaces are not restarted
+	if flag.Arg(0) == "import-device" {
+		err = importDevice(ctx, logger, deviceService, flag.Args()[1:])
+		logErrorAndExit(err)
+		return
+	}
+
 	err = deviceService.SyncDevices(ctx)
 	logErrorAndExit(err)
 
-	peerService := peerservice.NewPeerService(logger, deviceService, deviceRepo, peerRepo)
+	if cfg.ReconcileInterval > 0 {
+		go deviceService.RunReconciler(ctx, cfg.ReconcileInterval, cfg.ReconcileDriftOnly)
+	}
+
+	go deviceService.RunKeyCutover(ctx, cfg.SchedulerInterval)
 
-	server, err := server.NewServer(ctx, logger, peerService, deviceService, cfg)
+	peerService := peerservice.NewPeerService(logger, deviceService, deviceRepo, peerRepo, auditService)
+	go peerService.RunScheduler(ctx, cfg.SchedulerInterval)
+
+	apiKeyService := apikeyservice.NewAPIKeyService(logger, apiKeyRepo)
+
+	peerCollector := metrics.NewPeerCollector(logger, deviceService, deviceRepo, peerRepo)
+
+	server, err := server.NewServer(
+		ctx, logger, peerService, deviceService, apiKeyService, auditService, peerRepo, metrics.New(peerCollector), cfg,
+	)
 	logErrorAndExit(err)
 
 	server.Run(ctx, stop)
@@ -0,0 +1,90 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+	"golang.zx2c4.com/wireguard/wgctrl/wgtypes"
+)
+
+func init() {
+	goose.AddMigrationContext(upPeerPublicKey, downPeerPublicKey)
+}
+
+func upPeerPublicKey(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.Exec("ALTER TABLE peer ADD COLUMN IF NOT EXISTS public_key TEXT;")
+	if err != nil {
+		return err
+	}
+
+	rows, err := tx.Query("SELECT id, private_key FROM peer WHERE public_key IS NULL;")
+	if err != nil {
+		return err
+	}
+
+	publicKeys := make(map[string]string)
+
+	for rows.Next() {
+		var id, privateKey string
+
+		if err := rows.Scan(&id, &privateKey); err != nil {
+			rows.Close()
+			return err
+		}
+
+		key, err := wgtypes.ParseKey(privateKey)
+		if err != nil {
+			rows.Close()
+			return err
+		}
+
+		publicKeys[id] = key.PublicKey().String()
+	}
+	rows.Close()
+
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for id, publicKey := range publicKeys {
+		if _, err := tx.Exec("UPDATE peer SET public_key = $1 WHERE id = $2;", publicKey, id); err != nil {
+			return err
+		}
+	}
+
+	_, err = tx.Exec(`
+			ALTER TABLE peer
+				ALTER COLUMN public_key SET NOT NULL,
+				ALTER COLUMN private_key DROP NOT NULL,
+				ALTER COLUMN private_key SET DEFAULT '',
+				ADD CONSTRAINT peer_public_key_device_id_key UNIQUE (public_key, device_id);
+		`,
+	)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func downPeerPublicKey(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.Exec("DELETE FROM peer WHERE private_key IS NULL OR private_key = '';")
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+			ALTER TABLE peer
+				DROP CONSTRAINT IF EXISTS peer_public_key_device_id_key,
+				ALTER COLUMN private_key DROP DEFAULT,
+				ALTER COLUMN private_key SET NOT NULL,
+				DROP COLUMN IF EXISTS public_key;
+		`,
+	)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
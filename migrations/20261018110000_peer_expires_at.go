@@ -0,0 +1,40 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upPeerExpiresAt, downPeerExpiresAt)
+}
+
+func upPeerExpiresAt(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.Exec("ALTER TABLE peer ADD COLUMN IF NOT EXISTS expires_at TIMESTAMPTZ;")
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec("CREATE INDEX IF NOT EXISTS peer_expires_at_idx ON peer (expires_at) WHERE is_enabled;")
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func downPeerExpiresAt(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.Exec("DROP INDEX IF EXISTS peer_expires_at_idx;")
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec("ALTER TABLE peer DROP COLUMN IF EXISTS expires_at;")
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
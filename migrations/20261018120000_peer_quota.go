@@ -0,0 +1,50 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upPeerQuota, downPeerQuota)
+}
+
+func upPeerQuota(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.Exec(`
+			ALTER TABLE peer
+				ADD COLUMN IF NOT EXISTS quota_bytes          BIGINT NOT NULL DEFAULT 0,
+				ADD COLUMN IF NOT EXISTS quota_period         TEXT NOT NULL DEFAULT 'total',
+				ADD COLUMN IF NOT EXISTS usage_receive_bytes  BIGINT NOT NULL DEFAULT 0,
+				ADD COLUMN IF NOT EXISTS usage_transmit_bytes BIGINT NOT NULL DEFAULT 0,
+				ADD COLUMN IF NOT EXISTS last_receive_bytes   BIGINT NOT NULL DEFAULT 0,
+				ADD COLUMN IF NOT EXISTS last_transmit_bytes  BIGINT NOT NULL DEFAULT 0,
+				ADD COLUMN IF NOT EXISTS usage_period_start   TIMESTAMPTZ NOT NULL DEFAULT now();
+		`,
+	)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func downPeerQuota(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.Exec(`
+			ALTER TABLE peer
+				DROP COLUMN IF EXISTS quota_bytes,
+				DROP COLUMN IF EXISTS quota_period,
+				DROP COLUMN IF EXISTS usage_receive_bytes,
+				DROP COLUMN IF EXISTS usage_transmit_bytes,
+				DROP COLUMN IF EXISTS last_receive_bytes,
+				DROP COLUMN IF EXISTS last_transmit_bytes,
+				DROP COLUMN IF EXISTS usage_period_start;
+		`,
+	)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
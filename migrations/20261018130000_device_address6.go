@@ -0,0 +1,40 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upDeviceAddress6, downDeviceAddress6)
+}
+
+func upDeviceAddress6(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.Exec("ALTER TABLE device ADD COLUMN IF NOT EXISTS address6 INET;")
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func downDeviceAddress6(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.Exec(`
+			DELETE FROM peer_address
+			WHERE Family(address) = 6
+				AND device_id IN (SELECT id FROM device WHERE address6 IS NOT NULL);
+		`,
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec("ALTER TABLE device DROP COLUMN IF EXISTS address6;")
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,40 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upClientAllowedIPs, downClientAllowedIPs)
+}
+
+func upClientAllowedIPs(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.Exec("ALTER TABLE device ADD COLUMN IF NOT EXISTS client_allowed_ips TEXT NOT NULL DEFAULT '';")
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec("ALTER TABLE peer ADD COLUMN IF NOT EXISTS client_allowed_ips TEXT NOT NULL DEFAULT '';")
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func downClientAllowedIPs(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.Exec("ALTER TABLE device DROP COLUMN IF EXISTS client_allowed_ips;")
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec("ALTER TABLE peer DROP COLUMN IF EXISTS client_allowed_ips;")
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
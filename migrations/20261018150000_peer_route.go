@@ -0,0 +1,42 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upPeerRoute, downPeerRoute)
+}
+
+func upPeerRoute(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.Exec(`
+			CREATE TABLE IF NOT EXISTS peer_route
+			(
+				id        UUID DEFAULT Gen_random_uuid() PRIMARY KEY,
+				peer_id   UUID NOT NULL,
+				device_id UUID NOT NULL,
+				route     CIDR NOT NULL,
+				FOREIGN KEY (peer_id) REFERENCES peer (id) ON DELETE CASCADE,
+				FOREIGN KEY (device_id) REFERENCES device (id) ON DELETE CASCADE,
+				UNIQUE(route, device_id)
+			);
+		`,
+	)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func downPeerRoute(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.Exec("DROP TABLE IF EXISTS peer_route;")
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,44 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upAPIKey, downAPIKey)
+}
+
+func upAPIKey(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.Exec(`
+			CREATE TABLE IF NOT EXISTS api_key
+			(
+				id           UUID DEFAULT Gen_random_uuid() PRIMARY KEY,
+				name         TEXT NOT NULL,
+				role         TEXT NOT NULL,
+				device_ids   TEXT NOT NULL DEFAULT '',
+				salt         BYTEA NOT NULL,
+				hash         BYTEA NOT NULL,
+				created_at   TIMESTAMPTZ NOT NULL DEFAULT now(),
+				last_used_at TIMESTAMPTZ,
+				revoked_at   TIMESTAMPTZ
+			);
+		`,
+	)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func downAPIKey(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.Exec("DROP TABLE IF EXISTS api_key;")
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,46 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upAuditRecord, downAuditRecord)
+}
+
+func upAuditRecord(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.Exec(`
+			CREATE TABLE IF NOT EXISTS audit_record
+			(
+				id          UUID DEFAULT Gen_random_uuid() PRIMARY KEY,
+				actor       TEXT NOT NULL,
+				action      TEXT NOT NULL,
+				entity_type TEXT NOT NULL,
+				entity_id   UUID NOT NULL,
+				changes     JSONB NOT NULL DEFAULT '{}',
+				created_at  TIMESTAMPTZ NOT NULL DEFAULT now()
+			);
+
+			CREATE INDEX IF NOT EXISTS audit_record_created_at_idx ON audit_record (created_at);
+			CREATE INDEX IF NOT EXISTS audit_record_entity_idx ON audit_record (entity_type, entity_id);
+			CREATE INDEX IF NOT EXISTS audit_record_actor_idx ON audit_record (actor);
+		`,
+	)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func downAuditRecord(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.Exec("DROP TABLE IF EXISTS audit_record;")
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,40 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upDevicePendingKey, downDevicePendingKey)
+}
+
+func upDevicePendingKey(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.Exec(`
+			ALTER TABLE device
+				ADD COLUMN IF NOT EXISTS pending_private_key TEXT NOT NULL DEFAULT '',
+				ADD COLUMN IF NOT EXISTS key_cutover_at TIMESTAMPTZ;
+		`,
+	)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func downDevicePendingKey(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.Exec(`
+			ALTER TABLE device
+				DROP COLUMN IF EXISTS key_cutover_at,
+				DROP COLUMN IF EXISTS pending_private_key;
+		`,
+	)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
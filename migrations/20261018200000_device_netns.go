@@ -0,0 +1,38 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upDeviceNetns, downDeviceNetns)
+}
+
+func upDeviceNetns(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.Exec(`
+			ALTER TABLE device
+				ADD COLUMN IF NOT EXISTS netns TEXT NOT NULL DEFAULT '';
+		`,
+	)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func downDeviceNetns(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.Exec(`
+			ALTER TABLE device
+				DROP COLUMN IF EXISTS netns;
+		`,
+	)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -13,4 +13,12 @@ openssl req -newkey rsa:4096 -nodes -keyout server-key.pem -out server-req.pem -
 openssl x509 -req -in server-req.pem -days 60 -CA ca-cert.pem -CAkey ca-key.pem -CAcreateserial -out server-cert.pem -extfile server-ext.cnf
 
 echo "Server's signed certificate"
-openssl x509 -in server-cert.pem -noout -text
\ No newline at end of file
+openssl x509 -in server-cert.pem -noout -text
+# 4. Generate client's private key and certificate signed by the same CA for mutual TLS,
+# organizational unit sets the role and URI SANs (urn:wg-grpc-api:device:<device_id>) limit it to devices
+openssl req -newkey rsa:4096 -nodes -keyout client-key.pem -out client-req.pem -subj "/C=RU/ST=Moscow/L=Moscow/OU=admin/CN=automation"
+
+openssl x509 -req -in client-req.pem -days 60 -CA ca-cert.pem -CAkey ca-key.pem -CAcreateserial -out client-cert.pem
+
+echo "Client's signed certificate"
+openssl x509 -in client-cert.pem -noout -text